@@ -1,10 +1,10 @@
 create table A
-create index b on A#alter table A
-alter table A foo#alter table A
-alter table A rename to B#rename table A B
-rename table A to B#rename table A B
+create index b on A#alter table A add index b
+alter table A foo
+alter table A rename to B#rename table A to B
+rename table A to B#rename table A to B
 drop table B
-drop index b on A#alter table A
+drop index B on A#alter table A drop index b
 select a from B
 select A as B from C#select a as b from C
 select B.* from c
@@ -21,6 +21,6 @@ select VALUES(B, C) from b#select values(b, c) from b
 select * from b use index (A)#select * from b use index (a)
 insert into A(A, B) values (1, 2)#insert into A(a, b) values (1, 2)
 CREATE TABLE A#create table A
-create view A#create table a
-alter view A#alter table a
-drop view A#drop table a
+create view A#create view a
+alter view A#alter view a
+drop view A#drop view a
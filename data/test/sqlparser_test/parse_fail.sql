@@ -4,3 +4,92 @@ select : from t#syntax error at position 9 near :
 select 078 from t#syntax error at position 11 near 078
 select 'aa\#syntax error at position 12 near aa
 select 'aa#syntax error at position 12 near aa
+select * from t order by a nulls middle#expecting first or last at position 40 near middle
+select * from t order by a desc foo last#expecting nulls at position 41 near last
+create table a (a int foo)#unexpected column attribute foo at position 27 near )
+create table a (a int comment 5)#unexpected column attribute comment at position 32 near 5
+create table a (a int invisible 'x')#unexpected column attribute invisible at position 36 near x
+create table a (a int b key)#unexpected column attribute b at position 28 near key
+create table a (a)#syntax error at position 19 near )
+create table a (a foo('x'))#expecting enum at position 27 near )
+create table a (a enum())#syntax error at position 25 near )
+create table a (a int, foo key (a))#unexpected index type foo at position 36 near )
+create table a (a int, primary key p (a))#unexpected index type primary at position 42 near )
+create table a (a int, key k (a) foo 'x')#unexpected index option foo at position 41 near x
+create table a (a int) character foo = 1#expecting character set at position 37 near foo
+create table a (a int) default engine = x#unexpected default engine at position 42 near x
+create table t (a int stored)#unexpected column attribute stored at position 30 near )
+create table t (a int, constraint foo key (a))#expecting primary key at position 47 near )
+create table t (a int, foreign key (a) references u(id) on delete nothing)#unexpected reference option nothing at position 75 near )
+create table t (a int generated sometimes as (1))#expecting generated always at position 49 near )
+reset foo#unexpected reset target foo at position 11 near 
+reset query foo#expecting query cache at position 16 near foo
+insert into a set a = 1 values (1)#syntax error at position 31 near values
+insert into a(a) set a = 1#syntax error at position 21 near set
+insert into a values (1) as new as old#syntax error at position 35 near as
+insert into a values (1) on duplicate key update b = 1 as new#syntax error at position 58 near as
+insert into a set a = 1 on duplicate key update#syntax error at position 49 near 
+insert into a values (1) as#syntax error at position 29 near 
+select a.b.c.d from t#syntax error at position 14 near .
+select a from t into foo '/tmp/x'#expecting outfile or dumpfile at position 34 near /tmp/x
+select a from t into outfile#syntax error at position 30 near 
+select a from t into dumpfile 'x' lines terminated by 'y' for update#unexpected options for dumpfile at position 62 near for
+select a into @x from t into @y#syntax error at position 29 near into
+select a limit 1 into @x from t#order by and limit must follow from at position 33 near 
+select a from t procedure#syntax error at position 27 near 
+alter table a convert to foo set x#expecting character set at position 36 near 
+alter table a drop foo key#expecting primary key at position 27 near key
+select a from t where a := 1#syntax error at position 27 near :=
+select a from t where 5 member (doc)#syntax error at position 33 near (
+select a->b from t#syntax error at position 12 near b
+select a at zone time 'UTC' from t#expecting time zone at position 28 near UTC
+select a at time zone b from t#syntax error at position 24 near b
+select a over (partition by b) from t#syntax error at position 14 near over
+select sum(a) over (partitio by b) from t#syntax error at position 32 near by
+select sum(a) over (rows current foo) from t#unexpected frame bound at position 37 near foo
+select sum(a) over (rows between 1 following) from t#syntax error at position 46 near )
+lock tables t reed, u write#unexpected lock type reed at position 20 near ,
+lock tables t read remote, u write#unexpected lock type read remote at position 27 near ,
+lock tables t as a read remote#unexpected lock type read remote at position 31 near remote
+lock tabels t read#expecting tables at position 12 near tabels
+unlock tables t#syntax error at position 16 near t
+explain for conection 12#expecting connection at position 25 near 12
+explain for connection a#syntax error at position 25 near a
+explain partitions for connection 1#syntax error at position 23 near for
+show vitess_shards like a#syntax error at position 26 near a
+select next val for s#expecting value at position 22 near s
+select next value for s union select 1 from t#syntax error at position 30 near union
+set a = default + 1#syntax error at position 18 near +
+set foo a = 1#expecting scope at position 12 near =
+set names utf8 collate 5#syntax error at position 25 near 5
+set character set utf8 collate x#syntax error at position 31 near collate
+set charset utf8 collate x#expecting names at position 27 near x
+set global @a = 1#unexpected scope for variable at position 16 near =
+set session @@global.a = 1#unexpected scope for variable at position 25 near =
+select * from a, b where a.id = b.id(+)#the (+) outer join marker is not supported, use LEFT JOIN at position 40 near (+)
+select * from a, b where a.id ( + ) = b.id#the (+) outer join marker is not supported, use LEFT JOIN at position 36 near (+)
+select (+) from t#syntax error at position 11 near (+)
+replace into t values (1) on duplicate key update a = 2#on duplicate key update is not allowed with replace at position 39 near duplicate
+replace into t set a = 1 on duplicate key update a = 2#on duplicate key update is not allowed with replace at position 38 near duplicate
+insert quickly into t values (1)#expecting insert modifier at position 15 near quickly
+insert ignore low_priority into t values (1)#syntax error at position 27 near low_priority
+explain explain select 1 from t#cannot explain an explain statement at position 16 near explain
+explain format=xml select 1 from t#unexpected format xml at position 19 near xml
+explain output=json select 1 from t#expecting format at position 20 near json
+explain extend select 1 from t#unexpected explain extend at position 22 near select
+begin transaction#expecting work at position 18 near transaction
+commit foo#expecting work at position 11 near foo
+use a.b#syntax error at position 7 near .
+use select#syntax error at position 11 near select
+create database a.b#syntax error at position 19 near .
+select 1 from (select 1 from t) where a = 1#every derived table must have its own alias at position 38 near where
+select 1 from (select 1 from (select 1 from t) join u) as d#every derived table must have its own alias at position 52 near join
+select * from t where a = any (1)#syntax error at position 33 near 1
+select * from t where not (select 1 from u) and a = 1#expecting EXISTS before the subquery negated by NOT at position 48 near and
+select 1 where 1 = 1#syntax error at position 15 near where
+select 1 for update#syntax error at position 13 near for
+select 1 group by a#syntax error at position 15 near group
+select * from t lock in share mode nowait#syntax error at position 42 near nowait
+select * from t ignore index () where a = 1#expecting index names at position 32 near )
+select * from t use index for update (a)#syntax error at position 37 near update
+select * from t where a and b = 1#syntax error at position 28 near and
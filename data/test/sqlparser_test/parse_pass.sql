@@ -17,12 +17,40 @@ select 1 /* drop this comment */ from t#select 1 from t
 select /* union */ 1 from t union select 1 from t
 select /* double union */ 1 from t union select 1 from t union select 1 from t
 select /* union all */ 1 from t union all select 1 from t
+select /* union distinct */ 1 from t union distinct select 1 from t
+select /* except */ 1 from t except select 1 from t
+select /* except all */ 1 from t except all select 1 from t
+select /* minus */ 1 from t minus select 1 from t
+select /* intersect */ 1 from t intersect select 1 from t
+select /* intersect distinct */ 1 from t intersect distinct select 1 from t
+select /* intersect precedence */ 1 from t union select 1 from t intersect select 1 from t
+(select /* paren intersect */ 1 from t union select 1 from t) intersect select 1 from t
+select /* mixed union */ 1 from t union all select 1 from t union distinct select 1 from t union select 1 from t
+(select /* paren union */ a from t order by a limit 10) union (select a from u) order by a limit 5#(select /* paren union */ a from t order by a asc limit 10) union (select a from u) order by a asc limit 5
+select /* paren union operand */ a from t union (select a from u limit 1)
+((select /* nested paren union */ a from t) union (select b from u)) union all select c from v for update
 select /* minus */ 1 from t minus select 1 from t
 select /* except */ 1 from t except select 1 from t
 select /* intersect */ 1 from t intersect select 1 from t
 select /* distinct */ distinct 1 from t
 select /* for update */ 1 from t for update
 select /* lock in share mode */ 1 from t lock in share mode
+select /* for update nowait */ 1 from t for update nowait
+select /* for update skip locked */ 1 from t FOR UPDATE SKIP LOCKED#select /* for update skip locked */ 1 from t for update skip locked
+select /* for share */ 1 from t for share
+select /* for share skip locked */ 1 from t for share skip locked
+select /* partition */ 1 from t partition (p2023_11) where a = 1
+select /* partitions */ 1 from t partition (p0, p1)
+select /* partition before alias */ 1 from t partition (p0) x#select /* partition before alias */ 1 from t partition (p0) as x
+select /* partition after alias */ 1 from d.t as x partition (p0) join u y partition (p1) on x.a = y.a#select /* partition after alias */ 1 from d.t partition (p0) as x join u partition (p1) as y on x.a = y.a
+select /* into outfile */ 1 from t into outfile '/tmp/out'
+select /* into dumpfile */ 1 from t limit 1 into DUMPFILE '/tmp/dump' for update#select /* into dumpfile */ 1 from t limit 1 into dumpfile '/tmp/dump' for update
+select /* procedure */ 1 from t procedure analyse()
+select /* procedure args */ 1 from t limit 1 procedure analyse(10, 2000) into outfile 'x'
+select /* into outfile options */ a, b from t into outfile '/tmp/t.csv' fields terminated by ',' optionally enclosed by '"' lines terminated by '\n' for update#select /* into outfile options */ a, b from t into outfile '/tmp/t.csv' fields terminated by ',' optionally enclosed by '\"' lines terminated by '\n' for update
+select /* into variables */ a, b from t where c = 1 into @a, @B
+select /* into before from */ a, b into @a, @b from t where c = 1 for update#select /* into before from */ a, b from t where c = 1 into @a, @b for update
+select /* outfile before from */ a into outfile '/tmp/out' fields terminated by ',' from t#select /* outfile before from */ a from t into outfile '/tmp/out' fields terminated by ','
 select /* select list */ 1, 2 from t
 select /* * */ * from t
 select /* column alias */ a b from t#select /* column alias */ a as b from t
@@ -40,10 +68,15 @@ select /* parenthessis in table list 2 */ 1 from t1, (t2)
 select /* use */ 1 from t1 use index (a) where b = 1
 select /* use */ 1 from t1 as t2 use index (a), t3 use index (b) where b = 1
 select /* force */ 1 from t1 as t2 force index (a), t3 force index (b) where b = 1
+select /* ignore */ 1 from t1 ignore key (a, b) where b = 1#select /* ignore */ 1 from t1 ignore index (a, b) where b = 1
+select /* hint scopes */ 1 from t1 use index for order by (a) ignore index for group by (b) force index for join (primary)
+select /* empty hint */ 1 from t1 as t2 use index ()
 select /* table alias */ 1 from t t1#select /* table alias */ 1 from t as t1
 select /* table alias with as */ 1 from t as t1
 select /* join */ 1 from t1 join t2
 select /* straight_join */ 1 from t1 straight_join t2
+select /* straight_join on */ 1 from t1 STRAIGHT_JOIN t2 on t1.a = t2.a join t3 on t2.b = t3.b#select /* straight_join on */ 1 from t1 straight_join t2 on t1.a = t2.a join t3 on t2.b = t3.b
+select /* non-reserved keywords */ begin, t.rows from unlock as t where next = 1#select /* non-reserved keywords */ `begin`, t.`rows` from `unlock` as t where `next` = 1
 select /* left join */ 1 from t1 left join t2
 select /* left outer join */ 1 from t1 left outer join t2#select /* left outer join */ 1 from t1 left join t2
 select /* right join */ 1 from t1 right join t2
@@ -53,7 +86,27 @@ select /* cross join */ 1 from t1 cross join t2
 select /* natural join */ 1 from t1 natural join t2
 select /* join on */ 1 from t1 join t2 on a = b
 select /* s.t */ 1 from s.t
-select /* select in from */ 1 from (select 1 from t)
+select /* no from */ 1
+select /* sql_calc_found_rows */ sql_calc_found_rows * from t limit 10
+select /* options */ SQL_NO_CACHE high_priority straight_join a from t#select /* options */ sql_no_cache high_priority straight_join a from t
+select /* options after distinct */ distinct sql_buffer_result sql_small_result a from t
+select /* options before distinct */ sql_cache distinct a from t#select /* options before distinct */ distinct sql_cache a from t
+select /* no from bind var */ :v1 + 1#select /* no from bind var */ :v1+1
+select /* no from function */ now() limit 1
+select /* from dual */ 1 from dual
+select /* select in from */ 1 from (select 1 from t) as t
+select /* row constructor */ 1 from t where (a, b) = (1, 2)
+select /* row constructor in */ 1 from t where (a, b) in ((1,2), (:c, :d))#select /* row constructor in */ 1 from t where (a, b) in ((1, 2), (:c, :d))
+select /* row constructor subquery */ 1 from t where (a, b) in (select c, d from u)
+select /* not exists */ 1 from t where not exists (select 1 from u where u.a = t.a)
+select /* not exists and */ 1 from t where a = 1 and not exists (select 1 from u) or NOT EXISTS (select 1 from v)#select /* not exists and */ 1 from t where a = 1 and not exists (select 1 from u) or not exists (select 1 from v)
+select /* any */ 1 from t where a > any (select a from u)
+select /* some */ 1 from t where a = SOME (select a from u union select b from v)#select /* some */ 1 from t where a = some (select a from u union select b from v)
+select /* all */ 1 from t where a <> all (select a from u)
+select /* scalar subquery */ a, (select max(b) from u where u.a = t.a) as maxb from t
+select /* scalar subquery in function */ coalesce((select a from u union select b from v), 1) from t
+select /* doubly parenthesized subquery */ ((select a from u)) from t#select /* doubly parenthesized subquery */ (select a from u) from t
+select /* nested derived tables */ d.a from (select t.a from (select a from x where a > 5) as t join y on t.a = y.a) as d
 select /* where */ 1 from t where a = b
 select /* and */ 1 from t where a = b and a = c
 select /* or */ 1 from t where a = b or a = c
@@ -65,6 +118,18 @@ select /* in select */ 1 from t where a in (select 1 from t)
 select /* not in */ 1 from t where a not in (b, c)
 select /* like */ 1 from t where a like b
 select /* not like */ 1 from t where a not like b
+select /* member of */ 1 from t where 5 MEMBER OF (doc->'$.ids')#select /* member of */ 1 from t where 5 member of (doc->'$.ids')
+select /* json extract */ doc->'$.a', doc->>'$.b' from t where 'x' member of (tags)
+select /* at time zone */ a AT TIME ZONE 'UTC' from t#select /* at time zone */ a at time zone 'UTC' from t
+select /* at time zone */ convert_tz(a, 'x', 'y') at time zone 'Europe/Paris' as b from t where a at time zone 'UTC' > :c
+select /* window */ sum(col) over (partition by grp order by id) from t#select /* window */ sum(col) over (partition by grp order by id asc) from t
+select /* window */ count(*) over (), avg(a) over (order by b desc, c asc), max(a) over (partition by a, b) as m from t
+select /* frame */ row_number() over (partition by a order by b desc rows between unbounded preceding and current row) from t
+select /* frame */ sum(a) over (order by b asc range between 1 preceding and :n following), sum(a) over (rows unbounded preceding) from t
+SELECT /* frame */ SUM(a) OVER (ROWS CURRENT ROW) FROM t#select /* frame */ sum(a) over (rows current row) from t
+select /* named window */ sum(x) over w from t window w as (partition by a)
+select /* named windows */ sum(x) over w2, avg(x) over (w1 rows unbounded preceding) from t group by c having d > 1 window w1 as (partition by a), w2 as (w1 order by b asc) order by c asc limit 1
+SELECT /* named window */ SUM(x) OVER (W) FROM t WINDOW W AS ()#select /* named window */ sum(x) over w from t window w as ()
 select /* between */ 1 from t where a between b and c
 select /* not between */ 1 from t where a not between b and c
 select /* is null */ 1 from t where a is null
@@ -95,9 +160,14 @@ select /* empty function */ 1 from t where a = b()
 select /* function with 1 param */ 1 from t where a = b(c)
 select /* function with many params */ 1 from t where a = b(c, d)
 select /* if as func */ 1 from t where a = if(b)
+select /* conditional funcs */ if(a > 1, b, c), ifnull(a, 0), nullif(a, b), coalesce(a, b, 'c') from t
+select /* conditional funcs */ 1 from t where if(a = 1 and b = 2, c, d) = 1 and ifnull(a, 0) > 1 and nullif(a, b) is null and coalesce(a, b, c) in (1, 2)
+select /* if with subquery */ if(a in (select b from u), 1, 0) from t where if(exists (select 1 from u), 1, 0) = 1
 select /* function with distinct */ count(distinct a) from t
 select /* a */ a from t
 select /* a.b */ a.b from t
+select /* a.b.c */ a.b.c from t
+select /* a.b.c in where */ 1 from a.b join c.d on a.b.id = c.d.id where a.b.c = 1
 select /* string */ 'a' from t
 select /* double quoted string */ "a" from t#select /* double quoted string */ 'a' from t
 select /* quote quote in string */ 'a''a' from t#select /* quote quote in string */ 'a\'a' from t
@@ -119,11 +189,53 @@ select /* hex caps */ 0xF0 from t
 select /* float */ 0.1 from t
 select /* group by */ 1 from t group by a
 select /* having */ 1 from t having a = b
+select /* having subquery */ a, count(*) from t group by a having count(*) > (select avg(c) from t2)
 select /* simple order by */ 1 from t order by a#select /* simple order by */ 1 from t order by a asc
 select /* order by asc */ 1 from t order by a asc
 select /* order by desc */ 1 from t order by a desc
+select /* order by collate */ 1 from t order by a collate utf8_bin#select /* order by collate */ 1 from t order by a collate utf8_bin asc
+select /* order by nulls */ 1 from t order by a asc nulls first, b nulls last#select /* order by nulls */ 1 from t order by a asc nulls first, b asc nulls last
+select /* order by collate desc nulls */ 1 from t order by name collate utf8_bin desc nulls last
+select /* collate */ a collate utf8_bin from t where b collate latin1_general_ci = 'x'
 select /* limit a */ 1 from t limit a
 select /* limit a,b */ 1 from t limit a, b
+explain select /* explain */ 1 from t
+explain partitions select /* explain partitions */ 1 from t where a = 1
+explain partitions select /* explain partitions union */ 1 from t union select 1 from u
+explain for connection 12
+EXPLAIN FOR CONNECTION 12#explain for connection 12
+reset master
+reset query cache
+reset slave
+reset replica
+RESET MASTER#reset master
+do sleep(1)
+do 1+1, a = b, f(:a)
+lock tables t read, u write
+lock tables t as t1 read, u write
+lock tables t AS T1 READ LOCAL, d.u u1 low_priority write, v v1 write#lock tables t as T1 read local, d.u as u1 low_priority write, v as v1 write
+LOCK TABLE d.t READ LOCAL, u low_priority write#lock tables d.t read local, u low_priority write
+unlock tables
+analyze table t
+optimize local table t, d.u
+repair NO_WRITE_TO_BINLOG tables t#repair no_write_to_binlog table t
+repair table t, u quick EXTENDED use_frm#repair table t, u quick extended use_frm
+flush privileges
+flush tables t, d.u with read lock
+flush local table t for export#flush local tables t for export
+FLUSH BINARY LOGS, Status#flush binary logs, status
+load data infile '/tmp/x' into table t
+load data local infile '/tmp/x.csv' ignore into table d.t fields terminated by ',' lines terminated by '\n' (a, b, c)
+LOAD DATA INFILE 'x' REPLACE INTO TABLE t COLUMNS TERMINATED BY '\t' OPTIONALLY ENCLOSED BY '\'' ESCAPED BY '\\' LINES STARTING BY 'x' IGNORE 1 ROWS#load data infile 'x' replace into table t fields terminated by '\t' optionally enclosed by '\'' escaped by '\\' lines starting by 'x' ignore 1 lines
+grant select on db.* to 'app'@'%'
+GRANT SELECT, Insert, grant option ON *.* TO 'a'@localhost, b WITH GRANT OPTION#grant select, insert, grant option on *.* to 'a'@localhost, b WITH GRANT OPTION
+grant all privileges on * to 'x'
+revoke replication slave, create temporary tables on d.t from 'r'@'10.0.%'
+UNLOCK TABLE#unlock tables
+show vitess_keyspaces
+SHOW VITESS_SHARDS LIKE '-80%'#show vitess_shards like '-80%'
+select next value for s
+SELECT /* next value */ NEXT VALUE FOR user_seq#select /* next value */ next value for user_seq
 insert /* simple */ into a values (1)
 insert /* a.b */ into a.b values (1)
 insert /* multi-value */ into a values (1, 2)
@@ -133,44 +245,246 @@ insert /* column list */ into a(a, b) values (1, 2)
 insert /* qualified column list */ into a(a, a.b) values (1, 2)
 insert /* select */ into a select b, c from d
 insert /* on duplicate */ into a values (1, 2) on duplicate key update b = values(a), c = d
+insert /* values function */ into a(a, b) values (1, 2), (3, 4) on duplicate key update b = b+VALUES(b), a = values(a.a)#insert /* values function */ into a(a, b) values (1, 2), (3, 4) on duplicate key update b = b+values(b), a = values(a.a)
+insert /* column list on duplicate */ into a(a, b) values (1, 2) on duplicate key update b = 3
+insert /* set */ into a set a = 1, b = 2#insert /* set */ into a(a, b) values (1, 2)
+insert /* set on duplicate */ into a set a = 1, b = 2 on duplicate key update b = 3#insert /* set on duplicate */ into a(a, b) values (1, 2) on duplicate key update b = 3
+insert /* set row alias */ into a set a = 1 as new on duplicate key update b = new.a#insert /* set row alias */ into a(a) values (1) as new on duplicate key update b = new.a
+insert /* row alias */ into a values (1, 2) as new on duplicate key update b = new.a
+insert /* row alias columns */ into a(a, b) values (1, 2), (3, 4) as new(x, y) on duplicate key update b = y
+insert /* row alias no on duplicate */ into a values (1, 2) as new
+insert /* select on duplicate */ into a select b, c from d on duplicate key update b = 3
+insert /* select alias on duplicate */ into a select b, c from d as e on duplicate key update b = 3
 update /* simple */ a set b = 3
 update /* a.b */ a.b set b = 3
+update /* a.b.c */ a.b set a.b.c = 3
 update /* b.c */ a set b.c = 3
 update /* list */ a set b = 3, c = 4
 update /* expression */ a set b = 3+4
 update /* where */ a set b = 3 where a = b
 update /* order */ a set b = 3 order by c desc
 update /* limit */ a set b = 3 limit c
+update /* partition */ a partition (p0) set b = 3
 delete /* simple */ from a
 delete /* a.b */ from a.b
 delete /* where */ from a where a = b
 delete /* order */ from a order by b desc
 delete /* limit */ from a limit b
+delete /* partition */ from a partition (p0, p1) where a = b
 set /* simple */ a = 3
 set /* list */ a = 3, b = 4
-alter ignore table a add foo#alter table a
-alter table a add foo#alter table a
-alter table a alter foo#alter table a
-alter table a change foo#alter table a
-alter table a modify foo#alter table a
-alter table a drop foo#alter table a
-alter table a disable foo#alter table a
-alter table a enable foo#alter table a
-alter table a order foo#alter table a
-alter table a default foo#alter table a
-alter table a discard foo#alter table a
-alter table a import foo#alter table a
-alter table a rename b#rename table a b
-alter table a rename to b#rename table a b
+set /* subquery */ @a = (select max(a) from t), b = 2
+set /* chained */ @a = @b = 3
+set /* assign */ @a := 3#set /* assign */ @a = 3
+set /* chained assign */ @a := @b := 3, c = 4#set /* chained assign */ @a = @b := 3, c = 4
+set /* default */ sql_mode = DEFAULT#set /* default */ sql_mode = default
+set /* default */ @@session.sql_mode = default, @a := @b := default#set /* default */ session sql_mode = default, @a = @b := default
+set /* scope */ global a = 1, b = 2, session c = 3, @d = 4, e = 5#set /* scope */ global a = 1, global b = 2, session c = 3, @d = 4, session e = 5
+set /* local */ LOCAL a = 1#set /* local */ session a = 1
+set /* @@ */ @@a = 1, @@global.b = 2, @@LOCAL.c = 3, d = 4#set /* @@ */ session a = 1, global b = 2, session c = 3, session d = 4
+set /* @@ */ @@global.a = 1, b = 2#set /* @@ */ global a = 1, session b = 2
+set /* names */ names utf8mb4
+set /* names */ NAMES 'utf8' COLLATE utf8_general_ci#set /* names */ names 'utf8' collate utf8_general_ci
+set /* names */ names default
+set /* names */ names 'utf8' collate 'utf8_general_ci'
+set /* character set */ character set utf8
+set /* charset */ charset 'latin1', @a = 1#set /* charset */ character set 'latin1', @a = 1
+set /* transaction */ transaction isolation level repeatable read
+set /* transaction */ SESSION TRANSACTION READ ONLY, ISOLATION LEVEL SERIALIZABLE#set /* transaction */ session transaction isolation level serializable, read only
+set /* transaction */ global transaction isolation level read committed, read write
+set /* transaction */ local transaction read write#set /* transaction */ session transaction read write
+set /* transaction variable */ transaction = 1
+alter ignore table a add foo#alter table a add foo
+alter table a add foo
+alter table a alter foo
+alter table a change foo
+alter table a modify foo
+alter table a drop foo#alter table a drop column foo
+alter table a disable foo
+alter table a enable foo
+alter table a order foo
+alter table a default foo
+alter table a discard foo
+alter table a import foo
+alter table a convert to character set utf8mb4 collate utf8mb4_unicode_ci
+alter table a convert to CHARSET latin1#alter table a convert to character set latin1
+alter ignore table a convert to character set utf8mb4#alter table a convert to character set utf8mb4
+alter table a convert to character set
+alter table a add column b int not null
+alter table a add b varchar(10) default 'x', add fulltext index ft (c, d(10)), modify c bigint, change column d e int#alter table a add column b varchar(10) default 'x', add fulltext index ft (c, d(10)), modify column c bigint, change column d e int
+alter table a add unique key (a)#alter table a add unique index (a)
+alter table a convert to character set utf8, add column x int
+alter table a add column b int,#alter table a add column b int
+alter table t add constraint pk primary key (a), add constraint fk foreign key (a) references u(id)
+alter table t add column b int character set utf8, modify c int default -1#alter table t add column b int character set utf8, modify column c int default -1
+alter table t add column b int after a
+alter table t add column b int first
+alter table t add constraint fk foreign key (a) references u(id)
+alter table a rename b#rename table a to b
+alter table a rename to b#rename table a to b
+alter table a drop b, drop column c, drop index i, drop key k, drop primary key, add primary key (a, b), rename to d#alter table a drop column b, drop column c, drop index i, drop index k, drop primary key, add primary key (a, b), rename to d
+alter table a disable keys, add column b int, engine=InnoDB#alter table a disable keys, add column b int, engine=innodb
+alter table a algorithm=inplace, lock=none, add column c int
+alter table a add column x int, add index i (a) using btree, add column y int
+alter table a partition by range (a) (partition p0 values less than (10), partition p1 values less than maxvalue), add column x int
 create table a
-create table if not exists a#create table a
-create index a on b#alter table b
-create unique index a on b#alter table b
-create unique index a using foo on b#alter table b
-create view a#create table a
-alter view a#alter table a
-drop view a#drop table a
+create table if not exists a
+create temporary table a (b bigint)
+create temporary table if not exists a (b bigint) engine=memory
+create table a (id bigint primary key, name varchar(64) not null default '')
+create table a (id bigint unsigned not null auto_increment primary key, price decimal(10,2), b int(11) zerofill)
+create table a (a int comment 'the a column' invisible, b varchar(10) collate utf8_bin not null comment 'b' visible)
+create table a (a int visible comment 'vis', b point srid 4326 not null)#create table a (a int comment 'vis' visible, b point srid 4326 not null)
+create table a (a int null, b int unique, c int unique key, d timestamp default current_timestamp)#create table a (a int, b int unique key, c int unique key, d timestamp default current_timestamp)
+create table a (a enum('x','y', 'z') not null default 'x', b set('p','q'), c decimal(10, 4), d geometry, e polygon srid 0)#create table a (a enum('x', 'y', 'z') not null default 'x', b set('p', 'q'), c decimal(10,4), d geometry, e polygon srid 0)
+create table a (`key` int) engine=innodb
+create table a (id bigint, name varchar(64) not null default '', primary key (id), unique key name_idx (name(10)), key (name, id), fulltext index ft (name))#create table a (id bigint, name varchar(64) not null default '', primary key (id), unique index name_idx (name(10)), index (name, id), fulltext index ft (name))
+create table a (id bigint, primary key (id), name text) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE utf8mb4_bin auto_increment=5, comment 'x'#create table a (id bigint, name text, primary key (id)) engine=innodb charset=utf8mb4 collate=utf8mb4_bin auto_increment=5 comment='x'
+create table a (a int) default character set = latin1 default collate=latin1_bin row_format compressed#create table a (a int) charset=latin1 collate=latin1_bin row_format=compressed
+create table a (a int) engine=innodb partition by hash(a)
+create table a (a int) partition by hash(a)
+create table t (a int default -1, b decimal(5,2) default +1.5, c int default (a + 1))#create table t (a int default -1, b decimal(5,2) default 1.5, c int default (a+1))
+create table t (a timestamp not null default current_timestamp on update current_timestamp)
+create table t (a timestamp(6) default current_timestamp(6) on update current_timestamp(6), b datetime default now())
+create table t (a varchar(10) character set utf8 collate utf8_bin)
+create table t (a int, constraint pk primary key (a))
+create table t (a int, constraint primary key (a))#create table t (a int, primary key (a))
+create table t (a int, b int, constraint u unique key u_idx (b), constraint unique (a))#create table t (a int, b int, constraint u unique index u_idx (b), unique index (a))
+create table t (a int, b int, foreign key (b) references u(id) on delete cascade)
+create table t (a int, b int, constraint fk foreign key fk_b (b) references d.u (id) on delete set null on update no action)#create table t (a int, b int, constraint fk foreign key fk_b (b) references d.u(id) on delete set null on update no action)
+create table t (a int check (a > 0))
+create table t (a int, check (a > 0), constraint c check (a < 10))
+create table t (a int, b int generated always as (a + 1) stored)#create table t (a int, b int generated always as (a+1) stored)
+create table t (a int, b int as (a * 2) virtual not null)#create table t (a int, b int generated always as (a*2) virtual not null)
+create table a (a int) engine=innodb partition by range (a) (partition p0 values less than (10))
+create table a (a int) engine=innodb, comment 'x' partition by hash(a) partitions 4
+create table a (a int, b geometry, spatial index g (b), primary key (a) using btree, key k (a desc) comment 'k')#create table a (a int, b geometry, spatial index g (b), primary key (a) using btree, index k (a desc) comment 'k')
+create unique index idx_a on t (a, b(10) desc) using btree#alter table t add unique index idx_a (a, b(10) desc) using btree
+create index i using hash on t (a asc) comment 'x'#alter table t add index i (a asc) using hash comment 'x'
+create spatial index i on t (g)#alter table t add spatial index i (g)
+create fulltext index i on t (a, b)#alter table t add fulltext index i (a, b)
+create index i on t (a) algorithm=inplace#alter table t add index i (a), algorithm=inplace
+create index i on t (a) algorithm inplace lock=none comment 'x'#alter table t add index i (a) comment 'x', algorithm=inplace, lock=none
+create index i using btree on t (a) visible#alter table t add index i using btree (a) visible
+create index i on t (a) foo 'x'#alter table t add index i (a) foo 'x'
+create table a engine=innodb#create table a
+create table a like b
+create table if not exists a (like b.c)
+create index a on b#alter table b add index a
+create unique index a on b#alter table b add unique index a
+create unique index a using foo on b#alter table b add unique index a using foo
+create view a
+create view a as select b, c from t
+create view a (x, y) as select b, c from t where d in (select e from u)#create view a(x, y) as select b, c from t where d in (select e from u)
+create or replace view a as select 1 from t with check option
+create or replace view a#create or replace view a
+create algorithm=merge view v as select 1 from t
+create definer='root'@'localhost' view v as select 1 from t
+create or replace algorithm=temptable definer='root'@'%' sql security invoker view v as select 1 from t
+alter view a
+alter view a as select b from t union select c from u with local check option
+drop view a
 drop table a
-drop table if exists a#drop table a
-drop view if exists a#drop table a
-drop index b on a#alter table a
+drop table if exists a
+drop table a, b, c
+drop table t cascade#drop table t
+drop table t restrict#drop table t
+drop temporary table if exists a, b
+drop view if exists a
+drop view if exists a, B#drop view if exists a, b
+create database a
+create database if not exists a default character set utf8mb4#create database if not exists a character set utf8mb4
+create schema A charset = 'latin1', collate latin1_bin#create database A character set latin1 collate latin1_bin
+alter database a default collate = utf8_bin#alter database a collate utf8_bin
+drop database a
+drop schema if exists a#drop database if exists a
+select database(), schema() from dual
+select truncate(a, 2), round(b) from t
+drop index b on a#alter table a drop index b
+drop index `key` on a algorithm=inplace, lock=none#alter table a drop index `key`, algorithm=inplace, lock=none
+drop index b on a algorithm = default lock = shared#alter table a drop index b, algorithm=default, lock=shared
+drop index b on a lock none, foo(1)#alter table a drop index b, lock none, foo(1)
+drop index idx_a on t foo bar#alter table t drop index idx_a, foo bar
+drop index idx_a on t algorithm copy, Foo = 1#alter table t drop index idx_a, algorithm copy, Foo = 1
+with recent as (select * from orders where ts > :v1) select * from recent where region = 'us'
+WITH a (x, y) AS (select 1, 2 from t), b as (select x from a) select * from a join b#with a(x, y) as (select 1, 2 from t), b as (select x from a) select * from a join b
+with c as (select a from t) select a from c union all select a from u
+select * from t where a in (with c as (select a from u) select a from c)
+insert into t with c as (select a from u) select a from c
+WITH RECURSIVE nums AS (select 1 as n from dual union all select n + 1 from nums where n < 10) select * from nums#with recursive nums as (select 1 as n from dual union all select n+1 from nums where n < 10) select * from nums
+select ( + a) from t#select (+a) from t
+select f(+a) from t
+select `café` from `名前`
+select café, 名前x1 from 表#select `café`, `名前x1` from `表`
+select `a b`, `a``b` from t
+select t.`c d` as `e f` from t as `g h`
+select `1a` from t
+select `t 1`.* from `t 1`
+replace into t(a, b) values (1, 2)
+replace /* comment */ into t values (1, 'a'), (2, 'b')
+replace into t set a = 1, b = 2#replace into t(a, b) values (1, 2)
+replace into t(a) select b from u where c = 1
+select replace(a, 'x', 'y') from t
+insert ignore into t values (1)
+insert /* comment */ ignore into t(a) values (1)
+insert low_priority ignore into t(a) values (1) on duplicate key update a = 2
+insert DELAYED into t set a = 1#insert delayed into t(a) values (1)
+insert high_priority into t select * from u
+insert /* partition */ into t partition (p0)(a) values (1)
+truncate table foo
+truncate foo#truncate table foo
+truncate /* comment */ table `select`
+truncate `my table`#truncate table `my table`
+truncate db.foo#truncate table db.foo
+show tables
+SHOW TABLES FROM db LIKE 'a%'#show tables from db like 'a%'
+show tables in db where a = :a#show tables from db where a = :a
+show databases
+show schemas like 'a%'#show databases like 'a%'
+show columns from t
+show fields from t in db#show columns from db.t
+show columns from db.t where `Key` = 'PRI'#show columns from db.t where `key` = 'PRI'
+show variables
+SHOW GLOBAL STATUS LIKE 'Threads%'#show global status like 'Threads%'
+show session variables where variable_name in ('a', 'b')
+show local status where value > :v#show session status where value > :v
+show warnings
+show errors limit 10
+SHOW WARNINGS LIMIT 5, 10#show warnings limit 5, 10
+show count(*) errors
+show processlist
+show engine innodb status
+show full tables from db
+show create table t
+SHOW CREATE TABLE `my db`.`select`#show create table `my db`.`select`
+show create view db.v
+describe t
+desc /* comment */ db.t#describe /* comment */ db.t
+explain t#describe t
+explain db.t a#describe db.t a
+describe t 'a%'
+explain update t set a = 1 where b = 2
+explain delete from t where a in (select b from u)
+explain insert into t values (1)
+explain replace into t(a) select b from u
+explain format=json select * from t
+explain FORMAT = TREE select a from t union select b from u#explain format=tree select a from t union select b from u
+explain partitions update t set a = 1
+explain format = traditional select 1 from t#explain format=traditional select 1 from t
+explain extended select a from t where b = 1
+EXPLAIN ANALYZE select a from t where b in (select c from u)#explain analyze select a from t where b in (select c from u)
+begin
+begin work#begin
+begin /* comment */
+start transaction
+START /* comment */ TRANSACTION WITH CONSISTENT SNAPSHOT, READ ONLY#start /* comment */ transaction with consistent snapshot, read only
+start transaction read write
+commit
+commit /* comment */ work#commit /* comment */
+rollback
+rollback work#rollback
+use mydb
+use `my db`
+USE MyDb#use MyDb
+select * from t use index (a)
+select true, false from t where true and a = 1 or not false
@@ -8,7 +8,12 @@ package sqlparser
 // TODO(sougou): Move some generic functions out of execution.go
 // and router.go into this file.
 
-import "fmt"
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // GetDBName parses the specified DML and returns the
 // db name if it was used to qualify the table name.
@@ -22,6 +27,8 @@ func GetDBName(sql string) (string, error) {
 	switch stmt := statement.(type) {
 	case *Insert:
 		return extractDBName(stmt.Table), nil
+	case *Replace:
+		return extractDBName(stmt.Table), nil
 	case *Update:
 		return extractDBName(stmt.Table), nil
 	case *Delete:
@@ -36,3 +43,1777 @@ func extractDBName(node *Node) string {
 	}
 	return string(node.NodeAt(0).Value)
 }
+
+// ExtractTags returns the key:value tags found in the
+// /* ... */ comments of a statement. A comment like
+// /* app:myapp user:alice */ yields {"app": "myapp", "user": "alice"}.
+// Words that are not of the form key:value are ignored.
+func ExtractTags(stmt Statement) map[string]string {
+	tags := make(map[string]string)
+	for _, comment := range statementComments(stmt) {
+		if !bytes.HasPrefix(comment, []byte("/*")) || !bytes.HasSuffix(comment, []byte("*/")) {
+			continue
+		}
+		body := comment[2 : len(comment)-2]
+		for _, word := range bytes.Fields(body) {
+			sep := bytes.IndexByte(word, ':')
+			if sep <= 0 || sep == len(word)-1 {
+				continue
+			}
+			tags[string(word[:sep])] = string(word[sep+1:])
+		}
+	}
+	return tags
+}
+
+func statementComments(stmt Statement) Comments {
+	switch stmt := stmt.(type) {
+	case *Select:
+		return stmt.Comments
+	case *Union:
+		return statementComments(stmt.First)
+	case *ParenSelect:
+		return statementComments(stmt.Select)
+	case *NextValueFor:
+		return stmt.Comments
+	case *Insert:
+		return stmt.Comments
+	case *Replace:
+		return stmt.Comments
+	case *Update:
+		return stmt.Comments
+	case *Delete:
+		return stmt.Comments
+	case *Set:
+		return stmt.Comments
+	case *Truncate:
+		return stmt.Comments
+	case *Describe:
+		return stmt.Comments
+	case *Begin:
+		return stmt.Comments
+	case *Commit:
+		return stmt.Comments
+	case *Rollback:
+		return stmt.Comments
+	}
+	return nil
+}
+
+// RowArities returns the number of values in each row of
+// an INSERT ... VALUES statement, in order. It's useful when
+// the column list is omitted and the arity is the only indication
+// of the intended columns. A row that is a subquery has an arity
+// of -1. If the values come from a select, RowArities returns nil.
+func (node *Insert) RowArities() []int {
+	values, ok := node.Values.(*Node)
+	if !ok {
+		return nil
+	}
+	rowList := values.NodeAt(0) // VALUES->NODE_LIST
+	arities := make([]int, rowList.Len())
+	for i := 0; i < rowList.Len(); i++ {
+		// NODE_LIST->'('->NODE_LIST
+		if row, ok := rowList.NodeAt(i).At(0).(*Node); ok {
+			arities[i] = row.Len()
+		} else {
+			arities[i] = -1
+		}
+	}
+	return arities
+}
+
+// Validate verifies that all the value rows of an INSERT have
+// the same arity, and that it matches the column list if one
+// was specified.
+func (node *Insert) Validate() error {
+	want := -1
+	if node.Columns != nil {
+		want = len(node.Columns)
+	}
+	for _, arity := range node.RowArities() {
+		if arity == -1 {
+			continue
+		}
+		if want == -1 {
+			want = arity
+			continue
+		}
+		if arity != want {
+			return NewParserError("column count doesn't match value count")
+		}
+	}
+	return nil
+}
+
+// TableNames returns the names of the tables locked by
+// LOCK TABLES as they're written: table or db.table.
+func (node *LockTables) TableNames() []string {
+	names := make([]string, len(node.Tables))
+	for i, table := range node.Tables {
+		names[i] = String(table.Table.Expr)
+	}
+	return names
+}
+
+// TableNames returns the names of the tables changed by
+// the DDL statement, and the names of the tables it reads:
+// the source table of CREATE TABLE ... LIKE, and the
+// tables of a view definition. The names are as they're
+// written: table or db.table.
+func (node *DDLSimple) TableNames() (changed, read []string) {
+	if node.Tables != nil {
+		for _, table := range node.Tables {
+			changed = append(changed, String(table))
+		}
+	} else {
+		changed = []string{String(node.Table)}
+	}
+	if node.OptLike != nil {
+		read = append(read, String(node.OptLike.LikeTable))
+	}
+	ForEachTable(node, func(table *AliasedTableExpr, depth int) {
+		if table.Expr.Type == ID || table.Expr.Type == '.' {
+			read = append(read, String(table.Expr))
+		}
+	})
+	return changed, read
+}
+
+// StampVersion records ver as the schema version of stmt
+// if it's a DDL statement. Other statements are left as is.
+// There's no schema tracker to assign versions yet: the tablet
+// server applies DDLs through DDLParse, so nothing calls it.
+func StampVersion(stmt Statement, ver uint64) {
+	switch stmt := stmt.(type) {
+	case *DDLSimple:
+		stmt.SchemaVersion = ver
+	case *Rename:
+		stmt.SchemaVersion = ver
+	}
+}
+
+// SchemaVersion returns the schema version recorded on
+// stmt by StampVersion, or 0 if there's none.
+func SchemaVersion(stmt Statement) uint64 {
+	switch stmt := stmt.(type) {
+	case *DDLSimple:
+		return stmt.SchemaVersion
+	case *Rename:
+		return stmt.SchemaVersion
+	}
+	return 0
+}
+
+// SetAssignment is an assignment performed by a SET statement.
+// Scope is the scope of the assigned variable, as in SetExpr.
+type SetAssignment struct {
+	Scope []byte
+	Name  string
+	Expr  *Node
+}
+
+// Assignments returns the assignments performed by the SET statement.
+// In a chained assignment like "set @x = @y = 5", every name of the
+// chain is assigned the final expression. The variables assigned
+// inside a chain are user variables, which have no scope.
+func (node *Set) Assignments() []SetAssignment {
+	var assignments []SetAssignment
+	for _, setExpr := range node.Exprs {
+		names := []*Node{setExpr.Name}
+		expr := setExpr.Expr
+		for expr.Type == '=' || expr.Type == ASSIGN {
+			if _, ok := GetColumnName(expr.NodeAt(0)); !ok {
+				break
+			}
+			names = append(names, expr.NodeAt(0))
+			expr = expr.NodeAt(1)
+		}
+		for i, name := range names {
+			assignment := SetAssignment{Name: String(name), Expr: expr}
+			if i == 0 {
+				assignment.Scope = setExpr.Scope
+			}
+			assignments = append(assignments, assignment)
+		}
+	}
+	return assignments
+}
+
+// ColumnRef identifies a column of a table.
+type ColumnRef struct {
+	Table, Column string
+}
+
+// SensitiveColumns returns the columns referenced by the select
+// expressions of stmt that are listed as sensitive in piiMap.
+// piiMap maps table names to their sensitive column names.
+// Unqualified columns are attributed to every table of the FROM
+// clause that lists them, and '*' expands to all the sensitive
+// columns of the referenced tables. The columns of derived tables
+// and common table expressions are traced back to the sensitive
+// columns of the tables they select from.
+func SensitiveColumns(stmt Statement, piiMap map[string][]string) []ColumnRef {
+	sel, ok := stmt.(SelectStatement)
+	if !ok {
+		return nil
+	}
+	var refs []ColumnRef
+	seen := make(map[ColumnRef]bool)
+	for _, col := range piiProjection(sel, piiMap, nil) {
+		for _, ref := range col.refs {
+			if !seen[ref] {
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
+
+// piiColumn is a column of a table or of the result of a select,
+// with the sensitive columns of the base tables it exposes.
+type piiColumn struct {
+	name string
+	refs []ColumnRef
+}
+
+// piiSource is a table of a FROM clause, with the name
+// it can be referenced by and its columns.
+type piiSource struct {
+	alias   string
+	columns []piiColumn
+}
+
+// piiProjection returns the columns of the result of sel. ctes
+// maps the names of the common table expressions in scope to
+// their columns.
+func piiProjection(sel SelectStatement, piiMap map[string][]string, ctes map[string][]piiColumn) []piiColumn {
+	switch sel := sel.(type) {
+	case *Union:
+		ctes = piiWith(sel.With, piiMap, ctes)
+		columns := piiProjection(sel.First, piiMap, ctes)
+		for _, arm := range sel.Arms {
+			for i, col := range piiProjection(arm.Select, piiMap, ctes) {
+				if i < len(columns) {
+					refs := columns[i].refs
+					columns[i].refs = append(refs[:len(refs):len(refs)], col.refs...)
+				}
+			}
+		}
+		return columns
+	case *ParenSelect:
+		return piiProjection(sel.Select, piiMap, ctes)
+	case *Select:
+		ctes = piiWith(sel.With, piiMap, ctes)
+		sources := collectPIISources(sel.From, piiMap, ctes, nil)
+		var columns []piiColumn
+		for _, expr := range sel.SelectExprs {
+			switch expr := expr.(type) {
+			case *StarExpr:
+				for _, source := range sources {
+					if expr.TableName == nil || string(expr.TableName) == source.alias {
+						columns = append(columns, source.columns...)
+					}
+				}
+			case *NonStarExpr:
+				col := piiColumn{name: string(expr.As)}
+				if expr.As == nil {
+					if name, ok := GetColumnName(expr.Expr); ok {
+						col.name = name.Column
+					} else {
+						col.name = String(expr.Expr)
+					}
+				}
+				expr.Expr.visitColumns(func(name ColumnName) {
+					for _, source := range sources {
+						if name.Table != "" && name.Table != source.alias {
+							continue
+						}
+						for _, sourceCol := range source.columns {
+							if strings.EqualFold(sourceCol.name, name.Column) {
+								col.refs = append(col.refs, sourceCol.refs...)
+							}
+						}
+					}
+				}, func(sub SelectStatement) {
+					for _, subCol := range piiProjection(sub, piiMap, ctes) {
+						col.refs = append(col.refs, subCol.refs...)
+					}
+				})
+				columns = append(columns, col)
+			}
+		}
+		return columns
+	}
+	return nil
+}
+
+// piiWith returns ctes extended with the common table
+// expressions of with.
+func piiWith(with *With, piiMap map[string][]string, ctes map[string][]piiColumn) map[string][]piiColumn {
+	if with == nil {
+		return ctes
+	}
+	scope := make(map[string][]piiColumn, len(ctes)+len(with.CTEs))
+	for name, columns := range ctes {
+		scope[name] = columns
+	}
+	for _, cte := range with.CTEs {
+		columns := piiProjection(cte.Subquery, piiMap, scope)
+		for i, expr := range cte.Columns {
+			if expr, ok := expr.(*NonStarExpr); ok && i < len(columns) {
+				columns[i].name = String(expr.Expr)
+			}
+		}
+		scope[string(cte.Name.Value)] = columns
+	}
+	return scope
+}
+
+// collectPIISources appends the tables of tableExprs to sources.
+func collectPIISources(tableExprs TableExprs, piiMap map[string][]string, ctes map[string][]piiColumn, sources []piiSource) []piiSource {
+	for _, tableExpr := range tableExprs {
+		sources = collectPIISource(tableExpr, piiMap, ctes, sources)
+	}
+	return sources
+}
+
+func collectPIISource(tableExpr TableExpr, piiMap map[string][]string, ctes map[string][]piiColumn, sources []piiSource) []piiSource {
+	switch tableExpr := tableExpr.(type) {
+	case *AliasedTableExpr:
+		source := piiSource{alias: string(tableExpr.As)}
+		switch node := tableExpr.Expr; node.Type {
+		case '(':
+			source.columns = piiProjection(node.At(0).(SelectStatement), piiMap, ctes)
+		default:
+			var name string
+			if node.Type == '.' {
+				name = string(node.NodeAt(1).Value)
+			} else {
+				name = string(node.Value)
+			}
+			if tableExpr.As == nil {
+				source.alias = name
+			}
+			if columns, ok := ctes[name]; ok && node.Type == ID {
+				source.columns = columns
+				break
+			}
+			for _, col := range piiMap[name] {
+				source.columns = append(source.columns, piiColumn{name: col, refs: []ColumnRef{{Table: name, Column: col}}})
+			}
+		}
+		return append(sources, source)
+	case *ParenTableExpr:
+		return collectPIISource(tableExpr.Inner, piiMap, ctes, sources)
+	case *JoinTableExpr:
+		sources = collectPIISource(tableExpr.LeftExpr, piiMap, ctes, sources)
+		return collectPIISource(tableExpr.RightExpr, piiMap, ctes, sources)
+	}
+	return sources
+}
+
+// tableAlias is a table referenced in a FROM clause
+// along with the name it can be referenced by.
+type tableAlias struct {
+	alias, db, name string
+}
+
+// collectTableAliases appends the tables referenced by
+// tableExprs to aliases, in the order they appear.
+func collectTableAliases(tableExprs TableExprs, aliases []tableAlias) []tableAlias {
+	for _, tableExpr := range tableExprs {
+		aliases = collectTableAlias(tableExpr, aliases)
+	}
+	return aliases
+}
+
+func collectTableAlias(tableExpr TableExpr, aliases []tableAlias) []tableAlias {
+	switch tableExpr := tableExpr.(type) {
+	case *AliasedTableExpr:
+		n := len(aliases)
+		aliases = collectDMLTableAlias(tableExpr.Expr, aliases)
+		if tableExpr.As != nil && len(aliases) > n {
+			aliases[n].alias = string(tableExpr.As)
+		}
+		return aliases
+	case *ParenTableExpr:
+		return collectTableAlias(tableExpr.Inner, aliases)
+	case *JoinTableExpr:
+		aliases = collectTableAlias(tableExpr.LeftExpr, aliases)
+		return collectTableAlias(tableExpr.RightExpr, aliases)
+	}
+	return aliases
+}
+
+// collectDMLTableAlias appends the table named by node, which is
+// either table or db.table, to aliases.
+func collectDMLTableAlias(node *Node, aliases []tableAlias) []tableAlias {
+	switch node.Type {
+	case ID:
+		return append(aliases, tableAlias{alias: string(node.Value), name: string(node.Value)})
+	case '.':
+		name := string(node.NodeAt(1).Value)
+		return append(aliases, tableAlias{alias: name, db: string(node.NodeAt(0).Value), name: name})
+	}
+	return aliases
+}
+
+// visitColumns calls columnFunc for every column reference within
+// node, and subqueryFunc for every subquery.
+func (node *Node) visitColumns(columnFunc func(ColumnName), subqueryFunc func(SelectStatement)) {
+	if name, ok := GetColumnName(node); ok {
+		columnFunc(name)
+		return
+	}
+	if node.Type == FUNCTION {
+		// The function name is not a column, but its
+		// arguments may contain column references.
+		for _, sub := range node.Sub {
+			if exprs, ok := sub.(SelectExprs); ok {
+				for _, expr := range exprs {
+					if expr, ok := expr.(*NonStarExpr); ok {
+						expr.Expr.visitColumns(columnFunc, subqueryFunc)
+					}
+				}
+			}
+		}
+		return
+	}
+	for _, sub := range node.Sub {
+		switch sub := sub.(type) {
+		case *Node:
+			sub.visitColumns(columnFunc, subqueryFunc)
+		case SelectStatement:
+			subqueryFunc(sub)
+		}
+	}
+}
+
+// ColumnName is a column reference, optionally qualified
+// by its table and database names.
+type ColumnName struct {
+	DB, Table, Column string
+}
+
+func (name ColumnName) String() string {
+	switch {
+	case name.DB != "":
+		return name.DB + "." + name.Table + "." + name.Column
+	case name.Table != "":
+		return name.Table + "." + name.Column
+	}
+	return name.Column
+}
+
+// GetColumnName returns the ColumnName for node if node is
+// a column reference: col, table.col or db.table.col.
+func GetColumnName(node *Node) (name ColumnName, ok bool) {
+	switch node.Type {
+	case ID:
+		return ColumnName{Column: string(node.Value)}, true
+	case '.':
+		qualifier, column := node.NodeAt(0), node.NodeAt(1)
+		if column.Type != ID {
+			return ColumnName{}, false
+		}
+		switch qualifier.Type {
+		case ID:
+			return ColumnName{Table: string(qualifier.Value), Column: string(column.Value)}, true
+		case '.':
+			if qualifier.NodeAt(0).Type == ID && qualifier.NodeAt(1).Type == ID {
+				return ColumnName{
+					DB:     string(qualifier.NodeAt(0).Value),
+					Table:  string(qualifier.NodeAt(1).Value),
+					Column: string(column.Value),
+				}, true
+			}
+		}
+	}
+	return ColumnName{}, false
+}
+
+// AtTimeZoneExpr is an expression converted to the
+// time zone TZ with AT TIME ZONE.
+type AtTimeZoneExpr struct {
+	Expr *Node
+	TZ   []byte
+}
+
+func (expr AtTimeZoneExpr) String() string {
+	return String(NewSimpleParseNode(AT_TIME_ZONE, "at time zone").PushTwo(expr.Expr, NewParseNode(STRING, expr.TZ)))
+}
+
+// GetAtTimeZone returns the AtTimeZoneExpr for node
+// if node is an AT TIME ZONE expression.
+func GetAtTimeZone(node *Node) (expr AtTimeZoneExpr, ok bool) {
+	if node.Type != AT_TIME_ZONE {
+		return AtTimeZoneExpr{}, false
+	}
+	return AtTimeZoneExpr{Expr: node.NodeAt(0), TZ: node.NodeAt(1).Value}, true
+}
+
+// WindowExpr is a function call evaluated over
+// the window described by Over.
+type WindowExpr struct {
+	Func *Node
+	Over *OverClause
+}
+
+// GetWindowExpr returns the WindowExpr for node
+// if node is a function call with an OVER clause.
+func GetWindowExpr(node *Node) (expr WindowExpr, ok bool) {
+	if node.Type != OVER {
+		return WindowExpr{}, false
+	}
+	return WindowExpr{Func: node.NodeAt(0), Over: node.At(1).(*OverClause)}, true
+}
+
+// GetSubquery returns the select of node if node is a
+// parenthesized subquery, like a scalar subquery in the
+// select list or the right operand of IN. Column references
+// of sel that aren't resolved by its own tables are
+// correlated to the enclosing statement.
+func GetSubquery(node *Node) (sel SelectStatement, ok bool) {
+	if node.Type != '(' || node.Len() != 1 {
+		return nil, false
+	}
+	sel, ok = node.At(0).(SelectStatement)
+	return sel, ok
+}
+
+// QuantifiedComparison is a comparison of Left with the rows
+// of Subquery, like a > ANY (select b from t). Quantifier is
+// any, some or all. The comparison is kept as written: a = ANY
+// (subquery) is not rewritten to IN.
+type QuantifiedComparison struct {
+	Left       *Node
+	Operator   []byte
+	Quantifier []byte
+	Subquery   SelectStatement
+}
+
+// GetQuantifiedComparison returns the QuantifiedComparison
+// for node if node is a comparison with ANY, SOME or ALL.
+func GetQuantifiedComparison(node *Node) (cmp QuantifiedComparison, ok bool) {
+	switch node.Type {
+	case '=', '<', '>', LE, GE, NE, NULL_SAFE_EQUAL:
+	default:
+		return QuantifiedComparison{}, false
+	}
+	right := node.NodeAt(1)
+	switch right.Type {
+	case ANY, SOME, ALL:
+	default:
+		return QuantifiedComparison{}, false
+	}
+	sel, _ := GetSubquery(right.NodeAt(0))
+	return QuantifiedComparison{Left: node.NodeAt(0), Operator: node.Value, Quantifier: right.Value, Subquery: sel}, true
+}
+
+// ExtractColumnRefs returns the column references of stmt as they
+// are written, in the order they appear and without duplicates.
+// The columns of subqueries are not included: ExtractSubqueries
+// can be used to reach them.
+func ExtractColumnRefs(stmt Statement) []ColumnName {
+	return columnRefs(stmt, false)
+}
+
+// ResolveAliases is like ExtractColumnRefs, but table aliases are
+// replaced by the database and table names they stand for.
+// Unqualified columns are attributed to the table of the statement
+// if there's only one.
+func ResolveAliases(stmt Statement) []ColumnName {
+	return columnRefs(stmt, true)
+}
+
+func columnRefs(stmt Statement, resolve bool) []ColumnName {
+	var refs []ColumnName
+	seen := make(map[ColumnName]bool)
+	var tables []tableAlias
+	add := func(name ColumnName) {
+		if resolve {
+			name = resolveColumnName(name, tables)
+		}
+		if !seen[name] {
+			seen[name] = true
+			refs = append(refs, name)
+		}
+	}
+	skip := func(SelectStatement) {}
+	visit := func(node *Node) {
+		if node != nil {
+			node.visitColumns(add, skip)
+		}
+	}
+	var visitStatement func(stmt SQLNode)
+	visitStatement = func(stmt SQLNode) {
+		switch stmt := stmt.(type) {
+		case *Union:
+			visitStatement(stmt.First)
+			for _, arm := range stmt.Arms {
+				visitStatement(arm.Select)
+			}
+		case *ParenSelect:
+			visitStatement(stmt.Select)
+		case *Select:
+			tables = collectTableAliases(stmt.From, nil)
+			for _, expr := range stmt.SelectExprs {
+				if expr, ok := expr.(*NonStarExpr); ok {
+					visit(expr.Expr)
+				}
+			}
+			visitJoinConditions(stmt.From, visit)
+			visit(stmt.Where)
+			visit(stmt.GroupBy)
+			visit(stmt.Having)
+			visit(stmt.OrderBy)
+		case *Insert:
+			tables = collectDMLTableAlias(stmt.Table, nil)
+			for _, column := range stmt.Columns {
+				if column, ok := column.(*NonStarExpr); ok {
+					visit(column.Expr)
+				}
+			}
+			if values, ok := stmt.Values.(*Node); ok {
+				visit(values)
+			}
+			visit(stmt.OnDup)
+		case *Replace:
+			tables = collectDMLTableAlias(stmt.Table, nil)
+			for _, column := range stmt.Columns {
+				if column, ok := column.(*NonStarExpr); ok {
+					visit(column.Expr)
+				}
+			}
+			if values, ok := stmt.Values.(*Node); ok {
+				visit(values)
+			}
+		case *Update:
+			tables = collectDMLTableAlias(stmt.Table, nil)
+			visit(stmt.List)
+			visit(stmt.Where)
+			visit(stmt.OrderBy)
+		case *Delete:
+			tables = collectDMLTableAlias(stmt.Table, nil)
+			visit(stmt.Where)
+			visit(stmt.OrderBy)
+		}
+	}
+	visitStatement(stmt)
+	return refs
+}
+
+// visitJoinConditions calls visit for the ON condition
+// of every join in tableExprs.
+func visitJoinConditions(tableExprs TableExprs, visit func(*Node)) {
+	var visitTableExpr func(tableExpr TableExpr)
+	visitTableExpr = func(tableExpr TableExpr) {
+		switch tableExpr := tableExpr.(type) {
+		case *ParenTableExpr:
+			visitTableExpr(tableExpr.Inner)
+		case *JoinTableExpr:
+			visitTableExpr(tableExpr.LeftExpr)
+			visitTableExpr(tableExpr.RightExpr)
+			visit(tableExpr.On)
+		}
+	}
+	for _, tableExpr := range tableExprs {
+		visitTableExpr(tableExpr)
+	}
+}
+
+// resolveColumnName qualifies name with the database and table
+// names of the table it refers to among tables.
+func resolveColumnName(name ColumnName, tables []tableAlias) ColumnName {
+	i := findTableAlias(name, tables)
+	if i == -1 {
+		return name
+	}
+	return ColumnName{DB: tables[i].db, Table: tables[i].name, Column: name.Column}
+}
+
+// findTableAlias returns the index of the table name refers
+// to among tables, or -1 if it cannot be determined.
+func findTableAlias(name ColumnName, tables []tableAlias) int {
+	if name.Table == "" {
+		if len(tables) != 1 {
+			return -1
+		}
+		return 0
+	}
+	for i, table := range tables {
+		if table.alias != name.Table {
+			continue
+		}
+		if name.DB != "" && name.DB != table.db {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// ResolveAlias returns the table expression of the FROM clause of
+// stmt that alias refers to. A table is referred to by its alias if
+// it has one, or else by its name. Derived tables can only be
+// referred to by their alias. The tables of subqueries are not
+// considered, and neither are the target tables of UPDATE and DELETE,
+// which aren't table expressions.
+func ResolveAlias(stmt Statement, alias []byte) (*AliasedTableExpr, bool) {
+	sel, ok := stmt.(*Select)
+	if !ok {
+		return nil, false
+	}
+	for _, table := range fromTables(sel.From, nil) {
+		if bytes.Equal(tableExprAlias(table), alias) {
+			return table, true
+		}
+	}
+	return nil, false
+}
+
+// ValidateAliases verifies that no two tables of the same FROM
+// clause can be referred to by the same name, in stmt and in all
+// its subqueries.
+func ValidateAliases(stmt Statement) error {
+	if sel, ok := stmt.(SelectStatement); ok {
+		if err := validateAliases(sel); err != nil {
+			return err
+		}
+	}
+	for _, subquery := range ExtractSubqueries(stmt) {
+		if err := validateAliases(subquery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateAliases(sel SelectStatement) error {
+	switch sel := sel.(type) {
+	case *Select:
+		seen := make(map[string]bool)
+		for _, table := range fromTables(sel.From, nil) {
+			alias := tableExprAlias(table)
+			if alias == nil {
+				continue
+			}
+			if seen[string(alias)] {
+				return NewParserError("not unique table/alias: '%s'", alias)
+			}
+			seen[string(alias)] = true
+		}
+	case *Union:
+		if err := validateAliases(sel.First); err != nil {
+			return err
+		}
+		for _, arm := range sel.Arms {
+			if err := validateAliases(arm.Select); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *ParenSelect:
+		return validateAliases(sel.Select)
+	}
+	return nil
+}
+
+// fromTables appends the aliased table expressions of tableExprs,
+// including the ones of joins, to tables. The tables of derived
+// tables aren't included.
+func fromTables(tableExprs TableExprs, tables []*AliasedTableExpr) []*AliasedTableExpr {
+	var visit func(tableExpr TableExpr)
+	visit = func(tableExpr TableExpr) {
+		switch tableExpr := tableExpr.(type) {
+		case *AliasedTableExpr:
+			tables = append(tables, tableExpr)
+		case *ParenTableExpr:
+			visit(tableExpr.Inner)
+		case *JoinTableExpr:
+			visit(tableExpr.LeftExpr)
+			visit(tableExpr.RightExpr)
+		}
+	}
+	for _, tableExpr := range tableExprs {
+		visit(tableExpr)
+	}
+	return tables
+}
+
+// tableExprAlias returns the name table can be referred to by,
+// or nil if it has none.
+func tableExprAlias(table *AliasedTableExpr) []byte {
+	if table.As != nil {
+		return table.As
+	}
+	switch table.Expr.Type {
+	case ID:
+		return table.Expr.Value
+	case '.':
+		return table.Expr.NodeAt(1).Value
+	}
+	return nil
+}
+
+// ExtractSubqueries returns all the subqueries of stmt, in the
+// order they appear. Nested subqueries are included.
+func ExtractSubqueries(stmt Statement) []SelectStatement {
+	var subqueries []SelectStatement
+	var visit func(node SQLNode)
+	visit = func(node SQLNode) {
+		switch node := node.(type) {
+		case *Node:
+			if node == nil {
+				return
+			}
+			for _, sub := range node.Sub {
+				if sel, ok := sub.(SelectStatement); ok {
+					subqueries = append(subqueries, sel)
+				}
+				visit(sub)
+			}
+		case SelectExprs:
+			for _, expr := range node {
+				if expr, ok := expr.(*NonStarExpr); ok {
+					visit(expr.Expr)
+				}
+			}
+		case TableExprs:
+			for _, tableExpr := range node {
+				visit(tableExpr)
+			}
+		case *AliasedTableExpr:
+			visit(node.Expr)
+		case *ParenTableExpr:
+			visit(node.Inner)
+		case *JoinTableExpr:
+			visit(node.LeftExpr)
+			visit(node.RightExpr)
+			visit(node.On)
+		case *With:
+			if node == nil {
+				return
+			}
+			for _, cte := range node.CTEs {
+				subqueries = append(subqueries, cte.Subquery)
+				visit(cte.Subquery)
+			}
+		case *Select:
+			visit(node.With)
+			visit(node.SelectExprs)
+			visit(node.From)
+			visit(node.Where)
+			visit(node.GroupBy)
+			visit(node.Having)
+			visit(node.OrderBy)
+			visit(node.Limit)
+		case *Union:
+			visit(node.With)
+			visit(node.First)
+			for _, arm := range node.Arms {
+				visit(arm.Select)
+			}
+			visit(node.OrderBy)
+			visit(node.Limit)
+		case *ParenSelect:
+			visit(node.Select)
+		case *Insert:
+			if sel, ok := node.Values.(SelectStatement); ok {
+				subqueries = append(subqueries, sel)
+			}
+			visit(node.Values)
+			visit(node.OnDup)
+		case *Replace:
+			if sel, ok := node.Values.(SelectStatement); ok {
+				subqueries = append(subqueries, sel)
+			}
+			visit(node.Values)
+		case *Update:
+			visit(node.List)
+			visit(node.Where)
+			visit(node.OrderBy)
+		case *Delete:
+			visit(node.Where)
+			visit(node.OrderBy)
+		case *Set:
+			for _, expr := range node.Exprs {
+				visit(expr.Expr)
+			}
+		case *DDLSimple:
+			if node.ViewSpec != nil {
+				subqueries = append(subqueries, node.ViewSpec.Select)
+				visit(node.ViewSpec.Select)
+			}
+		case *Do:
+			for _, expr := range node.Exprs {
+				visit(expr)
+			}
+		case *Explain:
+			visit(node.Statement)
+		}
+	}
+	visit(stmt)
+	return subqueries
+}
+
+// ForEachTable calls fn for every aliased table expression of stmt,
+// including the ones of joins, derived tables and subqueries. depth
+// is 0 for the tables of the outermost query, and is incremented for
+// every level of subquery or derived table. A derived table itself is
+// visited before its tables. The select of a view definition and the
+// common table expressions of a WITH clause count as subqueries. The
+// target tables of INSERT, UPDATE and DELETE are not table
+// expressions, and are not visited.
+func ForEachTable(stmt Statement, fn func(t *AliasedTableExpr, depth int)) {
+	var visit func(node SQLNode, depth int)
+	visit = func(node SQLNode, depth int) {
+		switch node := node.(type) {
+		case *Node:
+			if node == nil {
+				return
+			}
+			for _, sub := range node.Sub {
+				if sel, ok := sub.(SelectStatement); ok {
+					visit(sel, depth+1)
+					continue
+				}
+				visit(sub, depth)
+			}
+		case SelectExprs:
+			for _, expr := range node {
+				if expr, ok := expr.(*NonStarExpr); ok {
+					visit(expr.Expr, depth)
+				}
+			}
+		case TableExprs:
+			for _, tableExpr := range node {
+				visit(tableExpr, depth)
+			}
+		case *AliasedTableExpr:
+			fn(node, depth)
+			visit(node.Expr, depth)
+		case *ParenTableExpr:
+			visit(node.Inner, depth)
+		case *JoinTableExpr:
+			visit(node.LeftExpr, depth)
+			visit(node.RightExpr, depth)
+			visit(node.On, depth)
+		case *With:
+			if node == nil {
+				return
+			}
+			for _, cte := range node.CTEs {
+				visit(cte.Subquery, depth+1)
+			}
+		case *Select:
+			visit(node.With, depth)
+			visit(node.SelectExprs, depth)
+			visit(node.From, depth)
+			visit(node.Where, depth)
+			visit(node.GroupBy, depth)
+			visit(node.Having, depth)
+			visit(node.OrderBy, depth)
+			visit(node.Limit, depth)
+		case *Union:
+			visit(node.With, depth)
+			visit(node.First, depth)
+			for _, arm := range node.Arms {
+				visit(arm.Select, depth)
+			}
+			visit(node.OrderBy, depth)
+			visit(node.Limit, depth)
+		case *ParenSelect:
+			visit(node.Select, depth)
+		case *Insert:
+			if sel, ok := node.Values.(SelectStatement); ok {
+				visit(sel, depth+1)
+			} else {
+				visit(node.Values, depth)
+			}
+			visit(node.OnDup, depth)
+		case *Replace:
+			if sel, ok := node.Values.(SelectStatement); ok {
+				visit(sel, depth+1)
+			} else {
+				visit(node.Values, depth)
+			}
+		case *Update:
+			visit(node.List, depth)
+			visit(node.Where, depth)
+			visit(node.OrderBy, depth)
+		case *Delete:
+			visit(node.Where, depth)
+			visit(node.OrderBy, depth)
+		case *Set:
+			for _, expr := range node.Exprs {
+				visit(expr.Expr, depth)
+			}
+		case *DDLSimple:
+			if node.ViewSpec != nil {
+				visit(node.ViewSpec.Select, depth+1)
+			}
+		case *Do:
+			for _, expr := range node.Exprs {
+				visit(expr, depth)
+			}
+		case *LockTables:
+			for _, table := range node.Tables {
+				visit(table.Table, depth)
+			}
+		case *Explain:
+			visit(node.Statement, depth)
+		}
+	}
+	visit(stmt, 0)
+}
+
+// NormalizeIN rewrites the OR chains of equalities between the
+// same column and values, like "a = 1 or a = 2", into the equivalent
+// IN condition, "a in (1, 2)". The conditions of subqueries are
+// rewritten too. It returns the number of rewrites performed.
+func NormalizeIN(stmt Statement) int {
+	count := normalizeIN(stmt)
+	for _, subquery := range ExtractSubqueries(stmt) {
+		count += normalizeIN(subquery)
+	}
+	return count
+}
+
+func normalizeIN(stmt SQLNode) int {
+	switch stmt := stmt.(type) {
+	case *Select:
+		return stmt.Where.normalizeIN() + stmt.Having.normalizeIN()
+	case *Union:
+		count := normalizeIN(stmt.First)
+		for _, arm := range stmt.Arms {
+			count += normalizeIN(arm.Select)
+		}
+		return count
+	case *ParenSelect:
+		return normalizeIN(stmt.Select)
+	case *Update:
+		return stmt.Where.normalizeIN()
+	case *Delete:
+		return stmt.Where.normalizeIN()
+	}
+	return 0
+}
+
+func (node *Node) normalizeIN() int {
+	if node == nil {
+		return 0
+	}
+	count := 0
+	for i, sub := range node.Sub {
+		sub, ok := sub.(*Node)
+		if !ok {
+			continue
+		}
+		if sub.Type == OR {
+			if in := sub.orChainToIN(); in != nil {
+				node.Sub[i] = in
+				count++
+				continue
+			}
+		}
+		count += sub.normalizeIN()
+	}
+	return count
+}
+
+// orChainToIN returns the IN condition equivalent to the OR
+// chain node, or nil if the chain cannot be converted.
+func (node *Node) orChainToIN() *Node {
+	equalities := node.collectEqualities(nil)
+	if len(equalities) < 2 {
+		return nil
+	}
+	column := String(equalities[0].NodeAt(0))
+	values := NewSimpleParseNode(NODE_LIST, "node_list")
+	for _, equality := range equalities {
+		if String(equality.NodeAt(0)) != column {
+			return nil
+		}
+		values.Push(equality.NodeAt(1))
+	}
+	in := NewSimpleParseNode(IN, "in")
+	return in.PushTwo(equalities[0].NodeAt(0), NewSimpleParseNode('(', "(").Push(values))
+}
+
+// collectEqualities appends to equalities the column = value
+// conditions of the OR chain node. It returns nil if any of
+// the terms is not such a condition.
+func (node *Node) collectEqualities(equalities []*Node) []*Node {
+	switch node.Type {
+	case OR:
+		for i := 0; i < node.Len(); i++ {
+			if equalities = node.NodeAt(i).collectEqualities(equalities); equalities == nil {
+				return nil
+			}
+		}
+		return equalities
+	case '(':
+		if sub, ok := node.At(0).(*Node); ok {
+			return sub.collectEqualities(equalities)
+		}
+	case '=':
+		if node.NodeAt(0).execAnalyzeID() != nil && node.NodeAt(1).execAnalyzeValue() != nil {
+			return append(equalities, node)
+		}
+	}
+	return nil
+}
+
+// NestToFlat returns a copy of node where the AND and OR chains,
+// including the ones split by redundant parentheses, are flattened
+// into a single AND or OR node that has all the operands as
+// children: "a and (b and c)" becomes "a and b and c". node itself
+// is left untouched, since the functions that analyze conditions
+// for execution, like ExecParse and Simplify, expect binary chains.
+// The subqueries of node are shared with the copy.
+func NestToFlat(node *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	flat := &Node{Type: node.Type, Value: node.Value, Sub: make([]SQLNode, 0, len(node.Sub))}
+	for _, sub := range node.Sub {
+		if sub, ok := sub.(*Node); ok {
+			flat.Sub = append(flat.Sub, NestToFlat(sub))
+			continue
+		}
+		flat.Sub = append(flat.Sub, sub)
+	}
+	if flat.Type != AND && flat.Type != OR {
+		return flat
+	}
+	operands := make([]SQLNode, 0, len(flat.Sub))
+	for _, sub := range flat.Sub {
+		sub := sub.(*Node)
+		if sub.Type == '(' {
+			if inner, ok := sub.At(0).(*Node); ok && inner.Type == flat.Type {
+				sub = inner
+			}
+		}
+		if sub.Type == flat.Type {
+			operands = append(operands, sub.Sub...)
+			continue
+		}
+		operands = append(operands, sub)
+	}
+	flat.Sub = operands
+	return flat
+}
+
+// aggregateFunctions contains the aggregate
+// functions, in lower case.
+var aggregateFunctions = map[string]bool{
+	"avg":          true,
+	"bit_and":      true,
+	"bit_or":       true,
+	"bit_xor":      true,
+	"count":        true,
+	"group_concat": true,
+	"max":          true,
+	"min":          true,
+	"std":          true,
+	"stddev":       true,
+	"stddev_pop":   true,
+	"stddev_samp":  true,
+	"sum":          true,
+	"var_pop":      true,
+	"var_samp":     true,
+	"variance":     true,
+}
+
+// RewriteINSubqueryToJoin rewrites the conditions of the WHERE clause
+// of stmt that are like "x in (select y from u ...)" into a join
+// against the subquery as a derived table:
+//
+//	select a from t join (select distinct y as __col from u ...) as __sq1 on x = __sq1.__col
+//
+// Only the conditions that are ANDed at the top of the WHERE clause
+// are rewritten, and only if stmt is a SELECT from a single table
+// expression without locks. The subquery must be a SELECT of a single
+// expression, without aggregates, GROUP BY, HAVING, LIMIT or nested
+// subqueries, and must be uncorrelated: its qualified column
+// references must all name its own tables. Unqualified columns are
+// assumed to belong to the subquery, like MySQL resolves them when
+// the column exists there. It returns the number of rewrites.
+func RewriteINSubqueryToJoin(stmt Statement) int {
+	sel, ok := stmt.(*Select)
+	if !ok || sel.Where.Len() == 0 || len(sel.From) != 1 || sel.Lock.Type != NO_LOCK {
+		return 0
+	}
+	conditions := sel.Where.NodeAt(0).splitAND(nil)
+	rewritable := false
+	for _, condition := range conditions {
+		if isRewritableINSubquery(condition) {
+			rewritable = true
+		}
+	}
+	if !rewritable || !qualifyStar(sel) {
+		return 0
+	}
+	count := 0
+	var remaining []*Node
+	for _, condition := range conditions {
+		if !isRewritableINSubquery(condition) {
+			remaining = append(remaining, condition)
+			continue
+		}
+		count++
+		alias := fmt.Sprintf("__sq%d", count)
+		sub := condition.NodeAt(1).At(0).(*Select)
+		sub.Distinct = true
+		sub.SelectExprs[0].(*NonStarExpr).As = []byte("__col")
+		on := NewSimpleParseNode('=', "=").PushTwo(
+			condition.NodeAt(0),
+			NewSimpleParseNode('.', ".").PushTwo(NewSimpleParseNode(ID, alias), NewSimpleParseNode(ID, "__col")),
+		)
+		sel.From[0] = &JoinTableExpr{
+			LeftExpr:  sel.From[0],
+			Join:      []byte("join"),
+			RightExpr: &AliasedTableExpr{Expr: NewSimpleParseNode('(', "(").Push(sub), As: []byte(alias)},
+			On:        on,
+		}
+	}
+	sel.Where.Sub = nil
+	if len(remaining) != 0 {
+		where := remaining[0]
+		for _, condition := range remaining[1:] {
+			where = NewSimpleParseNode(AND, "and").PushTwo(where, condition)
+		}
+		sel.Where.Push(where)
+	}
+	return count
+}
+
+// isRewritableINSubquery returns true if condition is an IN
+// condition that RewriteINSubqueryToJoin can rewrite.
+func isRewritableINSubquery(condition *Node) bool {
+	if condition.Type != IN {
+		return false
+	}
+	sub, ok := condition.NodeAt(1).At(0).(*Select)
+	if !ok || len(sub.SelectExprs) != 1 || sub.GroupBy.Len() != 0 || sub.Having.Len() != 0 ||
+		sub.Limit.Len() != 0 || sub.Lock.Type != NO_LOCK || sub.Into != nil || sub.Procedure != nil {
+		return false
+	}
+	expr, ok := sub.SelectExprs[0].(*NonStarExpr)
+	if !ok || len(ExtractSubqueries(sub)) != 0 || expr.Expr.hasAggregate() {
+		return false
+	}
+	tables := collectTableAliases(sub.From, nil)
+	for _, name := range ExtractColumnRefs(sub) {
+		if name.Table != "" && findTableAlias(name, tables) == -1 {
+			return false
+		}
+	}
+	return true
+}
+
+// qualifyStar qualifies the unqualified '*' of sel with the name of
+// its table, so that joining other tables doesn't change its columns.
+// It returns false if sel doesn't select from a single named table.
+func qualifyStar(sel *Select) bool {
+	for _, expr := range sel.SelectExprs {
+		expr, ok := expr.(*StarExpr)
+		if !ok || expr.TableName != nil {
+			continue
+		}
+		tables := collectTableAliases(sel.From, nil)
+		if len(tables) != 1 {
+			return false
+		}
+		expr.TableName = []byte(tables[0].alias)
+	}
+	return true
+}
+
+// hasAggregate returns true if node calls an aggregate function.
+func (node *Node) hasAggregate() bool {
+	if node.Type == FUNCTION && aggregateFunctions[strings.ToLower(string(node.Value))] {
+		return true
+	}
+	for _, sub := range node.Sub {
+		switch sub := sub.(type) {
+		case *Node:
+			if sub.hasAggregate() {
+				return true
+			}
+		case SelectExprs:
+			for _, expr := range sub {
+				if expr, ok := expr.(*NonStarExpr); ok && expr.Expr.hasAggregate() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// TableStats contains the statistics of a table used
+// to estimate the number of rows returned by a query.
+type TableStats struct {
+	RowCount int64
+	// AvgSelectivity is the average fraction of rows
+	// that satisfy a predicate on a column of the table.
+	AvgSelectivity float64
+}
+
+// EstimateResultRows estimates the number of rows returned by sel.
+// The row counts of the tables in the FROM clause are multiplied,
+// and every condition of the WHERE clause and of the join ON clauses
+// applies the selectivity of each table whose columns it references.
+// A row count LIMIT caps the estimate. Tables missing from tableStats,
+// and conditions whose columns cannot be attributed to a table, do
+// not affect the estimate.
+func EstimateResultRows(sel *Select, tableStats map[string]TableStats) int64 {
+	tables := collectTableAliases(sel.From, nil)
+	estimate := 1.0
+	for _, table := range tables {
+		if stats, ok := tableStats[table.name]; ok {
+			estimate *= float64(stats.RowCount)
+		}
+	}
+	var conditions []*Node
+	if sel.Where != nil && sel.Where.Len() != 0 {
+		conditions = sel.Where.NodeAt(0).splitAND(conditions)
+	}
+	visitJoinConditions(sel.From, func(on *Node) {
+		if on != nil {
+			conditions = on.splitAND(conditions)
+		}
+	})
+	for _, condition := range conditions {
+		referenced := make([]bool, len(tables))
+		condition.visitColumns(func(name ColumnName) {
+			if i := findTableAlias(name, tables); i != -1 {
+				referenced[i] = true
+			}
+		}, func(SelectStatement) {})
+		for i, table := range tables {
+			if stats, ok := tableStats[table.name]; ok && referenced[i] {
+				estimate *= stats.AvgSelectivity
+			}
+		}
+	}
+	rows := int64(estimate + 0.5)
+	if sel.Limit != nil && sel.Limit.Len() != 0 {
+		rowcount := sel.Limit.NodeAt(sel.Limit.Len() - 1)
+		if rowcount.Type == NUMBER {
+			if limit, err := strconv.ParseInt(string(rowcount.Value), 0, 64); err == nil && limit < rows {
+				rows = limit
+			}
+		}
+	}
+	return rows
+}
+
+// splitAND appends the conditions of the AND expression
+// node to conditions.
+func (node *Node) splitAND(conditions []*Node) []*Node {
+	switch node.Type {
+	case AND:
+		for i := 0; i < node.Len(); i++ {
+			conditions = node.NodeAt(i).splitAND(conditions)
+		}
+		return conditions
+	case '(':
+		if sub, ok := node.At(0).(*Node); ok {
+			return sub.splitAND(conditions)
+		}
+	}
+	return append(conditions, node)
+}
+
+// WritesToFile returns the path of the file written by stmt if
+// it is a SELECT ... INTO OUTFILE or INTO DUMPFILE. Such an
+// export reads its tables like any select, but isn't read-only.
+// A SELECT ... INTO variables doesn't write to a file.
+func WritesToFile(stmt Statement) (path []byte, ok bool) {
+	switch stmt := stmt.(type) {
+	case *Select:
+		if stmt.Into != nil && stmt.Into.Type != nil {
+			return stmt.Into.FileName.Value, true
+		}
+	case *Union:
+		if path, ok := WritesToFile(stmt.First); ok {
+			return path, true
+		}
+		for _, arm := range stmt.Arms {
+			if path, ok := WritesToFile(arm.Select); ok {
+				return path, true
+			}
+		}
+	case *ParenSelect:
+		return WritesToFile(stmt.Select)
+	}
+	return nil, false
+}
+
+// HasProcedureAnalyse returns true if sel ends with
+// a PROCEDURE ANALYSE() clause.
+func HasProcedureAnalyse(sel *Select) bool {
+	return sel.Procedure != nil && bytes.Equal(bytes.ToLower(sel.Procedure.Value), []byte("analyse"))
+}
+
+// HasIntoOutfile returns true if sel writes its result
+// to a file using INTO OUTFILE.
+func HasIntoOutfile(sel *Select) bool {
+	return sel.Into != nil && bytes.Equal(sel.Into.Type, []byte("outfile"))
+}
+
+// ProjectionWidth returns the number of columns projected by stmt,
+// which must be a SELECT or a UNION. The width of a UNION is the one
+// of its first select. If the select list contains a star expression,
+// the width can't be known without the schema: exact is then false,
+// and n only counts the other expressions.
+func ProjectionWidth(stmt Statement) (n int, exact bool) {
+	switch stmt := stmt.(type) {
+	case *Select:
+		exact = true
+		for _, expr := range stmt.SelectExprs {
+			if _, ok := expr.(*StarExpr); ok {
+				exact = false
+				continue
+			}
+			n++
+		}
+		return n, exact
+	case *Union:
+		return ProjectionWidth(stmt.First)
+	case *ParenSelect:
+		return ProjectionWidth(stmt.Select)
+	}
+	return 0, false
+}
+
+// IsReadOnly returns true if stmt only reads data: it's a SELECT
+// or a UNION that doesn't write to a file, and none of its selects
+// or subqueries take locks with FOR UPDATE or LOCK IN SHARE MODE.
+// EXPLAIN, DESCRIBE and SHOW statements are read-only too, except
+// EXPLAIN ANALYZE, which runs the statement it explains.
+func IsReadOnly(stmt Statement) bool {
+	switch stmt := stmt.(type) {
+	case *Select, *Union:
+	case *Explain:
+		return !stmt.Analyze || IsReadOnly(stmt.Statement)
+	case *ExplainForConnection, *Show, *Describe:
+		return true
+	default:
+		return false
+	}
+	if _, ok := WritesToFile(stmt); ok {
+		return false
+	}
+	if isLocking(stmt.(SelectStatement)) {
+		return false
+	}
+	for _, subquery := range ExtractSubqueries(stmt) {
+		if isLocking(subquery) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLocking returns true if sel, or one of the
+// selects of a union, has a locking clause.
+func isLocking(sel SelectStatement) bool {
+	switch sel := sel.(type) {
+	case *Select:
+		return sel.Lock.Type != NO_LOCK
+	case *Union:
+		if sel.Lock != nil && sel.Lock.Type != NO_LOCK {
+			return true
+		}
+		if isLocking(sel.First) {
+			return true
+		}
+		for _, arm := range sel.Arms {
+			if isLocking(arm.Select) {
+				return true
+			}
+		}
+	case *ParenSelect:
+		return isLocking(sel.Select)
+	}
+	return false
+}
+
+// IsSessionLocal returns true if stmt only reads the state of
+// the connection it runs on, like SHOW WARNINGS and SHOW ERRORS.
+// It must be sent on the connection of the previous statements.
+func IsSessionLocal(stmt Statement) bool {
+	show, ok := stmt.(*Show)
+	return ok && (show.Type == SHOW_WARNINGS || show.Type == SHOW_ERRORS)
+}
+
+// Simplify folds the conditions of stmt that contain TRUE, FALSE
+// or comparisons between integer literals, like "1 = 1". An AND or OR operand that
+// doesn't affect the result is removed, and a WHERE or HAVING clause
+// that is always true is dropped. An operand that determines the
+// result makes the other one unnecessary, but it's only discarded
+// if it has no function calls or subqueries. The conditions of
+// subqueries are simplified too. It returns the number of
+// simplifications performed.
+func Simplify(stmt Statement) int {
+	count := simplify(stmt)
+	for _, subquery := range ExtractSubqueries(stmt) {
+		count += simplify(subquery)
+	}
+	return count
+}
+
+func simplify(stmt SQLNode) int {
+	switch stmt := stmt.(type) {
+	case *Select:
+		return simplifyClause(stmt.Where) + simplifyClause(stmt.Having)
+	case *Union:
+		count := simplify(stmt.First)
+		for _, arm := range stmt.Arms {
+			count += simplify(arm.Select)
+		}
+		return count
+	case *ParenSelect:
+		return simplify(stmt.Select)
+	case *Update:
+		return simplifyClause(stmt.Where)
+	case *Delete:
+		return simplifyClause(stmt.Where)
+	}
+	return 0
+}
+
+// simplifyClause simplifies the condition of a WHERE or HAVING
+// clause, and drops the condition if it's always true.
+func simplifyClause(clause *Node) int {
+	if clause == nil || clause.Len() == 0 {
+		return 0
+	}
+	condition, count := clause.NodeAt(0).simplifyCondition()
+	if value, ok := condition.constantValue(); ok && value {
+		clause.Sub = nil
+		return count + 1
+	}
+	clause.Sub[0] = condition
+	return count
+}
+
+func (node *Node) simplifyCondition() (*Node, int) {
+	switch node.Type {
+	case AND, OR:
+		left, leftCount := node.NodeAt(0).simplifyCondition()
+		right, rightCount := node.NodeAt(1).simplifyCondition()
+		node.Sub[0], node.Sub[1] = left, right
+		count := leftCount + rightCount
+		// true is the identity of AND, false is the identity of OR.
+		identity := node.Type == AND
+		if value, ok := left.constantValue(); ok {
+			if value == identity {
+				return right, count + 1
+			}
+			if right.isPure() {
+				return left, count + 1
+			}
+		}
+		if value, ok := right.constantValue(); ok {
+			if value == identity {
+				return left, count + 1
+			}
+			if left.isPure() {
+				return right, count + 1
+			}
+		}
+		return node, count
+	case NOT, '(':
+		inner, ok := node.At(0).(*Node)
+		if !ok {
+			return node, 0
+		}
+		inner, count := inner.simplifyCondition()
+		node.Sub[0] = inner
+		return node, count
+	}
+	return node, 0
+}
+
+// constantValue returns the value of node if it's TRUE, FALSE or a
+// comparison between integer literals, possibly negated or
+// parenthesized.
+func (node *Node) constantValue() (value bool, ok bool) {
+	switch node.Type {
+	case TRUE:
+		return true, true
+	case FALSE:
+		return false, true
+	case '(', NOT:
+		inner, ok := node.At(0).(*Node)
+		if !ok {
+			return false, false
+		}
+		value, ok := inner.constantValue()
+		if node.Type == NOT {
+			value = !value
+		}
+		return value, ok
+	case '=', NE, '<', '>', LE, GE:
+		left, right := node.NodeAt(0), node.NodeAt(1)
+		if left.Type != NUMBER || right.Type != NUMBER {
+			return false, false
+		}
+		l, err := strconv.ParseInt(string(left.Value), 0, 64)
+		if err != nil {
+			return false, false
+		}
+		r, err := strconv.ParseInt(string(right.Value), 0, 64)
+		if err != nil {
+			return false, false
+		}
+		switch node.Type {
+		case '=':
+			return l == r, true
+		case NE:
+			return l != r, true
+		case '<':
+			return l < r, true
+		case '>':
+			return l > r, true
+		case LE:
+			return l <= r, true
+		case GE:
+			return l >= r, true
+		}
+	}
+	return false, false
+}
+
+// isPure returns true if node contains no function
+// calls or subqueries.
+func (node *Node) isPure() bool {
+	if node.Type == FUNCTION {
+		return false
+	}
+	for _, sub := range node.Sub {
+		sub, ok := sub.(*Node)
+		if !ok || !sub.isPure() {
+			return false
+		}
+	}
+	return true
+}
+
+// DDLWarning describes an operation of a DDL statement that
+// cannot be performed by an online schema change.
+type DDLWarning struct {
+	// Operation is the SQL of the operation.
+	Operation string
+	Reason    string
+}
+
+// ValidateDDLForOnlineSchema returns a DDLWarning for every operation
+// of stmt that requires copying the table: renaming a column, changing
+// the type of a column, which may narrow it, making a column NOT NULL
+// without a DEFAULT and adding a FULLTEXT index.
+func ValidateDDLForOnlineSchema(stmt Statement) []DDLWarning {
+	ddl, ok := stmt.(*DDLSimple)
+	if !ok || ddl.Action != ALTER {
+		return nil
+	}
+	var warnings []DDLWarning
+	warn := func(option AlterOption, format string, args ...interface{}) {
+		warnings = append(warnings, DDLWarning{Operation: String(option), Reason: fmt.Sprintf(format, args...)})
+	}
+	checkNotNull := func(option AlterOption, column *ColumnDefinition) {
+		if column.Type.NotNull && column.Type.Default == nil && !column.Type.Autoincrement {
+			warn(option, "column %s is NOT NULL without a DEFAULT", column.Name.Value)
+		}
+	}
+	for _, option := range ddl.AlterOptions {
+		switch option := option.(type) {
+		case *AddColumn:
+			checkNotNull(option, option.Column)
+		case *ChangeColumn:
+			if !bytes.Equal(option.OldName.Value, option.Column.Name.Value) {
+				warn(option, "column %s is renamed to %s", option.OldName.Value, option.Column.Name.Value)
+			}
+			warn(option, "the type of column %s may be narrowed", option.OldName.Value)
+			checkNotNull(option, option.Column)
+		case *ModifyColumn:
+			warn(option, "the type of column %s may be narrowed", option.Column.Name.Value)
+			checkNotNull(option, option.Column)
+		case *AddIndex:
+			if bytes.Equal(option.Index.Type, []byte("fulltext")) {
+				warn(option, "fulltext indexes cannot be added online")
+			}
+		}
+	}
+	return warnings
+}
+
+// IndexDef describes the columns of an index, in index order.
+type IndexDef struct {
+	Name    string
+	Columns []string
+}
+
+// CanUseIndex reports how many leading columns of index are
+// constrained by equality predicates in the top level AND
+// conditions of where. covers is true if every column of the
+// index is constrained.
+func CanUseIndex(where *Node, index IndexDef) (prefixLength int, covers bool) {
+	if where == nil {
+		return 0, false
+	}
+	if where.Type == WHERE {
+		if where.Len() == 0 {
+			return 0, false
+		}
+		where = where.NodeAt(0)
+	}
+	columns := where.equalityColumns(nil)
+	for _, indexColumn := range index.Columns {
+		found := false
+		for _, column := range columns {
+			if bytes.Equal(bytes.ToLower(column), bytes.ToLower([]byte(indexColumn))) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+		prefixLength++
+	}
+	return prefixLength, len(index.Columns) != 0 && prefixLength == len(index.Columns)
+}
+
+// equalityColumns appends to columns the names of the columns that
+// are compared for equality against a value in the AND conditions
+// of node.
+func (node *Node) equalityColumns(columns [][]byte) [][]byte {
+	switch node.Type {
+	case AND:
+		for i := 0; i < node.Len(); i++ {
+			columns = node.NodeAt(i).equalityColumns(columns)
+		}
+		return columns
+	case '(':
+		if sub, ok := node.At(0).(*Node); ok {
+			return sub.equalityColumns(columns)
+		}
+	case '=', NULL_SAFE_EQUAL:
+		left, right := node.NodeAt(0), node.NodeAt(1)
+		if id := left.execAnalyzeID(); id != nil && right.execAnalyzeValue() != nil {
+			return append(columns, id.Value)
+		}
+		if id := right.execAnalyzeID(); id != nil && left.execAnalyzeValue() != nil {
+			return append(columns, id.Value)
+		}
+	}
+	return columns
+}
@@ -4,7 +4,12 @@
 
 package sqlparser
 
-import "testing"
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestGetDBName(t *testing.T) {
 	wantYes := []string{
@@ -50,3 +55,1117 @@ func TestGetDBName(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractTags(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		want map[string]string
+	}{{
+		"select /* app:myapp */ 1 from t",
+		map[string]string{"app": "myapp"},
+	}, {
+		"select /* app:myapp user:alice */ 1 from t",
+		map[string]string{"app": "myapp", "user": "alice"},
+	}, {
+		"update /* app:a */ /* user:bob */ t set a = 1",
+		map[string]string{"app": "a", "user": "bob"},
+	}, {
+		"select /* app:a */ 1 from t union select /* app:b */ 1 from u",
+		map[string]string{"app": "a"},
+	}, {
+		"insert /* no tags here :x y: */ into t values (1)",
+		map[string]string{},
+	}, {
+		"delete from t",
+		map[string]string{},
+	}}
+	for _, tcase := range testcases {
+		stmt, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("error %v on %s", err, tcase.sql)
+			continue
+		}
+		got := ExtractTags(stmt)
+		if !reflect.DeepEqual(got, tcase.want) {
+			t.Errorf("ExtractTags(%s): %v, want %v", tcase.sql, got, tcase.want)
+		}
+	}
+}
+
+func TestInsertArity(t *testing.T) {
+	testcases := []struct {
+		sql      string
+		arities  []int
+		validErr string
+	}{{
+		"insert into t values (1, 2, 3)",
+		[]int{3},
+		"",
+	}, {
+		"insert into t values (1, 2), (3, 4), (5, 6)",
+		[]int{2, 2, 2},
+		"",
+	}, {
+		"insert into t set a = 1, b = 2",
+		[]int{2},
+		"",
+	}, {
+		"insert into t(a, b) values (1, 2), (3, 4) as new on duplicate key update a = new.b",
+		[]int{2, 2},
+		"",
+	}, {
+		"insert into t values (1, 2), (3), (5, 6)",
+		[]int{2, 1, 2},
+		"column count doesn't match value count",
+	}, {
+		"insert into t(a, b) values (1, 2), (3, 4)",
+		[]int{2, 2},
+		"",
+	}, {
+		"insert into t(a, b) values (1, 2, 3)",
+		[]int{3},
+		"column count doesn't match value count",
+	}, {
+		"insert into t values (1, 2), (select a, b from u)",
+		[]int{2, -1},
+		"",
+	}, {
+		"insert into t select a, b from u",
+		nil,
+		"",
+	}}
+	for _, tcase := range testcases {
+		stmt, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("error %v on %s", err, tcase.sql)
+			continue
+		}
+		ins := stmt.(*Insert)
+		if got := ins.RowArities(); !reflect.DeepEqual(got, tcase.arities) {
+			t.Errorf("RowArities(%s): %v, want %v", tcase.sql, got, tcase.arities)
+		}
+		err = ins.Validate()
+		var got string
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tcase.validErr {
+			t.Errorf("Validate(%s): %q, want %q", tcase.sql, got, tcase.validErr)
+		}
+	}
+}
+
+func TestSensitiveColumns(t *testing.T) {
+	piiMap := map[string][]string{
+		"users":  {"email", "ssn"},
+		"orders": {"card"},
+	}
+	testcases := []struct {
+		sql  string
+		want []ColumnRef
+	}{{
+		"select id, email from users",
+		[]ColumnRef{{"users", "email"}},
+	}, {
+		"select u.ssn, o.card, o.id from users as u join orders as o on u.id = o.uid",
+		[]ColumnRef{{"users", "ssn"}, {"orders", "card"}},
+	}, {
+		"select lower(email), concat(card, 'x') from users, orders",
+		[]ColumnRef{{"users", "email"}, {"orders", "card"}},
+	}, {
+		"select o.* from users as u join orders as o",
+		[]ColumnRef{{"orders", "card"}},
+	}, {
+		"select email from users union select card from orders",
+		[]ColumnRef{{"users", "email"}, {"orders", "card"}},
+	}, {
+		"select * from orders, users",
+		[]ColumnRef{{"orders", "card"}, {"users", "email"}, {"users", "ssn"}},
+	}, {
+		"select id from users where email = 'a'",
+		nil,
+	}, {
+		"select email from other",
+		nil,
+	}, {
+		"update users set email = 'a'",
+		nil,
+	}, {
+		"select t.ssn from (select ssn from users) as t",
+		[]ColumnRef{{"users", "ssn"}},
+	}, {
+		"select x from (select lower(email) as x, id from users) as t",
+		[]ColumnRef{{"users", "email"}},
+	}, {
+		"select id from (select * from users) as t",
+		nil,
+	}, {
+		"select * from (select o.card, u.id from orders as o join users as u) as t",
+		[]ColumnRef{{"orders", "card"}},
+	}, {
+		"with c as (select ssn from users) select ssn from c",
+		[]ColumnRef{{"users", "ssn"}},
+	}, {
+		"with c(x) as (select email from users union select card from orders) select * from c",
+		[]ColumnRef{{"users", "email"}, {"orders", "card"}},
+	}, {
+		"with c as (select id from users) select c.id from c join users as u on c.id = u.id",
+		nil,
+	}}
+	for _, tcase := range testcases {
+		stmt, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("error %v on %s", err, tcase.sql)
+			continue
+		}
+		got := SensitiveColumns(stmt, piiMap)
+		if !reflect.DeepEqual(got, tcase.want) {
+			t.Errorf("SensitiveColumns(%s): %v, want %v", tcase.sql, got, tcase.want)
+		}
+	}
+}
+
+func TestExtractSubqueries(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		want []string
+	}{{
+		"select a, count(*) from t group by a having count(*) > (select avg(c) from t2)",
+		[]string{"select avg(c) from t2"},
+	}, {
+		"select a from t where a in (select b from u where b = (select c from v)) and exists (select 1 from w)",
+		[]string{"select b from u where b = (select c from v)", "select c from v", "select 1 from w"},
+	}, {
+		"select a from (select b from u) as t join v on t.a = (select 1 from w)",
+		[]string{"select b from u", "select 1 from w"},
+	}, {
+		"insert into t select a from u where b in (select c from v)",
+		[]string{"select a from u where b in (select c from v)", "select c from v"},
+	}, {
+		"update t set a = 1 where b in (select b from u)",
+		[]string{"select b from u"},
+	}, {
+		"do sleep(1), (select a from u)",
+		[]string{"select a from u"},
+	}, {
+		"with c as (select a from u where b in (select b from v)) select * from c",
+		[]string{"select a from u where b in (select b from v)", "select b from v"},
+	}, {
+		"create view v as select a from u where b in (select c from w)",
+		[]string{"select a from u where b in (select c from w)", "select c from w"},
+	}, {
+		"select a from t where b = 1",
+		nil,
+	}}
+	for _, tcase := range testcases {
+		stmt, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("error %v on %s", err, tcase.sql)
+			continue
+		}
+		var got []string
+		for _, sub := range ExtractSubqueries(stmt) {
+			got = append(got, String(sub))
+		}
+		if !reflect.DeepEqual(got, tcase.want) {
+			t.Errorf("ExtractSubqueries(%s): %q, want %q", tcase.sql, got, tcase.want)
+		}
+	}
+}
+
+func TestCanUseIndex(t *testing.T) {
+	index := IndexDef{Name: "ab", Columns: []string{"a", "b"}}
+	testcases := []struct {
+		in     string
+		prefix int
+		covers bool
+	}{
+		{"select * from t", 0, false},
+		{"select * from t where a = 1 and b = 2", 2, true},
+		{"select * from t where b = 2 and t.a = :a", 2, true},
+		{"select * from t where (a = 1 and c = 3) and 2 = B", 2, true},
+		{"select * from t where a = 1", 1, false},
+		{"select * from t where b = 2", 0, false},
+		{"select * from t where a > 1 and b = 2", 0, false},
+		{"select * from t where a = 1 or b = 2", 0, false},
+		{"select * from t where a = c and b = 2", 0, false},
+		{"select * from t where a <=> 1 and b = 2", 2, true},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		prefix, covers := CanUseIndex(tree.(*Select).Where, index)
+		if prefix != tcase.prefix || covers != tcase.covers {
+			t.Errorf("CanUseIndex(%s): %d, %v, want %d, %v", tcase.in, prefix, covers, tcase.prefix, tcase.covers)
+		}
+	}
+}
+
+func TestIsReadOnly(t *testing.T) {
+	testcases := []struct {
+		in       string
+		readOnly bool
+	}{
+		{"select * from t", true},
+		{"select * from t for update", false},
+		{"select * from t lock in share mode", false},
+		{"select * from t where id in (select id from u for update)", false},
+		{"select * from t where id in (select id from u where a = (select a from v for update))", false},
+		{"select * from (select * from u for update) as d", false},
+		{"select * from t where id in (select id from u)", true},
+		{"select * from t union select * from u for update", false},
+		{"select * from t union select * from u where id in (select id from v lock in share mode)", false},
+		{"select * from t union select * from u", true},
+		{"select * from t into outfile 'x'", false},
+		{"select * from t into @a", true},
+		{"explain select * from t for update", true},
+		{"explain analyze select * from t", true},
+		{"explain analyze update t set a = 1", false},
+		{"show vitess_keyspaces", true},
+		{"show warnings limit 10", true},
+		{"describe t", true},
+		{"select next value for s", false},
+		{"insert into t select * from u", false},
+		{"update t set a = 1", false},
+		{"set a = 1", false},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if readOnly := IsReadOnly(tree); readOnly != tcase.readOnly {
+			t.Errorf("IsReadOnly(%s): %v, want %v", tcase.in, readOnly, tcase.readOnly)
+		}
+	}
+}
+
+func TestIsSessionLocal(t *testing.T) {
+	testcases := []struct {
+		in           string
+		sessionLocal bool
+	}{
+		{"show warnings", true},
+		{"show errors limit 1, 10", true},
+		{"show count(*) warnings", true},
+		{"show tables", false},
+		{"show session variables", false},
+		{"select @@warning_count from dual", false},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if sessionLocal := IsSessionLocal(tree); sessionLocal != tcase.sessionLocal {
+			t.Errorf("IsSessionLocal(%s): %v, want %v", tcase.in, sessionLocal, tcase.sessionLocal)
+		}
+	}
+}
+
+func TestDDLTableNames(t *testing.T) {
+	testcases := []struct {
+		in      string
+		changed []string
+		read    []string
+	}{
+		{"create table t (a int)", []string{"t"}, nil},
+		{"create table t like d.u", []string{"t"}, []string{"d.u"}},
+		{"create table t (like u)", []string{"t"}, []string{"u"}},
+		{"drop table if exists t, u", []string{"t", "u"}, nil},
+		{"create view v as select * from t join d.u where a in (select b from w)", []string{"v"}, []string{"t", "d.u", "w"}},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		changed, read := tree.(*DDLSimple).TableNames()
+		if !reflect.DeepEqual(changed, tcase.changed) || !reflect.DeepEqual(read, tcase.read) {
+			t.Errorf("TableNames(%s): %v %v, want %v %v", tcase.in, changed, read, tcase.changed, tcase.read)
+		}
+	}
+}
+
+func TestLockTables(t *testing.T) {
+	tree, err := Parse("lock tables t read, d.u write, v read local, w low_priority write, x as a read, y b write, z c read local, y AS d LOW_PRIORITY WRITE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock := tree.(*LockTables)
+	if names, want := lock.TableNames(), []string{"t", "d.u", "v", "w", "x", "y", "z", "y"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("TableNames: %v, want %v", names, want)
+	}
+	var locks []int
+	var aliases []string
+	for _, table := range lock.Tables {
+		locks = append(locks, table.Lock)
+		aliases = append(aliases, string(table.Table.As))
+	}
+	if want := []int{LOCK_READ, LOCK_WRITE, LOCK_READ_LOCAL, LOCK_LOW_PRIORITY_WRITE, LOCK_READ, LOCK_WRITE, LOCK_READ_LOCAL, LOCK_LOW_PRIORITY_WRITE}; !reflect.DeepEqual(locks, want) {
+		t.Errorf("locks: %v, want %v", locks, want)
+	}
+	if want := []string{"", "", "", "", "a", "b", "c", "d"}; !reflect.DeepEqual(aliases, want) {
+		t.Errorf("aliases: %v, want %v", aliases, want)
+	}
+}
+
+func TestGetAtTimeZone(t *testing.T) {
+	tree, err := Parse("select a at time zone 'Europe/Paris', b from t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exprs := tree.(*Select).SelectExprs
+	expr, ok := GetAtTimeZone(exprs[0].(*NonStarExpr).Expr)
+	if !ok || string(expr.TZ) != "Europe/Paris" || String(expr.Expr) != "a" {
+		t.Errorf("GetAtTimeZone: %#v, %v, want Europe/Paris, true", expr, ok)
+	}
+	if out := expr.String(); out != "a at time zone 'Europe/Paris'" {
+		t.Errorf("String: %s, want a at time zone 'Europe/Paris'", out)
+	}
+	if _, ok := GetAtTimeZone(exprs[1].(*NonStarExpr).Expr); ok {
+		t.Errorf("GetAtTimeZone(b): true, want false")
+	}
+}
+
+func TestGetSubquery(t *testing.T) {
+	tree, err := Parse("select a, (select max(b) from u where u.a = t.a) as maxb, coalesce((select c from v union select d from w), 1), (a) from t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exprs := tree.(*Select).SelectExprs
+	sel, ok := GetSubquery(exprs[1].(*NonStarExpr).Expr)
+	if !ok {
+		t.Fatalf("GetSubquery: false, want true")
+	}
+	if got, want := fmt.Sprintf("%v", ExtractColumnRefs(sel)), "[b u.a t.a]"; got != want {
+		t.Errorf("column refs: %s, want %s", got, want)
+	}
+	args := exprs[2].(*NonStarExpr).Expr.At(0).(SelectExprs)
+	if sel, ok := GetSubquery(args[0].(*NonStarExpr).Expr); !ok || String(sel) != "select c from v union select d from w" {
+		t.Errorf("GetSubquery(argument): %v, %v, want the union", sel, ok)
+	}
+	for _, i := range []int{0, 3} {
+		if _, ok := GetSubquery(exprs[i].(*NonStarExpr).Expr); ok {
+			t.Errorf("GetSubquery(%s): true, want false", String(exprs[i]))
+		}
+	}
+}
+
+func TestGetQuantifiedComparison(t *testing.T) {
+	for _, op := range []string{"=", "<", ">", "<=", ">=", "!=", "<>", "<=>"} {
+		for _, quantifier := range []string{"any", "some", "all"} {
+			sql := fmt.Sprintf("select * from t where a %s %s (select b from u)", op, quantifier)
+			tree, err := Parse(sql)
+			if err != nil {
+				t.Errorf("%s: %v", sql, err)
+				continue
+			}
+			if out := String(tree); out != sql {
+				t.Errorf("%s: formatted as %s", sql, out)
+			}
+			cmp, ok := GetQuantifiedComparison(tree.(*Select).Where.NodeAt(0))
+			if !ok {
+				t.Errorf("%s: GetQuantifiedComparison: false, want true", sql)
+				continue
+			}
+			if String(cmp.Left) != "a" || string(cmp.Operator) != op || string(cmp.Quantifier) != quantifier || String(cmp.Subquery) != "select b from u" {
+				t.Errorf("%s: %s %s %s %s", sql, String(cmp.Left), cmp.Operator, cmp.Quantifier, String(cmp.Subquery))
+			}
+		}
+	}
+	tree, err := Parse("select * from t where a = (select b from u) and a in (select b from u)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	where := tree.(*Select).Where.NodeAt(0)
+	for i := 0; i < 2; i++ {
+		if _, ok := GetQuantifiedComparison(where.NodeAt(i)); ok {
+			t.Errorf("GetQuantifiedComparison(%s): true, want false", String(where.NodeAt(i)))
+		}
+	}
+}
+
+func TestGetWindowExpr(t *testing.T) {
+	tree, err := Parse("select sum(col) over (partition by grp order by id), sum(col) from t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exprs := tree.(*Select).SelectExprs
+	expr, ok := GetWindowExpr(exprs[0].(*NonStarExpr).Expr)
+	if !ok {
+		t.Fatalf("GetWindowExpr: false, want true")
+	}
+	if out := String(expr.Func); out != "sum(col)" {
+		t.Errorf("Func: %s, want sum(col)", out)
+	}
+	if out := String(expr.Over.PartitionBy); out != "grp" {
+		t.Errorf("PartitionBy: %s, want grp", out)
+	}
+	if out := String(expr.Over.OrderBy); out != "id asc" {
+		t.Errorf("OrderBy: %s, want id asc", out)
+	}
+	if expr.Over.Frame != nil {
+		t.Errorf("Frame: %s, want nil", String(expr.Over.Frame))
+	}
+	if _, ok := GetWindowExpr(exprs[1].(*NonStarExpr).Expr); ok {
+		t.Errorf("GetWindowExpr(sum(col)): true, want false")
+	}
+
+	tree, err = Parse("select sum(col) over (order by id rows between 2 preceding and current row) from t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expr, _ = GetWindowExpr(tree.(*Select).SelectExprs[0].(*NonStarExpr).Expr)
+	frame := expr.Over.Frame
+	if out := fmt.Sprintf("%s %s/%s %s", frame.Unit, frame.Start.Type, String(frame.Start.Expr), frame.End.Type); out != "rows preceding/2 current row" {
+		t.Errorf("Frame: %s, want rows preceding/2 current row", out)
+	}
+}
+
+func TestNamedWindows(t *testing.T) {
+	tree, err := Parse("select sum(a) over w2 from t window w1 as (partition by b), w2 as (w1 order by c)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sel := tree.(*Select)
+	expr, _ := GetWindowExpr(sel.SelectExprs[0].(*NonStarExpr).Expr)
+	if out := String(expr.Over.Name); out != "w2" {
+		t.Errorf("Name: %s, want w2", out)
+	}
+	format := func(node *Node) string {
+		if node == nil {
+			return ""
+		}
+		return String(node)
+	}
+	var got []string
+	for _, window := range sel.Windows {
+		got = append(got, fmt.Sprintf("%s:%s:%s:%s", window.Name.Value, format(window.Spec.Name), format(window.Spec.PartitionBy), format(window.Spec.OrderBy)))
+	}
+	if out, want := strings.Join(got, " "), "w1::b: w2:w1::c asc"; out != want {
+		t.Errorf("Windows: %s, want %s", out, want)
+	}
+}
+
+func TestForEachTable(t *testing.T) {
+	testcases := []struct {
+		in  string
+		out string
+	}{
+		{"select * from t", "t:0"},
+		{"select * from t as a join (u join d.v) on a.id = u.id", "t:0 u:0 d.v:0"},
+		{"select * from t where a in (select b from u where c = (select d from v))", "t:0 u:1 v:2"},
+		{"select * from (select * from u join v) as d, w", "(select * from u join v):0 u:1 v:1 w:0"},
+		{"select d.a from (select t.a from (select a from x) as t join y on t.a = y.a) as d", "(select t.a from (select a from x) as t join y on t.a = y.a):0 (select a from x):1 x:2 y:1"},
+		{"select (select a from u) from t union select * from v", "u:1 t:0 v:0"},
+		{"select * from t join u on t.a = u.a and exists (select 1 from v)", "t:0 u:0 v:1"},
+		{"insert into t select * from u", "u:1"},
+		{"update t set a = (select b from u) where c in (select d from v)", "u:1 v:1"},
+		{"delete from t", ""},
+		{"explain update t set a = (select b from u join v)", "u:1 v:1"},
+		{"lock tables t read, d.u as a write", "t:0 d.u:0"},
+		{"create or replace view v (a) as select * from t join d.u", "t:1 d.u:1"},
+		{"do 1 + (select a from t), exists (select 1 from u)", "t:1 u:1"},
+		{"with c as (select * from u), d as (select * from c) select * from c join t", "u:1 c:1 c:0 t:0"},
+		{"with c as (select * from u) select * from c union select * from v", "u:1 c:0 v:0"},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		var tables []string
+		ForEachTable(tree, func(table *AliasedTableExpr, depth int) {
+			tables = append(tables, fmt.Sprintf("%s:%d", String(table.Expr), depth))
+		})
+		if out := strings.Join(tables, " "); out != tcase.out {
+			t.Errorf("ForEachTable(%s): %s, want %s", tcase.in, out, tcase.out)
+		}
+	}
+}
+
+func TestResolveAlias(t *testing.T) {
+	testcases := []struct {
+		in    string
+		alias string
+		out   string
+	}{
+		{"select * from t", "t", "t"},
+		{"select * from d.t", "t", "d.t"},
+		{"select * from t as a", "a", "t as a"},
+		{"select * from t as a", "t", ""},
+		{"select * from t join (u as b join v) on t.id = b.id", "b", "u as b"},
+		{"select * from t, (select * from u) as d", "d", "(select * from u) as d"},
+		{"select * from t, (select * from u) as d", "u", ""},
+		{"select * from t where a in (select b from u)", "u", ""},
+		{"update t set a = 1", "t", ""},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		table, ok := ResolveAlias(tree, []byte(tcase.alias))
+		if ok != (tcase.out != "") {
+			t.Errorf("ResolveAlias(%s, %s): %v, want %v", tcase.in, tcase.alias, ok, !ok)
+			continue
+		}
+		if ok && String(table) != tcase.out {
+			t.Errorf("ResolveAlias(%s, %s): %s, want %s", tcase.in, tcase.alias, String(table), tcase.out)
+		}
+	}
+}
+
+func TestValidateAliases(t *testing.T) {
+	testcases := []struct {
+		in  string
+		err string
+	}{
+		{"select * from t, u", ""},
+		{"select * from t as a, u as b", ""},
+		{"select * from t, t", "not unique table/alias: 't'"},
+		{"select * from t as a join u as a", "not unique table/alias: 'a'"},
+		{"select * from t join d.t", "not unique table/alias: 't'"},
+		{"select * from t as u, u", "not unique table/alias: 'u'"},
+		{"select * from t, (select * from t) as d", ""},
+		{"select * from t, (select * from u, u) as d", "not unique table/alias: 'u'"},
+		{"select * from t, (select * from u) as t", "not unique table/alias: 't'"},
+		{"select * from t where a in (select b from t)", ""},
+		{"select * from t union select * from t", ""},
+		{"select * from t union select * from u as a, v as a", "not unique table/alias: 'a'"},
+		{"insert into t select * from u, u", "not unique table/alias: 'u'"},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		var errStr string
+		if err := ValidateAliases(tree); err != nil {
+			errStr = err.Error()
+		}
+		if errStr != tcase.err {
+			t.Errorf("ValidateAliases(%s): %q, want %q", tcase.in, errStr, tcase.err)
+		}
+	}
+}
+
+func TestNestToFlat(t *testing.T) {
+	testcases := []struct {
+		in       string
+		out      string
+		operands int
+	}{
+		{"a = 1", "a = 1", 0},
+		{"a = 1 and b = 2", "a = 1 and b = 2", 2},
+		{"a = 1 and (b = 2 and (c = 3 and (d = 4 and e = 5)))", "a = 1 and b = 2 and c = 3 and d = 4 and e = 5", 5},
+		{"(a = 1 and b = 2) and c = 3 and d = 4", "a = 1 and b = 2 and c = 3 and d = 4", 4},
+		{"a = 1 or b = 2 or (c = 3 or d = 4)", "a = 1 or b = 2 or c = 3 or d = 4", 4},
+		{"a = 1 and (b = 2 or c = 3 or d = 4) and e = 5", "a = 1 and (b = 2 or c = 3 or d = 4) and e = 5", 3},
+		{"a = 1 or b = 2 and c = 3 and d = 4", "a = 1 or b = 2 and c = 3 and d = 4", 3},
+		{"not (a = 1 and (b = 2 and c = 3))", "not (a = 1 and b = 2 and c = 3)", 0},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse("select * from t where " + tcase.in)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		where := tree.(*Select).Where
+		flat := NestToFlat(where.NodeAt(0))
+		where.Sub[0] = flat
+		operands := 0
+		if flat.Type == AND || flat.Type == OR {
+			operands = flat.Len()
+		}
+		if out := String(flat); out != tcase.out || operands != tcase.operands {
+			t.Errorf("NestToFlat(%s): %s, %d, want %s, %d", tcase.in, out, operands, tcase.out, tcase.operands)
+		}
+		if _, err := Parse(String(tree)); err != nil {
+			t.Errorf("Parse(%s): %v", String(tree), err)
+		}
+	}
+
+	// The depth of a long chain doesn't grow with its length.
+	conditions := make([]string, 100)
+	for i := range conditions {
+		conditions[i] = fmt.Sprintf("a%d = %d", i, i)
+	}
+	tree, err := Parse("select * from t where " + strings.Join(conditions, " and (") + strings.Repeat(")", 99))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var depth func(node *Node) int
+	depth = func(node *Node) int {
+		max := 0
+		for i := 0; i < node.Len(); i++ {
+			if sub, ok := node.At(i).(*Node); ok {
+				if d := depth(sub); d > max {
+					max = d
+				}
+			}
+		}
+		return max + 1
+	}
+	where := tree.(*Select).Where.NodeAt(0)
+	flat := NestToFlat(where)
+	before := depth(where)
+	after := depth(flat)
+	if before < 200 || after != 4 {
+		t.Errorf("depth: %d before, %d after, want >= 200 and 4", before, after)
+	}
+	if flat.Len() != 100 {
+		t.Errorf("operands: %d, want 100", flat.Len())
+	}
+
+	// The input is left as a binary chain for the other analyzers.
+	if where.Len() != 2 {
+		t.Errorf("input operands: %d, want 2", where.Len())
+	}
+	tree, err = Parse("delete from t where 1 = 1 and a = 1 and b = 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	NestToFlat(tree.(*Delete).Where.NodeAt(0))
+	Simplify(tree)
+	if out, want := String(tree), "delete from t where a = 1 and b = 2"; out != want {
+		t.Errorf("Simplify after NestToFlat: %s, want %s", out, want)
+	}
+}
+
+func TestRewriteINSubqueryToJoin(t *testing.T) {
+	testcases := []struct {
+		in    string
+		out   string
+		count int
+	}{
+		{
+			"select a from t where x in (select y from u where u.b = 1)",
+			"select a from t join (select distinct y as __col from u where u.b = 1) as __sq1 on x = __sq1.__col",
+			1,
+		},
+		{
+			"select * from d.t as t1 where t1.x in (select v.y from u as v) and t1.b = 2",
+			"select t1.* from d.t as t1 join (select distinct v.y as __col from u as v) as __sq1 on t1.x = __sq1.__col where t1.b = 2",
+			1,
+		},
+		{
+			"select a from t where b = 1 and x in (select y from u) and z in (select w + 1 from v) and c = 2",
+			"select a from t join (select distinct y as __col from u) as __sq1 on x = __sq1.__col join (select distinct w+1 as __col from v) as __sq2 on z = __sq2.__col where b = 1 and c = 2",
+			2,
+		},
+		// Correlated.
+		{"select a from t where x in (select y from u where u.b = t.b)", "", 0},
+		// Multi-column.
+		{"select a from t where x in (select y, z from u)", "", 0},
+		// Aggregates.
+		{"select a from t where x in (select max(y) from u)", "", 0},
+		{"select a from t where x in (select y from u group by y)", "", 0},
+		{"select a from t where x in (select y from u group by y having count(*) > 1)", "", 0},
+		// Unsafe positions and shapes.
+		{"select a from t where b = 1 or x in (select y from u)", "", 0},
+		{"select a from t where x not in (select y from u)", "", 0},
+		{"select a from t where x in (select y from u limit 1)", "", 0},
+		{"select a from t where x in (select y from u where y in (select z from v))", "", 0},
+		{"select a from t where x in (select * from u)", "", 0},
+		{"select a from t where x in (select y from u) for update", "", 0},
+		{"select a from t, s where x in (select y from u)", "", 0},
+		{"select * from t join s where x in (select y from u)", "", 0},
+		{"select a from t where x in (1, 2)", "", 0},
+		{"select a from t where x in (select y from u) union select a from s", "", 0},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if tcase.out == "" {
+			tcase.out = tcase.in
+		}
+		count := RewriteINSubqueryToJoin(tree)
+		if out := String(tree); out != tcase.out || count != tcase.count {
+			t.Errorf("RewriteINSubqueryToJoin(%s): %s, %d, want %s, %d", tcase.in, out, count, tcase.out, tcase.count)
+		}
+		if _, err := Parse(String(tree)); err != nil {
+			t.Errorf("Parse(%s): %v", String(tree), err)
+		}
+	}
+}
+
+func TestNormalizeIN(t *testing.T) {
+	testcases := []struct {
+		in    string
+		out   string
+		count int
+	}{
+		{"select * from t where a = 1 or a = 2 or a = 3", "select * from t where a in (1, 2, 3)", 1},
+		{"select * from t where (a = 1 or a = :b) and b = 'x'", "select * from t where (a in (1, :b)) and b = 'x'", 1},
+		{"select * from t where t.a = 1 or (t.a = 2 or t.a = 3)", "select * from t where t.a in (1, 2, 3)", 1},
+		{"select * from t where a = 1 or b = 2", "select * from t where a = 1 or b = 2", 0},
+		{"select * from t where a = 1 or a > 2", "select * from t where a = 1 or a > 2", 0},
+		{"select * from t where a = 1 or a = b", "select * from t where a = 1 or a = b", 0},
+		{"select * from t where a = 1 or t.a = 2", "select * from t where a = 1 or t.a = 2", 0},
+		{"select * from t where a = 1", "select * from t where a = 1", 0},
+		{
+			"select * from t where a = 1 or a = 2 union select * from u where b in (select c from v where c = 1 or c = 2)",
+			"select * from t where a in (1, 2) union select * from u where b in (select c from v where c in (1, 2))",
+			2,
+		},
+		{"update t set a = 1 where (b = 1 or b = 2) or (c = 1 or c = 2)", "update t set a = 1 where (b in (1, 2)) or (c in (1, 2))", 2},
+		{"delete from t where a = 1 or a = 2", "delete from t where a in (1, 2)", 1},
+		{"select a from t group by a having a = 1 or a = 2", "select a from t group by a having a in (1, 2)", 1},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		count := NormalizeIN(tree)
+		if out := String(tree); out != tcase.out || count != tcase.count {
+			t.Errorf("NormalizeIN(%s): %s, %d, want %s, %d", tcase.in, out, count, tcase.out, tcase.count)
+		}
+	}
+}
+
+func TestColumnRefs(t *testing.T) {
+	testcases := []struct {
+		sql      string
+		refs     string
+		resolved string
+	}{{
+		"select db1.t1.col, db2.t2.col from db1.t1 join db2.t2 on db1.t1.id = db2.t2.id",
+		"db1.t1.col db2.t2.col db1.t1.id db2.t2.id",
+		"db1.t1.col db2.t2.col db1.t1.id db2.t2.id",
+	}, {
+		"select x.a, b from db.t as x where c = 1 order by x.b",
+		"x.a b c x.b",
+		"db.t.a db.t.b db.t.c",
+	}, {
+		"select t.a, u.b, c from t, u where (select d from v) = 1 group by t.e",
+		"t.a u.b c t.e",
+		"t.a u.b c t.e",
+	}, {
+		"select a from t union select b from u",
+		"a b",
+		"t.a u.b",
+	}, {
+		"update db.t set a = b + 1 where db.t.c = 1",
+		"a b db.t.c",
+		"db.t.a db.t.b db.t.c",
+	}, {
+		"delete from t where other.a = 1",
+		"other.a",
+		"other.a",
+	}, {
+		"insert into t(a, b) values (1, c) on duplicate key update a = d",
+		"a b c d",
+		"t.a t.b t.c t.d",
+	}}
+	join := func(names []ColumnName) string {
+		var strs []string
+		for _, name := range names {
+			strs = append(strs, name.String())
+		}
+		return strings.Join(strs, " ")
+	}
+	for _, tcase := range testcases {
+		stmt, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("error %v on %s", err, tcase.sql)
+			continue
+		}
+		if got := join(ExtractColumnRefs(stmt)); got != tcase.refs {
+			t.Errorf("ExtractColumnRefs(%s): %s, want %s", tcase.sql, got, tcase.refs)
+		}
+		if got := join(ResolveAliases(stmt)); got != tcase.resolved {
+			t.Errorf("ResolveAliases(%s): %s, want %s", tcase.sql, got, tcase.resolved)
+		}
+	}
+}
+
+func TestEstimateResultRows(t *testing.T) {
+	tableStats := map[string]TableStats{
+		"t": {RowCount: 1000, AvgSelectivity: 0.1},
+		"u": {RowCount: 50, AvgSelectivity: 0.5},
+	}
+	testcases := []struct {
+		sql  string
+		want int64
+	}{
+		{"select * from t", 1000},
+		{"select * from t where a = 1", 100},
+		{"select * from t where a = 1 and (b > 2 and c < 3)", 1},
+		{"select * from t where a = 1 or b = 2", 100},
+		{"select * from t where a = 1 limit 10", 10},
+		{"select * from t where a = 1 limit 5, 500", 100},
+		{"select * from t, u", 50000},
+		{"select * from t join u on t.id = u.tid", 2500},
+		{"select * from t as x join u as y on x.id = y.tid where y.a = 1", 1250},
+		{"select * from t, u where a = 1", 50000},
+		{"select * from t, other where t.a = 1", 100},
+		{"select * from other", 1},
+	}
+	for _, tcase := range testcases {
+		stmt, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("error %v on %s", err, tcase.sql)
+			continue
+		}
+		if got := EstimateResultRows(stmt.(*Select), tableStats); got != tcase.want {
+			t.Errorf("EstimateResultRows(%s): %d, want %d", tcase.sql, got, tcase.want)
+		}
+	}
+}
+
+func TestWritesToFile(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		path string
+		ok   bool
+	}{
+		{"select * from t into outfile '/tmp/out'", "/tmp/out", true},
+		{"select * from t limit 10 into dumpfile '/tmp/dump' for update", "/tmp/dump", true},
+		{"select * from t union select * from u into outfile 'x'", "x", true},
+		{"select * from t", "", false},
+		{"select * from t into outfile '/tmp/csv' fields terminated by ',' lines terminated by '\\n'", "/tmp/csv", true},
+		{"select a from t into @a", "", false},
+		{"select * from t where a = 'into outfile'", "", false},
+		{"insert into t select * from u", "", false},
+	}
+	for _, tcase := range testcases {
+		stmt, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("error %v on %s", err, tcase.sql)
+			continue
+		}
+		path, ok := WritesToFile(stmt)
+		if string(path) != tcase.path || ok != tcase.ok {
+			t.Errorf("WritesToFile(%s): %s, %v, want %s, %v", tcase.sql, path, ok, tcase.path, tcase.ok)
+		}
+	}
+}
+
+func TestSelectClassifiers(t *testing.T) {
+	testcases := []struct {
+		sql       string
+		procedure bool
+		outfile   bool
+	}{
+		{"select * from t", false, false},
+		{"select * from t procedure analyse()", true, false},
+		{"select * from t limit 1 procedure analyse(10, 2000) into outfile 'x'", true, true},
+		{"select * from t procedure other()", false, false},
+		{"select * from t into dumpfile 'x'", false, false},
+		{"select * from t into outfile 'x' for update", false, true},
+	}
+	for _, tcase := range testcases {
+		stmt, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("error %v on %s", err, tcase.sql)
+			continue
+		}
+		sel := stmt.(*Select)
+		if got := HasProcedureAnalyse(sel); got != tcase.procedure {
+			t.Errorf("HasProcedureAnalyse(%s): %v, want %v", tcase.sql, got, tcase.procedure)
+		}
+		if got := HasIntoOutfile(sel); got != tcase.outfile {
+			t.Errorf("HasIntoOutfile(%s): %v, want %v", tcase.sql, got, tcase.outfile)
+		}
+	}
+}
+
+func TestProjectionWidth(t *testing.T) {
+	testcases := []struct {
+		in    string
+		n     int
+		exact bool
+	}{
+		{"select a from t", 1, true},
+		{"select a, b + 1 as c, count(*), (select d from u) from t", 4, true},
+		{"select * from t", 0, false},
+		{"select t.* from t", 0, false},
+		{"select a, t.*, b from t", 2, false},
+		{"select a, b from t union select c, d from u union all select * from v", 2, true},
+		{"select * from t union select c, d from u", 0, false},
+		{"insert into t values (1)", 0, false},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		n, exact := ProjectionWidth(tree)
+		if n != tcase.n || exact != tcase.exact {
+			t.Errorf("ProjectionWidth(%s): %d, %v, want %d, %v", tcase.in, n, exact, tcase.n, tcase.exact)
+		}
+	}
+}
+
+func TestSetAssignments(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		want string
+	}{
+		{"set a = 1", "a=1"},
+		{"set @x = @y = 5", "@x=5 @y=5"},
+		{"set @x := @y := @z := 5, b = 'c'", "@x=5 @y=5 @z=5 b='c'"},
+		{"set @x = @y := 5 + 1", "@x=5+1 @y=5+1"},
+		{"set @x = 1 = @y", "@x=1 = @y"},
+		{"set sql_mode = default, @@session.a = @b := DEFAULT", "sql_mode=default session.a=default @b=default"},
+		{"set global a = 1, b = @c := 2, @d = 3, local e = 4", "global.a=1 global.b=2 @c=2 @d=3 session.e=4"},
+	}
+	for _, tcase := range testcases {
+		stmt, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("error %v on %s", err, tcase.sql)
+			continue
+		}
+		var got []string
+		for _, assignment := range stmt.(*Set).Assignments() {
+			name := assignment.Name
+			if assignment.Scope != nil {
+				name = string(assignment.Scope) + "." + name
+			}
+			got = append(got, name+"="+String(assignment.Expr))
+		}
+		if strings.Join(got, " ") != tcase.want {
+			t.Errorf("Assignments(%s): %s, want %s", tcase.sql, strings.Join(got, " "), tcase.want)
+		}
+	}
+}
+
+func TestStampVersion(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		want uint64
+	}{
+		{"create table a (b int)", 7},
+		{"alter table a convert to character set utf8", 7},
+		{"drop table a", 7},
+		{"rename table a to b", 7},
+		{"select * from a", 0},
+	}
+	for _, tcase := range testcases {
+		stmt, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("error %v on %s", err, tcase.sql)
+			continue
+		}
+		if got := SchemaVersion(stmt); got != 0 {
+			t.Errorf("SchemaVersion(%s): %d, want 0", tcase.sql, got)
+		}
+		StampVersion(stmt, 7)
+		if got := SchemaVersion(stmt); got != tcase.want {
+			t.Errorf("SchemaVersion(%s): %d, want %d", tcase.sql, got, tcase.want)
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	testcases := []struct {
+		in    string
+		out   string
+		count int
+	}{
+		{"select * from t where 1 = 1 and x = 5", "select * from t where x = 5", 1},
+		{"select * from t where x = 5 and (1 = 1)", "select * from t where x = 5", 1},
+		{"select * from t where x = 5 or 1 = 0", "select * from t where x = 5", 1},
+		{"select * from t where x = 5 or not (1 = 1)", "select * from t where x = 5", 1},
+		{"select * from t where 1 = 1", "select * from t", 1},
+		{"select * from t where true and x = 5", "select * from t where x = 5", 1},
+		{"select * from t where x = 5 or false", "select * from t where x = 5", 1},
+		{"select * from t where x = 5 and not false", "select * from t where x = 5", 1},
+		{"select * from t where (true)", "select * from t", 1},
+		{"select * from t where false or true", "select * from t", 2},
+		{"select * from t where x = 5 and false", "select * from t where false", 1},
+		{"select * from t where (x = 1 and 2 > 1) or (y = 2 and 1 <> 1)", "select * from t where (x = 1)", 3},
+		{"select * from t where x = 5 or 1 = 1", "select * from t", 2},
+		{"select * from t where 1 = 0 and x = 5", "select * from t where 1 = 0", 1},
+		{"select a from t group by a having 1 = 1 and count(*) > 1", "select a from t group by a having count(*) > 1", 1},
+		{"update t set a = 1 where 1 = 1 and b = 2", "update t set a = 1 where b = 2", 1},
+		{"delete from t where b = 2 and 1 = 1", "delete from t where b = 2", 1},
+		{"select * from t where a in (select b from u where 1 = 1 and c = 1)", "select * from t where a in (select b from u where c = 1)", 1},
+		// Must not fold.
+		{"select * from t where 1 = 0 and sleep(1) = 0", "select * from t where 1 = 0 and sleep(1) = 0", 0},
+		{"select * from t where 1 = 1 or a in (select b from u)", "select * from t where 1 = 1 or a in (select b from u)", 0},
+		{"select * from t where 'a' = 'a' and x = 5", "select * from t where 'a' = 'a' and x = 5", 0},
+		{"select * from t where 1 = 1.0 and x = 5", "select * from t where 1 = 1.0 and x = 5", 0},
+		{"select * from t where x = x and y = 1", "select * from t where x = x and y = 1", 0},
+		{"select * from t where false and sleep(1) = 0", "select * from t where false and sleep(1) = 0", 0},
+		{"select * from t where a = true and x = 5", "select * from t where a = true and x = 5", 0},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		count := Simplify(tree)
+		if out := String(tree); out != tcase.out || count != tcase.count {
+			t.Errorf("Simplify(%s): %s, %d, want %s, %d", tcase.in, out, count, tcase.out, tcase.count)
+		}
+	}
+}
+
+func TestValidateDDLForOnlineSchema(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		want []DDLWarning
+	}{{
+		"alter table a add column b int, add index (b)",
+		nil,
+	}, {
+		"alter table a add column b int not null",
+		[]DDLWarning{{"add column b int not null", "column b is NOT NULL without a DEFAULT"}},
+	}, {
+		"alter table a add column b int not null default 0, add column c int not null auto_increment",
+		nil,
+	}, {
+		"alter table a change column b c int",
+		[]DDLWarning{
+			{"change column b c int", "column b is renamed to c"},
+			{"change column b c int", "the type of column b may be narrowed"},
+		},
+	}, {
+		"alter table a modify b varchar(10) not null",
+		[]DDLWarning{
+			{"modify column b varchar(10) not null", "the type of column b may be narrowed"},
+			{"modify column b varchar(10) not null", "column b is NOT NULL without a DEFAULT"},
+		},
+	}, {
+		"alter table a add fulltext key ft (b)",
+		[]DDLWarning{{"add fulltext index ft (b)", "fulltext indexes cannot be added online"}},
+	}, {
+		"alter table a convert to character set utf8",
+		nil,
+	}, {
+		"create table a (b int not null)",
+		nil,
+	}, {
+		"select * from a",
+		nil,
+	}}
+	for _, tcase := range testcases {
+		stmt, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("error %v on %s", err, tcase.sql)
+			continue
+		}
+		if got := ValidateDDLForOnlineSchema(stmt); !reflect.DeepEqual(got, tcase.want) {
+			t.Errorf("ValidateDDLForOnlineSchema(%s): %v, want %v", tcase.sql, got, tcase.want)
+		}
+	}
+}
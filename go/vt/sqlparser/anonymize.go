@@ -0,0 +1,239 @@
+// Copyright 2013, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Anonymize parses sql and returns it with the database, table and
+// column names replaced by placeholders like db1, tbl1 and col1.
+// The same identifier is always replaced by the same placeholder.
+// Literals are replaced by '?', and comments are removed. User and
+// system variables like @a and @@sql_mode are kept as they are. The
+// returned map contains the original identifier of every placeholder.
+func Anonymize(sql string) (string, map[string]string, error) {
+	stmt, err := Parse(sql)
+	if err != nil {
+		return "", nil, err
+	}
+	an := &anonymizer{
+		kinds:        make(map[*Node]string),
+		placeholders: make(map[string]string),
+		counts:       make(map[string]int),
+		mapping:      make(map[string]string),
+	}
+	an.markStatement(stmt)
+	for _, subquery := range ExtractSubqueries(stmt) {
+		an.markStatement(subquery)
+	}
+	buf := NewTrackedBuffer(an.format)
+	buf.Fprintf("%v", stmt)
+	return buf.String(), an.mapping, nil
+}
+
+type anonymizer struct {
+	// kinds contains the placeholder prefix of the identifiers
+	// that are not column names.
+	kinds map[*Node]string
+	// placeholders maps prefix:identifier to its placeholder.
+	placeholders map[string]string
+	counts       map[string]int
+	mapping      map[string]string
+}
+
+// placeholder returns the placeholder for name, using kind
+// as the placeholder prefix.
+func (an *anonymizer) placeholder(kind string, name []byte) []byte {
+	key := kind + ":" + string(name)
+	if placeholder, ok := an.placeholders[key]; ok {
+		return []byte(placeholder)
+	}
+	an.counts[kind]++
+	placeholder := fmt.Sprintf("%s%d", kind, an.counts[kind])
+	an.placeholders[key] = placeholder
+	an.mapping[placeholder] = string(name)
+	return []byte(placeholder)
+}
+
+// markStatement marks the table names of stmt, and replaces the
+// aliases of stmt that are not formatted as nodes.
+func (an *anonymizer) markStatement(stmt SQLNode) {
+	switch stmt := stmt.(type) {
+	case *Union:
+		an.markWith(stmt.With)
+		an.markStatement(stmt.First)
+		for _, arm := range stmt.Arms {
+			an.markStatement(arm.Select)
+		}
+	case *ParenSelect:
+		an.markStatement(stmt.Select)
+	case *Select:
+		an.markWith(stmt.With)
+		for _, expr := range stmt.SelectExprs {
+			switch expr := expr.(type) {
+			case *StarExpr:
+				if expr.TableName != nil {
+					expr.TableName = an.placeholder("tbl", expr.TableName)
+				}
+			case *NonStarExpr:
+				if expr.As != nil {
+					expr.As = an.placeholder("col", expr.As)
+				}
+			}
+		}
+		for _, tableExpr := range stmt.From {
+			an.markTableExpr(tableExpr)
+		}
+	case *Insert:
+		an.markTable(stmt.Table)
+		an.markPartitions(stmt.Partitions)
+		if stmt.RowAlias != nil {
+			an.kinds[stmt.RowAlias.Name] = "tbl"
+		}
+	case *Replace:
+		an.markTable(stmt.Table)
+	case *Update:
+		an.markTable(stmt.Table)
+		an.markPartitions(stmt.Partitions)
+	case *Delete:
+		an.markTable(stmt.Table)
+		an.markPartitions(stmt.Partitions)
+	case *DDLSimple:
+		for _, table := range stmt.Tables {
+			an.markTable(table)
+		}
+		an.markTable(stmt.Table)
+		if stmt.OptLike != nil {
+			an.markTable(stmt.OptLike.LikeTable)
+		}
+	case *DBDDL:
+		an.kinds[stmt.DBName] = "db"
+	case *Truncate:
+		an.markTable(stmt.Table)
+	case *Describe:
+		an.markTable(stmt.Table)
+	case *Use:
+		an.kinds[stmt.DBName] = "db"
+	case *Show:
+		if stmt.OnTable != nil {
+			an.markTable(stmt.OnTable)
+		}
+	case *Rename:
+		an.markTable(stmt.OldName)
+		an.markTable(stmt.NewName)
+	case *OtherAdmin:
+		for _, table := range stmt.Tables {
+			an.markTable(table)
+		}
+	case *Load:
+		an.markTable(stmt.Table)
+	case *Grant:
+		an.markTable(stmt.On)
+	case *Flush:
+		for _, table := range stmt.Tables {
+			an.markTable(table)
+		}
+	case *LockTables:
+		for _, table := range stmt.Tables {
+			an.markTableExpr(table.Table)
+		}
+	case *Explain:
+		an.markStatement(stmt.Statement)
+	}
+}
+
+// markWith marks the names of the common table expressions
+// of with as table names, like the references to them.
+func (an *anonymizer) markWith(with *With) {
+	if with == nil {
+		return
+	}
+	for _, cte := range with.CTEs {
+		an.kinds[cte.Name] = "tbl"
+	}
+}
+
+func (an *anonymizer) markTableExpr(tableExpr TableExpr) {
+	switch tableExpr := tableExpr.(type) {
+	case *AliasedTableExpr:
+		an.markTable(tableExpr.Expr)
+		an.markPartitions(tableExpr.Partitions)
+		if tableExpr.As != nil {
+			tableExpr.As = an.placeholder("tbl", tableExpr.As)
+		}
+	case *ParenTableExpr:
+		an.markTableExpr(tableExpr.Inner)
+	case *JoinTableExpr:
+		an.markTableExpr(tableExpr.LeftExpr)
+		an.markTableExpr(tableExpr.RightExpr)
+	}
+}
+
+func (an *anonymizer) markPartitions(partitions Partitions) {
+	for _, partition := range partitions {
+		an.kinds[partition] = "part"
+	}
+}
+
+// markTable marks node as a table name if it's
+// either table or db.table.
+func (an *anonymizer) markTable(node *Node) {
+	switch node.Type {
+	case ID:
+		an.kinds[node] = "tbl"
+	case '.':
+		an.kinds[node] = "."
+		an.kinds[node.NodeAt(0)] = "db"
+		an.kinds[node.NodeAt(1)] = "tbl"
+	}
+}
+
+func (an *anonymizer) format(buf *TrackedBuffer, node SQLNode) {
+	if _, ok := node.(Comments); ok {
+		// Comments can hold anything, like user names.
+		return
+	}
+	n, ok := node.(*Node)
+	if !ok {
+		node.Format(buf)
+		return
+	}
+	switch n.Type {
+	case STRING, NUMBER:
+		buf.Fprintf("?")
+	case ID:
+		if isVariable(n) {
+			n.Format(buf)
+			return
+		}
+		kind, ok := an.kinds[n]
+		if !ok {
+			kind = "col"
+		}
+		buf.Fprintf("%s", an.placeholder(kind, n.Value))
+	case '.':
+		if isVariable(n.NodeAt(0)) {
+			// A system variable like @@session.sql_mode.
+			buf.Fprintf("%s", String(n))
+			return
+		}
+		if _, ok := an.kinds[n]; !ok {
+			// A column name qualified by a table name.
+			an.kinds[n] = "."
+			an.markTable(n.NodeAt(0))
+		}
+		n.Format(buf)
+	default:
+		n.Format(buf)
+	}
+}
+
+// isVariable returns true if node is the name of
+// a user or system variable, like @a or @@session.
+func isVariable(node *Node) bool {
+	return node.Type == ID && bytes.HasPrefix(node.Value, []byte("@"))
+}
@@ -0,0 +1,98 @@
+// Copyright 2013, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnonymize(t *testing.T) {
+	testcases := []struct {
+		sql     string
+		out     string
+		mapping map[string]string
+	}{{
+		"select name, email from users where id = 12 and name = 'bob'",
+		"select col1, col2 from tbl1 where col3 = ? and col1 = ?",
+		map[string]string{"col1": "name", "col2": "email", "col3": "id", "tbl1": "users"},
+	}, {
+		"select u.id, o.total as amount, count(*) from users as u join shop.orders as o on u.id = o.user_id group by u.id",
+		"select tbl1.col2, tbl2.col3 as col1, count(*) from tbl3 as tbl1 join db1.tbl4 as tbl2 on tbl1.col2 = tbl2.col4 group by tbl1.col2",
+		map[string]string{
+			"col1": "amount", "col2": "id", "col3": "total", "col4": "user_id",
+			"db1": "shop", "tbl1": "u", "tbl2": "o", "tbl3": "users", "tbl4": "orders",
+		},
+	}, {
+		"select a from t where b in (select a from u where c = :c)",
+		"select col1 from tbl1 where col2 in (select col1 from tbl2 where col3 = :c)",
+		map[string]string{"col1": "a", "col2": "b", "col3": "c", "tbl1": "t", "tbl2": "u"},
+	}, {
+		"update t set a = a + 1 where t.b = 'x'",
+		"update tbl1 set col1 = col1+? where tbl1.col2 = ?",
+		map[string]string{"col1": "a", "col2": "b", "tbl1": "t"},
+	}, {
+		"insert into t(a, b) values (1, 'x') on duplicate key update b = 2",
+		"insert into tbl1(col1, col2) values (?, ?) on duplicate key update col2 = ?",
+		map[string]string{"col1": "a", "col2": "b", "tbl1": "t"},
+	}, {
+		"delete from t partition (p0, p1) where a = 1",
+		"delete from tbl1 partition (part1, part2) where col1 = ?",
+		map[string]string{"col1": "a", "part1": "p0", "part2": "p1", "tbl1": "t"},
+	}, {
+		"lock tables t read, d.u write",
+		"lock tables tbl1 read, db1.tbl2 write",
+		map[string]string{"db1": "d", "tbl1": "t", "tbl2": "u"},
+	}, {
+		"create table t like d.u",
+		"create table tbl1 like db1.tbl2",
+		map[string]string{"db1": "d", "tbl1": "t", "tbl2": "u"},
+	}, {
+		"grant select on d.* to 'app'@'%'",
+		"grant select on db1.* to ?@?",
+		map[string]string{"db1": "d"},
+	}, {
+		"show create table d.t",
+		"show create table db1.tbl1",
+		map[string]string{"db1": "d", "tbl1": "t"},
+	}, {
+		"use d",
+		"use db1",
+		map[string]string{"db1": "d"},
+	}, {
+		"with c as (select a from t) select a from c",
+		"with tbl1 as (select col1 from tbl2) select col1 from tbl1",
+		map[string]string{"col1": "a", "tbl1": "c", "tbl2": "t"},
+	}, {
+		"create database if not exists d charset utf8",
+		"create database if not exists db1 character set utf8",
+		map[string]string{"db1": "d"},
+	}, {
+		"select /* user:alice */ a from t where b = @x and c = @@session.sql_mode and d = @@autocommit",
+		"select col1 from tbl1 where col2 = @x and col3 = @@session.sql_mode and col4 = @@autocommit",
+		map[string]string{"col1": "a", "col2": "b", "col3": "c", "col4": "d", "tbl1": "t"},
+	}, {
+		"update /* user:alice */ t set a = @y",
+		"update tbl1 set col1 = @y",
+		map[string]string{"col1": "a", "tbl1": "t"},
+	}}
+	for _, tcase := range testcases {
+		out, mapping, err := Anonymize(tcase.sql)
+		if err != nil {
+			t.Errorf("Anonymize(%s): %v", tcase.sql, err)
+			continue
+		}
+		if out != tcase.out {
+			t.Errorf("Anonymize(%s): %s, want %s", tcase.sql, out, tcase.out)
+		}
+		if !reflect.DeepEqual(mapping, tcase.mapping) {
+			t.Errorf("Anonymize(%s): %v, want %v", tcase.sql, mapping, tcase.mapping)
+		}
+	}
+
+	if _, _, err := Anonymize("select from"); err == nil {
+		t.Errorf("Anonymize(select from): nil, want error")
+	}
+}
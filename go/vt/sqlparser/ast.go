@@ -7,6 +7,7 @@ package sqlparser
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/youtube/vitess/go/sqltypes"
 )
@@ -36,13 +37,95 @@ type Node struct {
 }
 
 func Parse(sql string) (Statement, error) {
-	tokenizer := NewStringTokenizer(sql)
+	return ParseWithOptions(sql, DefaultParserOptions)
+}
+
+// MustParse is like Parse, but panics if sql doesn't parse.
+// It's meant for tests and statically defined queries.
+func MustParse(sql string) Statement {
+	stmt, err := Parse(sql)
+	if err != nil {
+		panic(fmt.Sprintf("MustParse(%q): %v", sql, err))
+	}
+	return stmt
+}
+
+// ParseWithOptions parses sql using the dialect
+// flags specified by options.
+func ParseWithOptions(sql string, options ParserOptions) (Statement, error) {
+	tokenizer := NewTokenizerWithOptions(sql, options)
 	if yyParse(tokenizer) != 0 {
+		if stmt, ok := parseOtherShow(sql, options); ok {
+			return stmt, nil
+		}
+		if stmt, ok := parseExtensionStatement(sql, options); ok {
+			return stmt, nil
+		}
 		return nil, NewParserError("%s", tokenizer.LastError)
 	}
 	return tokenizer.ParseTree, nil
 }
 
+// parseCreateTable returns the CREATE TABLE of sql without
+// its definition if sql is a CREATE TABLE that doesn't parse,
+// or nil otherwise.
+func parseCreateTable(sql string) *DDLSimple {
+	tokenizer := NewTokenizerWithOptions(sql, DefaultParserOptions)
+	if yyParse(tokenizer) == 0 {
+		return nil
+	}
+	return tokenizer.createTable
+}
+
+// showTypes are the words after SHOW that have a typed
+// representation. The other SHOW statements are kept as text.
+var showTypes = map[string]bool{
+	"vitess_keyspaces": true,
+	"vitess_shards":    true,
+	"tables":           true,
+	"databases":        true,
+	"schemas":          true,
+	"columns":          true,
+	"fields":           true,
+	"variables":        true,
+	"status":           true,
+	"global":           true,
+	"session":          true,
+	"local":            true,
+	"warnings":         true,
+	"errors":           true,
+	"count":            true,
+}
+
+// parseOtherShow returns a SHOW_OTHER Show if sql is a
+// SHOW statement that isn't one of showTypes, which the
+// grammar doesn't accept.
+func parseOtherShow(sql string, options ParserOptions) (Statement, bool) {
+	tokenizer := NewTokenizerWithOptions(sql, options)
+	tok := tokenizer.Scan()
+	for tok.Type == COMMENT {
+		tok = tokenizer.Scan()
+	}
+	if tok.Type != SHOW {
+		return nil, false
+	}
+	// The character following SHOW has already been read.
+	rest := ""
+	if tokenizer.lastChar != EOFCHAR {
+		rest = sql[tokenizer.position-1:]
+	}
+	tok = tokenizer.Scan()
+	if tok.Type == 0 || tok.Type == LEX_ERROR || showTypes[string(bytes.ToLower(tok.Value))] {
+		return nil, false
+	}
+	if tok.Type == CREATE {
+		if tok = tokenizer.Scan(); tok.Type == TABLE || tok.Type == VIEW {
+			return nil, false
+		}
+	}
+	return &Show{Type: SHOW_OTHER, Raw: []byte(strings.TrimSpace(rest))}, true
+}
+
 func NewSimpleParseNode(Type int, value string) *Node {
 	return &Node{Type: Type, Value: []byte(value)}
 }
@@ -97,10 +180,6 @@ func (node *Node) Format(buf *TrackedBuffer) {
 			buf.Fprintf(" as %v", node.NodeAt(1).At(0))
 		}
 		buf.Fprintf("%v", node.At(2))
-	case USE, FORCE:
-		if node.Len() != 0 {
-			buf.Fprintf(" %s index %v", node.Value, node.At(0))
-		}
 	case WHERE, HAVING:
 		if node.Len() > 0 {
 			buf.Fprintf(" %s %v", node.Value, node.At(0))
@@ -116,7 +195,7 @@ func (node *Node) Format(buf *TrackedBuffer) {
 				buf.Fprintf(", %v", node.At(1))
 			}
 		}
-	case INDEX_LIST:
+	case TUPLE:
 		if node.Len() > 0 {
 			buf.Fprintf("(%v", node.At(0))
 			for i := 1; i < node.Len(); i++ {
@@ -145,20 +224,16 @@ func (node *Node) Format(buf *TrackedBuffer) {
 		if node.Len() != 0 {
 			buf.Fprintf(" on duplicate key update %v", node.At(0))
 		}
-	case NUMBER, NULL, NO_LOCK, TABLE, FOR_UPDATE, LOCK_IN_SHARE_MODE:
+	case NUMBER, NULL, TRUE, FALSE, DEFAULT, TABLE, SET_NAMES, SET_CHARSET, WILDCARD:
 		buf.Fprintf("%s", node.Value)
 	case ID:
-		if _, ok := keywords[string(node.Value)]; ok {
-			buf.Fprintf("`%s`", node.Value)
-		} else {
-			buf.Fprintf("%s", node.Value)
-		}
+		formatID(buf, node.Value)
 	case VALUE_ARG:
 		buf.WriteArg(string(node.Value[1:]))
 	case STRING:
 		s := sqltypes.MakeString(node.Value)
 		s.EncodeSql(buf)
-	case '+', '-', '*', '/', '%', '&', '|', '^', '.':
+	case '+', '-', '*', '/', '%', '&', '|', '^', '.', CONCAT_PIPE, JSON_EXTRACT_OP, JSON_UNQUOTE_EXTRACT_OP:
 		buf.Fprintf("%v%s%v", node.At(0), node.Value, node.At(1))
 	case CASE_WHEN:
 		buf.Fprintf("case %v end", node.At(0))
@@ -168,23 +243,39 @@ func (node *Node) Format(buf *TrackedBuffer) {
 		buf.Fprintf("when %v then %v", node.At(0), node.At(1))
 	case ELSE:
 		buf.Fprintf("else %v", node.At(0))
-	case '=', '>', '<', GE, LE, NE, NULL_SAFE_EQUAL, AS, AND, OR, UNION, UNION_ALL, MINUS, EXCEPT, INTERSECT, LIKE, NOT_LIKE, IN, NOT_IN:
+	case '=', '>', '<', GE, LE, NE, NULL_SAFE_EQUAL, ASSIGN, AS, UNION, UNION_ALL, MINUS, EXCEPT, INTERSECT, LIKE, NOT_LIKE, IN, NOT_IN:
+		buf.Fprintf("%v %s %v", node.At(0), node.Value, node.At(1))
+	case AND, OR:
+		// Flattened chains have more than two operands.
+		for i, sub := range node.Sub {
+			if i != 0 {
+				buf.Fprintf(" %s ", node.Value)
+			}
+			buf.Fprintf("%v", sub)
+		}
+	case COLLATE, AT_TIME_ZONE:
 		buf.Fprintf("%v %s %v", node.At(0), node.Value, node.At(1))
 	case '(':
 		buf.Fprintf("(%v)", node.At(0))
 	case EXISTS:
 		buf.Fprintf("%s (%v)", node.Value, node.At(0))
+	case OVER:
+		buf.Fprintf("%v %s %v", node.At(0), node.Value, node.At(1))
+	case MEMBER_OF:
+		buf.Fprintf("%v %s (%v)", node.At(0), node.Value, node.At(1))
 	case FUNCTION:
 		if node.Len() == 2 { // DISTINCT
 			buf.Fprintf("%s(%v%v)", node.Value, node.At(0), node.At(1))
 		} else {
 			buf.Fprintf("%s(%v)", node.Value, node.At(0))
 		}
+	case VALUES_FUNC:
+		buf.Fprintf("values(%v)", node.At(0))
 	case UPLUS, UMINUS, '~':
 		buf.Fprintf("%s%v", node.Value, node.At(0))
-	case NOT, VALUES:
+	case NOT, VALUES, ANY, SOME, ALL:
 		buf.Fprintf("%s %v", node.Value, node.At(0))
-	case ASC, DESC, IS_NULL, IS_NOT_NULL:
+	case ASC, DESC, IS_NULL, IS_NOT_NULL, NULLS_FIRST, NULLS_LAST:
 		buf.Fprintf("%v %s", node.At(0), node.Value)
 	case BETWEEN, NOT_BETWEEN:
 		buf.Fprintf("%v %s %v and %v", node.At(0), node.Value, node.At(1), node.At(2))
@@ -211,6 +302,35 @@ func AnonymizedFormatter(buf *TrackedBuffer, node SQLNode) {
 	}
 }
 
+// formatID writes the identifier id, quoted with backticks if it
+// wouldn't scan back as the same identifier otherwise: it's a keyword,
+// it starts with a digit, or it contains anything other than ASCII
+// letters and digits, like spaces or non-ASCII characters.
+func formatID(buf *TrackedBuffer, id []byte) {
+	if !needsQuoting(id) {
+		buf.Write(id)
+		return
+	}
+	buf.WriteByte('`')
+	buf.Write(bytes.Replace(id, []byte("`"), []byte("``"), -1))
+	buf.WriteByte('`')
+}
+
+func needsQuoting(id []byte) bool {
+	if len(id) == 0 || isDigit(uint16(id[0])) {
+		return true
+	}
+	if _, ok := keywords[string(bytes.ToLower(id))]; ok {
+		return true
+	}
+	for _, ch := range id {
+		if isMultibyte(uint16(ch)) || !isLetter(uint16(ch)) && !isDigit(uint16(ch)) {
+			return true
+		}
+	}
+	return false
+}
+
 // TrackedBuffer is used to rebuild a query from the ast.
 // bindLocations keeps track of locations in the buffer that
 // use bind variables for efficient future substitutions.
@@ -0,0 +1,60 @@
+// Copyright 2013, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import "reflect"
+
+// DeepSize returns an estimate of the number of bytes retained
+// by a parse tree: the node structs, their values and the child
+// slices. It's meant to be used to bound the memory of caches
+// that hold parsed statements, and is only approximate.
+func DeepSize(stmt Statement) int {
+	if stmt == nil {
+		return 0
+	}
+	seen := make(map[uintptr]bool)
+	return deepSize(reflect.ValueOf(stmt), seen)
+}
+
+func deepSize(v reflect.Value, seen map[uintptr]bool) int {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		elem := v.Elem()
+		return int(elem.Type().Size()) + deepSize(elem, seen)
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		elem := v.Elem()
+		if elem.Kind() == reflect.Ptr {
+			return deepSize(elem, seen)
+		}
+		// Non-pointer values stored in an interface are boxed.
+		return int(elem.Type().Size()) + deepSize(elem, seen)
+	case reflect.Slice:
+		if v.IsNil() {
+			return 0
+		}
+		size := v.Cap() * int(v.Type().Elem().Size())
+		for i := 0; i < v.Len(); i++ {
+			size += deepSize(v.Index(i), seen)
+		}
+		return size
+	case reflect.String:
+		return v.Len()
+	case reflect.Struct:
+		size := 0
+		for i := 0; i < v.NumField(); i++ {
+			size += deepSize(v.Field(i), seen)
+		}
+		return size
+	}
+	// Scalars are accounted for by their container.
+	return 0
+}
@@ -0,0 +1,31 @@
+// Copyright 2013, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import "testing"
+
+func TestDeepSize(t *testing.T) {
+	queries := []string{
+		"select a from t",
+		"select a, b from t where c = 1",
+		"select a, b, c from t join u on t.id = u.id where c = 1 and d in (1, 2, 3) order by a limit 10",
+		"select a, b, c from t join u on t.id = u.id where c = 1 and d in (select e from v where f = 'a long string value') order by a limit 10",
+	}
+	last := 0
+	for _, sql := range queries {
+		stmt, err := Parse(sql)
+		if err != nil {
+			t.Fatalf("error %v on %s", err, sql)
+		}
+		size := DeepSize(stmt)
+		if size <= last {
+			t.Errorf("DeepSize(%s): %d, want more than %d", sql, size, last)
+		}
+		last = size
+	}
+	if size := DeepSize(nil); size != 0 {
+		t.Errorf("DeepSize(nil): %d, want 0", size)
+	}
+}
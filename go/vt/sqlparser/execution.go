@@ -165,10 +165,15 @@ type ExecPlan struct {
 	SetValue interface{}
 }
 
+// DDLPlan describes the schema change of a DDL. Temporary
+// tables belong to the session, and are not in the schema.
+// View is set if the statement is on views.
 type DDLPlan struct {
 	Action    int
 	TableName string
 	NewName   string
+	Temporary bool
+	View      bool
 }
 
 type StreamExecPlan struct {
@@ -229,7 +234,13 @@ func StreamExecParse(sql string, sensitiveMode bool) (plan *StreamExecPlan, err
 func DDLParse(sql string) (plan *DDLPlan) {
 	statement, err := Parse(sql)
 	if err != nil {
-		return &DDLPlan{Action: 0}
+		// A CREATE TABLE whose definition doesn't parse
+		// is still classified by its table name.
+		ddl := parseCreateTable(sql)
+		if ddl == nil {
+			return &DDLPlan{Action: 0}
+		}
+		statement = ddl
 	}
 	switch stmt := statement.(type) {
 	case *DDLSimple:
@@ -237,6 +248,8 @@ func DDLParse(sql string) (plan *DDLPlan) {
 			Action:    stmt.Action,
 			TableName: string(stmt.Table.Value),
 			NewName:   string(stmt.Table.Value),
+			Temporary: stmt.Temporary,
+			View:      stmt.View,
 		}
 	case *Rename:
 		return &DDLPlan{
@@ -244,6 +257,17 @@ func DDLParse(sql string) (plan *DDLPlan) {
 			TableName: string(stmt.OldName.Value),
 			NewName:   string(stmt.NewName.Value),
 		}
+	case *Truncate:
+		// Tables of other databases aren't in the schema.
+		tableName := stmt.Table.collectTableName()
+		if tableName == "" {
+			break
+		}
+		return &DDLPlan{
+			Action:    TRUNCATE,
+			TableName: tableName,
+			NewName:   tableName,
+		}
 	}
 	return &DDLPlan{Action: 0}
 }
@@ -270,7 +294,7 @@ func execAnalyzeSql(statement Statement, getTable TableGetter) (plan *ExecPlan)
 		return execAnalyzeDelete(stmt, getTable)
 	case *Set:
 		return execAnalyzeSet(stmt)
-	case *DDLSimple, *Rename:
+	case *DDLSimple, *Rename, *Truncate:
 		return &ExecPlan{PlanId: PLAN_DDL}
 	}
 	panic(NewParserError("invalid SQL"))
@@ -528,12 +552,14 @@ func execAnalyzeSet(set *Set) (plan *ExecPlan) {
 		PlanId:    PLAN_SET,
 		FullQuery: GenerateFullQuery(set),
 	}
-	if set.Updates.Len() > 1 { // Multiple set values
+	if len(set.Exprs) != 1 { // Multiple set values, or set transaction
 		return
 	}
-	update_expression := set.Updates.NodeAt(0)              // '='
-	plan.SetKey = string(update_expression.NodeAt(0).Value) // ID
-	expression := update_expression.NodeAt(1)
+	if set.Exprs[0].Scope != nil { // MySQL system variable
+		return
+	}
+	plan.SetKey = string(set.Exprs[0].Name.Value) // ID
+	expression := set.Exprs[0].Expr
 	valstr := string(expression.Value)
 	if expression.Type == NUMBER {
 		if ival, err := strconv.ParseInt(valstr, 0, 64); err == nil {
@@ -620,14 +646,14 @@ func execGetColumnName(node *Node) string {
 // From
 
 func execAnalyzeFrom(tableExprs TableExprs) (tablename string, hasHints bool) {
-	if len(tableExprs) > 1 {
+	if len(tableExprs) != 1 {
 		return "", false
 	}
 	node, ok := tableExprs[0].(*AliasedTableExpr)
 	if !ok {
 		return "", false
 	}
-	return node.Expr.collectTableName(), node.Hint != nil
+	return node.Expr.collectTableName(), node.Hints != nil
 }
 
 func (node *Node) collectTableName() string {
@@ -983,6 +1009,10 @@ func GenerateFieldQuery(statement Statement) *ParsedQuery {
 func FormatImpossible(buf *TrackedBuffer, node SQLNode) {
 	switch node := node.(type) {
 	case *Select:
+		if node.From == nil {
+			buf.Fprintf("select %v from dual where 1 != 1", node.SelectExprs)
+			return
+		}
 		buf.Fprintf("select %v from %v where 1 != 1", node.SelectExprs, node.From)
 	case *JoinTableExpr:
 		if bytes.Equal(node.Join, LJOIN) || bytes.Equal(node.Join, RJOIN) {
@@ -1031,9 +1061,10 @@ func GenerateInOuterQuery(sel *Select, tableInfo *schema.Table) *ParsedQuery {
 
 func GenerateInsertOuterQuery(ins *Insert) *ParsedQuery {
 	buf := NewTrackedBuffer(nil)
-	buf.Fprintf("insert %vinto %v%v values %a%v",
-		ins.Comments,
+	buf.Fprintf("insert %v%sinto %v%v%v values %a%v",
+		ins.Comments, ins.modifiers(),
 		ins.Table,
+		ins.Partitions,
 		ins.Columns,
 		"_rowValues",
 		ins.OnDup,
@@ -1043,14 +1074,14 @@ func GenerateInsertOuterQuery(ins *Insert) *ParsedQuery {
 
 func GenerateUpdateOuterQuery(upd *Update, pkIndex *schema.Index) *ParsedQuery {
 	buf := NewTrackedBuffer(nil)
-	buf.Fprintf("update %v%v set %v where ", upd.Comments, upd.Table, upd.List)
+	buf.Fprintf("update %v%v%v set %v where ", upd.Comments, upd.Table, upd.Partitions, upd.List)
 	generatePKWhere(buf, pkIndex)
 	return buf.ParsedQuery()
 }
 
 func GenerateDeleteOuterQuery(del *Delete, pkIndex *schema.Index) *ParsedQuery {
 	buf := NewTrackedBuffer(nil)
-	buf.Fprintf("delete %vfrom %v where ", del.Comments, del.Table)
+	buf.Fprintf("delete %vfrom %v%v where ", del.Comments, del.Table, del.Partitions)
 	generatePKWhere(buf, pkIndex)
 	return buf.ParsedQuery()
 }
@@ -1065,14 +1096,15 @@ func generatePKWhere(buf *TrackedBuffer, pkIndex *schema.Index) {
 }
 
 func GenerateSelectSubquery(sel *Select, tableInfo *schema.Table, index string) *ParsedQuery {
-	hint := NewSimpleParseNode(USE, "use")
-	hint.Push(NewSimpleParseNode(INDEX_LIST, ""))
-	hint.NodeAt(0).Push(NewSimpleParseNode(ID, index))
+	hint := &IndexHint{
+		Type:    USE_INDEX,
+		Indexes: []*Node{NewSimpleParseNode(ID, index)},
+	}
 	table_expr := sel.From[0].(*AliasedTableExpr)
-	savedHint := table_expr.Hint
-	table_expr.Hint = hint
+	savedHints := table_expr.Hints
+	table_expr.Hints = IndexHints{hint}
 	defer func() {
-		table_expr.Hint = savedHint
+		table_expr.Hints = savedHints
 	}()
 	return GenerateSubquery(
 		tableInfo.Indexes[0].Columns,
@@ -1087,7 +1119,7 @@ func GenerateSelectSubquery(sel *Select, tableInfo *schema.Table, index string)
 func GenerateUpdateSubquery(upd *Update, tableInfo *schema.Table) *ParsedQuery {
 	return GenerateSubquery(
 		tableInfo.Indexes[0].Columns,
-		&AliasedTableExpr{Expr: upd.Table},
+		&AliasedTableExpr{Expr: upd.Table, Partitions: upd.Partitions},
 		upd.Where,
 		upd.OrderBy,
 		upd.Limit,
@@ -1098,7 +1130,7 @@ func GenerateUpdateSubquery(upd *Update, tableInfo *schema.Table) *ParsedQuery {
 func GenerateDeleteSubquery(del *Delete, tableInfo *schema.Table) *ParsedQuery {
 	return GenerateSubquery(
 		tableInfo.Indexes[0].Columns,
-		&AliasedTableExpr{Expr: del.Table},
+		&AliasedTableExpr{Expr: del.Table, Partitions: del.Partitions},
 		del.Where,
 		del.OrderBy,
 		del.Limit,
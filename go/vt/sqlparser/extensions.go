@@ -0,0 +1,88 @@
+// Copyright 2013, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"bytes"
+)
+
+// DialectExtension lets callers teach the parser constructs
+// that the standard grammar does not understand. Extensions
+// are only consulted when the standard rules fail, so they
+// cannot change the meaning of SQL that already parses.
+//
+// ParseExpression is called when the tokenizer meets a
+// character it does not recognize in an expression. The
+// tokenizer is positioned at that character, which Scan
+// returns as a single-character token. The extension must
+// scan exactly the tokens that make up the expression and
+// return false, without side effects, if it does not
+// recognize them.
+//
+// ParseStatement is called with a fresh tokenizer when the
+// whole statement fails to parse. Since Statement cannot be
+// implemented outside this package, extensions return one
+// of the existing statement types.
+type DialectExtension interface {
+	ParseExpression(tok *Tokenizer) (*Node, bool)
+	ParseStatement(tok *Tokenizer) (Statement, bool)
+}
+
+var dialectExtensions []DialectExtension
+
+// RegisterDialectExtension adds ext to the extensions
+// consulted by the parser. It is not safe to call
+// concurrently with parsing, and is meant to be called
+// from an init function.
+func RegisterDialectExtension(ext DialectExtension) {
+	dialectExtensions = append(dialectExtensions, ext)
+}
+
+// parseExtensionStatement offers sql to the registered
+// extensions, returning the first statement accepted.
+func parseExtensionStatement(sql string, options ParserOptions) (Statement, bool) {
+	for _, ext := range dialectExtensions {
+		tkn := NewTokenizerWithOptions(sql, options)
+		tkn.inExtension = true
+		if stmt, ok := ext.ParseStatement(tkn); ok {
+			return stmt, true
+		}
+	}
+	return nil, false
+}
+
+// scanExtension offers the input starting at the unexpected
+// character ch to the registered extensions. It returns nil,
+// with the tokenizer state untouched, if none accepts it.
+// The lookahead character has already been read, so both are
+// pushed back in front of the remaining input.
+func (tkn *Tokenizer) scanExtension(ch uint16) *Node {
+	if len(dialectExtensions) == 0 || tkn.inExtension {
+		return nil
+	}
+	buf, ok := tkn.InStream.(*bytes.Buffer)
+	if !ok {
+		return nil
+	}
+	lastChar, position := tkn.lastChar, tkn.position
+	rest := []byte{byte(ch)}
+	if lastChar != EOFCHAR {
+		rest = append(rest, byte(lastChar))
+	}
+	rest = append(rest, buf.Bytes()...)
+
+	tkn.inExtension = true
+	defer func() { tkn.inExtension = false }()
+	for _, ext := range dialectExtensions {
+		tkn.InStream = bytes.NewBuffer(append([]byte(nil), rest...))
+		tkn.lastChar = 0
+		tkn.position = position - 2
+		if node, ok := ext.ParseExpression(tkn); ok {
+			return NewSimpleParseNode(EXTENSION_EXPR, "").Push(node)
+		}
+	}
+	tkn.InStream, tkn.lastChar, tkn.position = buf, lastChar, position
+	return nil
+}
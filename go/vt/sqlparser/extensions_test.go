@@ -0,0 +1,73 @@
+// Copyright 2013, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+)
+
+// dollarExtension accepts $name as a bind variable and
+// "vacuum table" as a table DDL.
+type dollarExtension struct{}
+
+func (dollarExtension) ParseExpression(tok *Tokenizer) (*Node, bool) {
+	if tok.Scan().Type != '$' {
+		return nil, false
+	}
+	name := tok.Scan()
+	if name.Type != ID {
+		return nil, false
+	}
+	return NewParseNode(VALUE_ARG, append([]byte(":"), name.Value...)), true
+}
+
+func (dollarExtension) ParseStatement(tok *Tokenizer) (Statement, bool) {
+	keyword := tok.Scan()
+	if keyword.Type != ID || string(keyword.Value) != "vacuum" {
+		return nil, false
+	}
+	table := tok.Scan()
+	if table.Type != ID || tok.Scan().Type != 0 {
+		return nil, false
+	}
+	return &DDLSimple{Action: ALTER, Table: table}, true
+}
+
+func TestDialectExtension(t *testing.T) {
+	saved := dialectExtensions
+	defer func() { dialectExtensions = saved }()
+
+	if _, err := Parse("select $a from t"); err == nil {
+		t.Errorf("want error without extension")
+	}
+	RegisterDialectExtension(dollarExtension{})
+
+	testcases := []struct {
+		in  string
+		out string
+	}{
+		{"select $a from t", "select :a from t"},
+		{"select a from t where b = $b and c = 1", "select a from t where b = :b and c = 1"},
+		{"select a from t where b in ($x, $y)", "select a from t where b in (:x, :y)"},
+		{"select a from t", "select a from t"},
+		{"vacuum t", "alter table t"},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tcase.in, err)
+			continue
+		}
+		if out := String(tree); out != tcase.out {
+			t.Errorf("Parse(%q): %q, want %q", tcase.in, out, tcase.out)
+		}
+	}
+
+	for _, sql := range []string{"select $ from t", "select # from t", "vacuum t u"} {
+		if _, err := Parse(sql); err == nil {
+			t.Errorf("Parse(%q): want error", sql)
+		}
+	}
+}
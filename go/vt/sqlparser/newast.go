@@ -36,18 +36,28 @@ type SelectStatement interface {
 	SQLNode
 }
 
-// Select represents a SELECT statement.
+// Select represents a SELECT statement. With is
+// nil if the statement has no WITH clause, From is
+// nil if the statement has no FROM clause, and
+// Windows are the windows named by the WINDOW clause.
+// Options are the select options other than DISTINCT,
+// like sql_no_cache, in the order they're written.
 type Select struct {
+	With        *With
 	Comments    Comments
 	Distinct    Distinct
+	Options     []string
 	SelectExprs SelectExprs
 	From        TableExprs
 	Where       *Node
 	GroupBy     *Node
 	Having      *Node
+	Windows     NamedWindows
 	OrderBy     *Node
 	Limit       *Node
-	Lock        *Node
+	Procedure   *Node
+	Into        *SelectInto
+	Lock        *Lock
 }
 
 func (*Select) statement() {}
@@ -55,121 +65,1671 @@ func (*Select) statement() {}
 func (*Select) selectStatement() {}
 
 func (node *Select) Format(buf *TrackedBuffer) {
-	buf.Fprintf("select %v%v%v from %v%v%v%v%v%v%v",
-		node.Comments, node.Distinct, node.SelectExprs,
-		node.From, node.Where,
-		node.GroupBy, node.Having, node.OrderBy,
-		node.Limit, node.Lock)
+	if node.With != nil {
+		buf.Fprintf("%v ", node.With)
+	}
+	buf.Fprintf("select %v%v", node.Comments, node.Distinct)
+	for _, option := range node.Options {
+		buf.Fprintf("%s ", option)
+	}
+	buf.Fprintf("%v", node.SelectExprs)
+	if node.From != nil {
+		buf.Fprintf(" from %v", node.From)
+	}
+	buf.Fprintf("%v%v%v%v%v%v",
+		node.Where, node.GroupBy, node.Having, node.Windows,
+		node.OrderBy, node.Limit)
+	if node.Procedure != nil {
+		buf.Fprintf(" procedure %v", node.Procedure)
+	}
+	if node.Into != nil {
+		buf.Fprintf(" %v", node.Into)
+	}
+	buf.Fprintf("%v", node.Lock)
+}
+
+// Lock represents the lock clause of a SELECT. Type is
+// NO_LOCK if there's none, FOR_UPDATE, FOR_SHARE or
+// LOCK_IN_SHARE_MODE. Wait is what FOR UPDATE and FOR
+// SHARE do with rows that are already locked.
+type Lock struct {
+	Type int
+	Wait int
+}
+
+// Lock wait policies.
+const (
+	LOCK_WAIT = iota
+	LOCK_NOWAIT
+	LOCK_SKIP_LOCKED
+)
+
+var lockWaitName = []string{
+	"",
+	" nowait",
+	" skip locked",
+}
+
+func (node *Lock) Format(buf *TrackedBuffer) {
+	switch node.Type {
+	case FOR_UPDATE:
+		buf.Fprintf(" for update")
+	case FOR_SHARE:
+		buf.Fprintf(" for share")
+	case LOCK_IN_SHARE_MODE:
+		buf.Fprintf(" lock in share mode")
+	}
+	buf.Fprintf("%s", lockWaitName[node.Wait])
+}
+
+// SelectInto represents the INTO clause of a SELECT. Type
+// is "outfile" or "dumpfile", and Fields and Lines are the
+// export options of an outfile. Type is nil if the values
+// are stored in the Variables. The clause can precede FROM,
+// but it's always formatted in its trailing position, after
+// PROCEDURE and before the lock clause.
+type SelectInto struct {
+	Type      []byte
+	FileName  *Node
+	Fields    *FieldsOptions
+	Lines     *LinesOptions
+	Variables []*Node
+}
+
+func (node *SelectInto) Format(buf *TrackedBuffer) {
+	if node.Type == nil {
+		buf.Fprintf("into ")
+		for i, variable := range node.Variables {
+			if i != 0 {
+				buf.Fprintf(", ")
+			}
+			buf.Fprintf("%v", variable)
+		}
+		return
+	}
+	buf.Fprintf("into %s %v", node.Type, node.FileName)
+	if node.Fields != nil {
+		buf.Fprintf(" %v", node.Fields)
+	}
+	if node.Lines != nil {
+		buf.Fprintf(" %v", node.Lines)
+	}
+}
+
+// FieldsOptions represents the FIELDS clause of LOAD DATA and
+// SELECT ... INTO OUTFILE. The values are strings, or nil if
+// they're not specified.
+type FieldsOptions struct {
+	TerminatedBy       *Node
+	EnclosedBy         *Node
+	OptionallyEnclosed bool
+	EscapedBy          *Node
+}
+
+func (node *FieldsOptions) Format(buf *TrackedBuffer) {
+	buf.Fprintf("fields")
+	if node.TerminatedBy != nil {
+		buf.Fprintf(" terminated by %v", node.TerminatedBy)
+	}
+	if node.EnclosedBy != nil {
+		if node.OptionallyEnclosed {
+			buf.Fprintf(" optionally")
+		}
+		buf.Fprintf(" enclosed by %v", node.EnclosedBy)
+	}
+	if node.EscapedBy != nil {
+		buf.Fprintf(" escaped by %v", node.EscapedBy)
+	}
+}
+
+// LinesOptions represents the LINES clause of LOAD DATA and
+// SELECT ... INTO OUTFILE.
+type LinesOptions struct {
+	StartingBy   *Node
+	TerminatedBy *Node
+}
+
+func (node *LinesOptions) Format(buf *TrackedBuffer) {
+	buf.Fprintf("lines")
+	if node.StartingBy != nil {
+		buf.Fprintf(" starting by %v", node.StartingBy)
+	}
+	if node.TerminatedBy != nil {
+		buf.Fprintf(" terminated by %v", node.TerminatedBy)
+	}
+}
+
+// Union represents a UNION statement. The selects of a
+// chain like a UNION b UNION ALL c are kept flat, in source
+// order: First is a, and each of Arms holds one of the
+// others with the union type that precedes it. With is the
+// WITH clause that precedes the whole union, or nil.
+// OrderBy, Limit and Lock are the clauses that follow
+// a parenthesized last select, and apply to the whole
+// union. They're nil if there are none, which is always
+// the case if the last select isn't parenthesized, since
+// it has its own clauses.
+type Union struct {
+	With    *With
+	First   SelectStatement
+	Arms    []*UnionArm
+	OrderBy *Node
+	Limit   *Node
+	Lock    *Lock
+}
+
+func (*Union) statement() {}
+
+func (*Union) selectStatement() {}
+
+func (node *Union) Format(buf *TrackedBuffer) {
+	if node.With != nil {
+		buf.Fprintf("%v ", node.With)
+	}
+	buf.Fprintf("%v", node.First)
+	for _, arm := range node.Arms {
+		buf.Fprintf(" %v", arm)
+	}
+	if node.OrderBy != nil {
+		buf.Fprintf("%v%v%v", node.OrderBy, node.Limit, node.Lock)
+	}
+}
+
+// Select1 returns the left operand of the last union of node,
+// as if the chain was a tree of binary unions: it's First if
+// there's only one arm, or the union of all but the last arm.
+func (node *Union) Select1() SelectStatement {
+	if len(node.Arms) <= 1 {
+		return node.First
+	}
+	return &Union{First: node.First, Arms: node.Arms[:len(node.Arms)-1]}
+}
+
+// Select2 returns the select of the last arm of node.
+func (node *Union) Select2() SelectStatement {
+	if len(node.Arms) == 0 {
+		return nil
+	}
+	return node.Arms[len(node.Arms)-1].Select
+}
+
+// UnionArm is a select of a Union, with the
+// set operation that precedes it.
+type UnionArm struct {
+	Type   int
+	Select SelectStatement
+}
+
+// UnionArm types. INTERSECT binds tighter than the
+// others, so the parser groups a select followed by
+// intersect arms into a Union of its own.
+const (
+	SETOP_UNION = iota
+	SETOP_UNION_ALL
+	SETOP_UNION_DISTINCT
+	SETOP_MINUS
+	SETOP_EXCEPT
+	SETOP_EXCEPT_ALL
+	SETOP_EXCEPT_DISTINCT
+	SETOP_INTERSECT
+	SETOP_INTERSECT_ALL
+	SETOP_INTERSECT_DISTINCT
+)
+
+var setOpName = []string{
+	"union",
+	"union all",
+	"union distinct",
+	"minus",
+	"except",
+	"except all",
+	"except distinct",
+	"intersect",
+	"intersect all",
+	"intersect distinct",
+}
+
+// IsIntersect returns true if the set operation
+// typ is one of the INTERSECT types.
+func IsIntersect(typ int) bool {
+	return typ >= SETOP_INTERSECT
+}
+
+func (node *UnionArm) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%s %v", setOpName[node.Type], node.Select)
+}
+
+// ParenSelect is a parenthesized SELECT or UNION
+// used as an operand of a UNION.
+type ParenSelect struct {
+	Select SelectStatement
+}
+
+func (*ParenSelect) statement() {}
+
+func (*ParenSelect) selectStatement() {}
+
+func (node *ParenSelect) Format(buf *TrackedBuffer) {
+	buf.Fprintf("(%v)", node.Select)
+}
+
+// With represents the WITH clause of a SELECT or UNION,
+// which defines common table expressions. The statement
+// refers to them as tables. Recursive is set by WITH
+// RECURSIVE, whose expressions can refer to themselves.
+type With struct {
+	Recursive bool
+	CTEs      []*CommonTableExpr
+}
+
+func (node *With) Format(buf *TrackedBuffer) {
+	buf.Fprintf("with ")
+	if node.Recursive {
+		buf.Fprintf("recursive ")
+	}
+	for i, cte := range node.CTEs {
+		if i != 0 {
+			buf.Fprintf(", ")
+		}
+		buf.Fprintf("%v", cte)
+	}
+}
+
+// CommonTableExpr is a named subquery of a WITH clause.
+// Columns is nil if the column names are those of the
+// subquery.
+type CommonTableExpr struct {
+	Name     *Node
+	Columns  Columns
+	Subquery SelectStatement
+}
+
+func (node *CommonTableExpr) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v%v as (%v)", node.Name, node.Columns, node.Subquery)
+}
+
+// Insert represents an INSERT statement.
+type Insert struct {
+	Comments   Comments
+	Priority   []byte
+	Ignore     bool
+	Table      *Node
+	Partitions Partitions
+	Columns    Columns
+	Values     SQLNode
+	RowAlias   *RowAlias
+	OnDup      *Node
+}
+
+// Priority values of an INSERT, which are nil by default.
+// The other value is "high_priority", which is a keyword.
+var (
+	LOW_PRIORITY = []byte("low_priority")
+	DELAYED      = []byte("delayed")
+)
+
+func (*Insert) statement() {}
+
+func (node *Insert) Format(buf *TrackedBuffer) {
+	buf.Fprintf("insert %v%sinto %v%v%v %v",
+		node.Comments, node.modifiers(),
+		node.Table, node.Partitions, node.Columns, node.Values)
+	if node.RowAlias != nil {
+		buf.Fprintf(" %v", node.RowAlias)
+	}
+	buf.Fprintf("%v", node.OnDup)
+}
+
+// modifiers returns the modifiers of node as they're
+// written between INSERT and INTO, like "low_priority ignore ".
+func (node *Insert) modifiers() string {
+	var modifiers string
+	if node.Priority != nil {
+		modifiers = string(node.Priority) + " "
+	}
+	if node.Ignore {
+		modifiers += "ignore "
+	}
+	return modifiers
+}
+
+// Replace represents a REPLACE statement. Its forms are the
+// ones of INSERT, except for ON DUPLICATE KEY UPDATE.
+type Replace struct {
+	Comments Comments
+	Table    *Node
+	Columns  Columns
+	Values   SQLNode
+}
+
+func (*Replace) statement() {}
+
+func (node *Replace) Format(buf *TrackedBuffer) {
+	buf.Fprintf("replace %vinto %v%v %v",
+		node.Comments,
+		node.Table, node.Columns, node.Values)
+}
+
+// RowAlias represents the alias given to the new row
+// of an INSERT, like in "values (1, 2) as new(a, b)".
+type RowAlias struct {
+	Name    *Node
+	Columns Columns
+}
+
+func (node *RowAlias) Format(buf *TrackedBuffer) {
+	buf.Fprintf("as %v%v", node.Name, node.Columns)
+}
+
+// NextValueFor represents a SELECT NEXT VALUE FOR statement,
+// which fetches the next value of the sequence SequenceName.
+type NextValueFor struct {
+	Comments     Comments
+	SequenceName []byte
+}
+
+func (*NextValueFor) statement() {}
+
+func (node *NextValueFor) Format(buf *TrackedBuffer) {
+	buf.Fprintf("select %vnext value for %s", node.Comments, node.SequenceName)
+}
+
+// Update represents an UPDATE statement.
+type Update struct {
+	Comments   Comments
+	Table      *Node
+	Partitions Partitions
+	List       *Node
+	Where      *Node
+	OrderBy    *Node
+	Limit      *Node
+}
+
+func (*Update) statement() {}
+
+func (node *Update) Format(buf *TrackedBuffer) {
+	buf.Fprintf("update %v%v%v set %v%v%v%v",
+		node.Comments, node.Table, node.Partitions,
+		node.List, node.Where, node.OrderBy, node.Limit)
+}
+
+// Delete represents a DELETE statement.
+type Delete struct {
+	Comments   Comments
+	Table      *Node
+	Partitions Partitions
+	Where      *Node
+	OrderBy    *Node
+	Limit      *Node
+}
+
+func (*Delete) statement() {}
+
+func (node *Delete) Format(buf *TrackedBuffer) {
+	buf.Fprintf("delete %vfrom %v%v%v%v%v",
+		node.Comments,
+		node.Table, node.Partitions, node.Where, node.OrderBy, node.Limit)
+}
+
+// Set represents a SET statement. Transaction is set
+// instead of Exprs for SET TRANSACTION.
+type Set struct {
+	Comments    Comments
+	Exprs       SetExprs
+	Transaction *TransactionChars
+}
+
+func (*Set) statement() {}
+
+func (node *Set) Format(buf *TrackedBuffer) {
+	if node.Transaction != nil {
+		buf.Fprintf("set %v%v", node.Comments, node.Transaction)
+		return
+	}
+	buf.Fprintf("set %v%v", node.Comments, node.Exprs)
+}
+
+// TransactionChars represents the characteristics set by
+// SET TRANSACTION. Scope is as in SetExpr. IsolationLevel
+// and AccessMode are nil if they're not set.
+type TransactionChars struct {
+	Scope          []byte
+	IsolationLevel []byte
+	AccessMode     []byte
+}
+
+// IsolationLevel values of TransactionChars.
+var (
+	REPEATABLE_READ  = []byte("repeatable read")
+	READ_COMMITTED   = []byte("read committed")
+	READ_UNCOMMITTED = []byte("read uncommitted")
+	SERIALIZABLE     = []byte("serializable")
+)
+
+// AccessMode values of TransactionChars.
+var (
+	READ_ONLY  = []byte("read only")
+	READ_WRITE = []byte("read write")
+)
+
+func (node *TransactionChars) Format(buf *TrackedBuffer) {
+	if node.Scope != nil {
+		buf.Fprintf("%s ", node.Scope)
+	}
+	buf.Fprintf("transaction")
+	prefix := " "
+	if node.IsolationLevel != nil {
+		buf.Fprintf("%sisolation level %s", prefix, node.IsolationLevel)
+		prefix = ", "
+	}
+	if node.AccessMode != nil {
+		buf.Fprintf("%s%s", prefix, node.AccessMode)
+	}
+}
+
+// SetExprs represents the assignments of a SET statement.
+type SetExprs []*SetExpr
+
+func (node SetExprs) Format(buf *TrackedBuffer) {
+	var prefix string
+	for _, n := range node {
+		buf.Fprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
+}
+
+// SetExpr represents a single assignment of a SET statement.
+// Scope is nil for user variables, and for system variables
+// that weren't given an explicit scope. The @@ prefix of system
+// variables is not part of Name. Expr can itself be a chained
+// assignment, like in "set @x = @y = 5".
+// SET NAMES and SET CHARACTER SET are represented by a Name of
+// type SET_NAMES or SET_CHARSET, whose Expr is the charset, and
+// for SET NAMES optionally a COLLATE node of the charset.
+type SetExpr struct {
+	Scope []byte
+	Name  *Node
+	Expr  *Node
+}
+
+// Scope values of a SetExpr. LOCAL is stored as SESSION.
+var (
+	GLOBAL  = []byte("global")
+	SESSION = []byte("session")
+)
+
+func (node *SetExpr) Format(buf *TrackedBuffer) {
+	if node.Name.Type == SET_NAMES || node.Name.Type == SET_CHARSET {
+		buf.Fprintf("%v %v", node.Name, node.Expr)
+		return
+	}
+	if node.Scope != nil {
+		buf.Fprintf("%s ", node.Scope)
+	}
+	buf.Fprintf("%v = %v", node.Name, node.Expr)
+}
+
+// DDLSimple represents a CREATE, ALTER or DROP statement.
+// TableSpec is set for CREATE TABLE statements that
+// specify the table structure, and ViewSpec for the
+// CREATE VIEW and ALTER VIEW statements that define
+// the view. Tables is the list of tables of a DROP
+// statement, and Table is its first element. IfExists
+// is set by DROP ... IF EXISTS, IfNotExists by CREATE
+// ... IF NOT EXISTS, and Temporary by CREATE TEMPORARY
+// TABLE and DROP TEMPORARY TABLE. OptLike is set for
+// CREATE TABLE ... LIKE. View is set for the statements
+// on views, and Replace for CREATE OR REPLACE VIEW, whose
+// Action is ALTER. SchemaVersion is not part of the SQL:
+// it's set by StampVersion.
+type DDLSimple struct {
+	Action        int
+	Table         *Node
+	Tables        []*Node
+	View          bool
+	Replace       bool
+	Temporary     bool
+	IfExists      bool
+	IfNotExists   bool
+	TableSpec     *TableSpec
+	OptLike       *OptLike
+	ViewSpec      *ViewSpec
+	AlterOptions  []AlterOption
+	SchemaVersion uint64
+}
+
+func (*DDLSimple) statement() {}
+
+func (node *DDLSimple) Format(buf *TrackedBuffer) {
+	if node.View {
+		node.formatView(buf)
+		return
+	}
+	switch node.Action {
+	case CREATE:
+		buf.Fprintf("create %stable %s%v", node.temporary(), node.ifNotExists(), node.Table)
+		if node.TableSpec != nil {
+			buf.Fprintf(" %v", node.TableSpec)
+		}
+		if node.OptLike != nil {
+			buf.Fprintf(" %v", node.OptLike)
+		}
+	case ALTER:
+		buf.Fprintf("alter table %v", node.Table)
+		for i, option := range node.AlterOptions {
+			if i == 0 {
+				buf.Fprintf(" %v", option)
+			} else {
+				buf.Fprintf(", %v", option)
+			}
+		}
+	case DROP:
+		buf.Fprintf("drop %stable ", node.temporary())
+		node.formatDropList(buf)
+	default:
+		panic("unreachable")
+	}
+}
+
+func (node *DDLSimple) formatView(buf *TrackedBuffer) {
+	switch {
+	case node.Replace:
+		buf.Fprintf("create or replace ")
+	case node.Action == CREATE:
+		buf.Fprintf("create ")
+	case node.Action == ALTER:
+		buf.Fprintf("alter ")
+	case node.Action == DROP:
+		buf.Fprintf("drop view ")
+		node.formatDropList(buf)
+		return
+	default:
+		panic("unreachable")
+	}
+	if node.ViewSpec != nil {
+		node.ViewSpec.formatOptions(buf)
+	}
+	buf.Fprintf("view %v", node.Table)
+	if node.ViewSpec != nil {
+		buf.Fprintf("%v", node.ViewSpec)
+	}
+}
+
+func (node *DDLSimple) formatDropList(buf *TrackedBuffer) {
+	if node.IfExists {
+		buf.Fprintf("if exists ")
+	}
+	for i, table := range node.Tables {
+		if i != 0 {
+			buf.Fprintf(", ")
+		}
+		buf.Fprintf("%v", table)
+	}
+}
+
+func (node *DDLSimple) temporary() string {
+	if node.Temporary {
+		return "temporary "
+	}
+	return ""
+}
+
+func (node *DDLSimple) ifNotExists() string {
+	if node.IfNotExists {
+		return "if not exists "
+	}
+	return ""
+}
+
+// DBDDL represents a CREATE, ALTER or DROP DATABASE statement.
+// SCHEMA is a synonym of DATABASE. Charset and Collate are the
+// character set and collation options, or empty if they're
+// not specified.
+type DBDDL struct {
+	Action      int
+	DBName      *Node
+	IfExists    bool
+	IfNotExists bool
+	Charset     string
+	Collate     string
+}
+
+func (*DBDDL) statement() {}
+
+func (node *DBDDL) Format(buf *TrackedBuffer) {
+	switch node.Action {
+	case CREATE:
+		buf.Fprintf("create database ")
+		if node.IfNotExists {
+			buf.Fprintf("if not exists ")
+		}
+	case ALTER:
+		buf.Fprintf("alter database ")
+	case DROP:
+		buf.Fprintf("drop database ")
+		if node.IfExists {
+			buf.Fprintf("if exists ")
+		}
+	default:
+		panic("unreachable")
+	}
+	buf.Fprintf("%v", node.DBName)
+	if node.Charset != "" {
+		buf.Fprintf(" character set %s", node.Charset)
+	}
+	if node.Collate != "" {
+		buf.Fprintf(" collate %s", node.Collate)
+	}
+}
+
+// Truncate represents a TRUNCATE TABLE statement. Unlike
+// DROP TABLE, it keeps the table and only deletes its rows.
+type Truncate struct {
+	Comments Comments
+	Table    *Node
+}
+
+func (*Truncate) statement() {}
+
+func (node *Truncate) Format(buf *TrackedBuffer) {
+	buf.Fprintf("truncate %vtable %v", node.Comments, node.Table)
+}
+
+// AlterOption represents an operation of an ALTER TABLE statement.
+type AlterOption interface {
+	alterOption()
+	SQLNode
+}
+
+// AlterCharset represents CONVERT TO CHARACTER SET, which
+// changes the character set of the table and all its columns.
+type AlterCharset struct {
+	CharacterSet []byte
+	Collate      []byte
+}
+
+func (*AlterCharset) alterOption() {}
+
+func (node *AlterCharset) Format(buf *TrackedBuffer) {
+	buf.Fprintf("convert to character set %s", node.CharacterSet)
+	if node.Collate != nil {
+		buf.Fprintf(" collate %s", node.Collate)
+	}
+}
+
+// AddColumn represents ADD COLUMN in an ALTER TABLE.
+type AddColumn struct {
+	Column *ColumnDefinition
+}
+
+func (*AddColumn) alterOption() {}
+
+func (node *AddColumn) Format(buf *TrackedBuffer) {
+	buf.Fprintf("add column %v", node.Column)
+}
+
+// ChangeColumn represents CHANGE COLUMN in an ALTER TABLE.
+type ChangeColumn struct {
+	OldName *Node
+	Column  *ColumnDefinition
+}
+
+func (*ChangeColumn) alterOption() {}
+
+func (node *ChangeColumn) Format(buf *TrackedBuffer) {
+	buf.Fprintf("change column %v %v", node.OldName, node.Column)
+}
+
+// ModifyColumn represents MODIFY COLUMN in an ALTER TABLE.
+type ModifyColumn struct {
+	Column *ColumnDefinition
+}
+
+func (*ModifyColumn) alterOption() {}
+
+func (node *ModifyColumn) Format(buf *TrackedBuffer) {
+	buf.Fprintf("modify column %v", node.Column)
+}
+
+// AddIndex represents ADD INDEX in an ALTER TABLE.
+type AddIndex struct {
+	Index *IndexDefinition
+}
+
+func (*AddIndex) alterOption() {}
+
+func (node *AddIndex) Format(buf *TrackedBuffer) {
+	buf.Fprintf("add %v", node.Index)
+}
+
+// DropColumn represents DROP COLUMN in an ALTER TABLE.
+type DropColumn struct {
+	Name *Node
+}
+
+func (*DropColumn) alterOption() {}
+
+func (node *DropColumn) Format(buf *TrackedBuffer) {
+	buf.Fprintf("drop column %v", node.Name)
+}
+
+// DropIndex represents DROP INDEX in an ALTER TABLE.
+// Name is nil for DROP PRIMARY KEY.
+type DropIndex struct {
+	Name *Node
+}
+
+func (*DropIndex) alterOption() {}
+
+func (node *DropIndex) Format(buf *TrackedBuffer) {
+	if node.Name == nil {
+		buf.Fprintf("drop primary key")
+		return
+	}
+	buf.Fprintf("drop index %v", node.Name)
+}
+
+// RenameTable represents RENAME TO in an ALTER TABLE. An
+// ALTER TABLE that only renames is parsed as a Rename.
+type RenameTable struct {
+	NewName *Node
+}
+
+func (*RenameTable) alterOption() {}
+
+func (node *RenameTable) Format(buf *TrackedBuffer) {
+	buf.Fprintf("rename to %v", node.NewName)
+}
+
+// AlterRaw is an operation of an ALTER TABLE that the
+// grammar doesn't parse yet. It's kept as written.
+type AlterRaw struct {
+	Raw []byte
+}
+
+func (*AlterRaw) alterOption() {}
+
+func (node *AlterRaw) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%s", node.Raw)
+}
+
+// IndexDefinition describes an index. Type is nil
+// for regular indexes, or unique, fulltext or spatial.
+// It's primary for a primary key, which has no Name.
+// Using is the index algorithm, like btree. Constraint
+// is the symbol of CONSTRAINT for a primary key or a
+// unique index, or nil. Raw is set instead of Columns
+// and Comment if the columns and options of CREATE INDEX
+// can't be parsed.
+type IndexDefinition struct {
+	Constraint *Node
+	Type       []byte
+	Name       *Node
+	Columns    []*IndexColumn
+	Using      []byte
+	Comment    *Node
+	Raw        []byte
+}
+
+func (node *IndexDefinition) Format(buf *TrackedBuffer) {
+	if node.Constraint != nil {
+		buf.Fprintf("constraint %v ", node.Constraint)
+	}
+	switch string(node.Type) {
+	case "primary":
+		buf.Fprintf("primary key")
+	case "":
+		buf.Fprintf("index")
+	default:
+		buf.Fprintf("%s index", node.Type)
+	}
+	if node.Name != nil {
+		buf.Fprintf(" %v", node.Name)
+	}
+	if node.Columns == nil {
+		if node.Using != nil {
+			buf.Fprintf(" using %s", node.Using)
+		}
+		if node.Raw != nil {
+			buf.Fprintf(" %s", node.Raw)
+		}
+		return
+	}
+	buf.Fprintf(" (")
+	for i, col := range node.Columns {
+		if i != 0 {
+			buf.Fprintf(", ")
+		}
+		buf.Fprintf("%v", col)
+	}
+	buf.Fprintf(")")
+	if node.Using != nil {
+		buf.Fprintf(" using %s", node.Using)
+	}
+	if node.Comment != nil {
+		buf.Fprintf(" comment %v", node.Comment)
+	}
+}
+
+// IndexColumn is a column of an index, with an optional
+// prefix length. Direction is nil, asc or desc.
+type IndexColumn struct {
+	Column    *Node
+	Length    []byte
+	Direction []byte
+}
+
+func (node *IndexColumn) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v", node.Column)
+	if node.Length != nil {
+		buf.Fprintf("(%s)", node.Length)
+	}
+	if node.Direction != nil {
+		buf.Fprintf(" %s", node.Direction)
+	}
+}
+
+// TableSpec describes the structure of a table
+// in a CREATE TABLE statement. The indexes and the
+// constraints are formatted after the columns.
+// RawOptions is set instead of Options if one of
+// the clauses that follow the definition isn't
+// parsed, like PARTITION BY: they're kept as written.
+type TableSpec struct {
+	Columns     []*ColumnDefinition
+	Indexes     []*IndexDefinition
+	Constraints []*ConstraintDefinition
+	Options     []*TableOption
+	RawOptions  []byte
+}
+
+func (node *TableSpec) Format(buf *TrackedBuffer) {
+	buf.Fprintf("(")
+	for i, col := range node.Columns {
+		if i != 0 {
+			buf.Fprintf(", ")
+		}
+		buf.Fprintf("%v", col)
+	}
+	for _, index := range node.Indexes {
+		buf.Fprintf(", %v", index)
+	}
+	for _, constraint := range node.Constraints {
+		buf.Fprintf(", %v", constraint)
+	}
+	buf.Fprintf(")")
+	for _, option := range node.Options {
+		buf.Fprintf(" %v", option)
+	}
+	if node.RawOptions != nil {
+		buf.Fprintf(" %s", node.RawOptions)
+	}
+}
+
+// ConstraintDefinition is a FOREIGN KEY or a CHECK
+// constraint of a table. Name is the symbol of
+// CONSTRAINT, or nil. Check is the expression of
+// a CHECK constraint, and ForeignKey is nil then.
+type ConstraintDefinition struct {
+	Name       *Node
+	ForeignKey *ForeignKeyDefinition
+	Check      *Node
+}
+
+func (node *ConstraintDefinition) Format(buf *TrackedBuffer) {
+	if node.Name != nil {
+		buf.Fprintf("constraint %v ", node.Name)
+	}
+	if node.ForeignKey != nil {
+		buf.Fprintf("%v", node.ForeignKey)
+		return
+	}
+	buf.Fprintf("check (%v)", node.Check)
+}
+
+// ForeignKeyDefinition describes a FOREIGN KEY. Name
+// is the name of its index, or nil. OnDelete and
+// OnUpdate are the referential actions, like cascade
+// or set null, or nil if they're not specified.
+type ForeignKeyDefinition struct {
+	Name              *Node
+	Columns           Columns
+	ReferencedTable   *Node
+	ReferencedColumns Columns
+	OnDelete          []byte
+	OnUpdate          []byte
+}
+
+func (node *ForeignKeyDefinition) Format(buf *TrackedBuffer) {
+	buf.Fprintf("foreign key ")
+	if node.Name != nil {
+		buf.Fprintf("%v ", node.Name)
+	}
+	buf.Fprintf("%v references %v%v", node.Columns, node.ReferencedTable, node.ReferencedColumns)
+	if node.OnDelete != nil {
+		buf.Fprintf(" on delete %s", node.OnDelete)
+	}
+	if node.OnUpdate != nil {
+		buf.Fprintf(" on update %s", node.OnUpdate)
+	}
+}
+
+// OptLike is the LIKE clause of CREATE TABLE, which copies
+// the structure of LikeTable. Parens is set for the
+// parenthesized form.
+type OptLike struct {
+	LikeTable *Node
+	Parens    bool
+}
+
+func (node *OptLike) Format(buf *TrackedBuffer) {
+	if node.Parens {
+		buf.Fprintf("(like %v)", node.LikeTable)
+		return
+	}
+	buf.Fprintf("like %v", node.LikeTable)
+}
+
+// ViewSpec is the definition of a view in a CREATE VIEW
+// or ALTER VIEW statement. Columns is nil if the column
+// names are those of the select. CheckOption is nil,
+// "check option", "cascaded check option" or "local
+// check option". Algorithm, Definer and Security are the
+// options that precede VIEW, or nil if they're not set.
+type ViewSpec struct {
+	Algorithm   []byte
+	Definer     *UserSpec
+	Security    []byte
+	Columns     Columns
+	Select      SelectStatement
+	CheckOption []byte
+}
+
+// formatOptions formats the options that precede VIEW.
+func (node *ViewSpec) formatOptions(buf *TrackedBuffer) {
+	if node.Algorithm != nil {
+		buf.Fprintf("algorithm=%s ", node.Algorithm)
+	}
+	if node.Definer != nil {
+		buf.Fprintf("definer=%v ", node.Definer)
+	}
+	if node.Security != nil {
+		buf.Fprintf("sql security %s ", node.Security)
+	}
+}
+
+func (node *ViewSpec) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v as %v", node.Columns, node.Select)
+	if node.CheckOption != nil {
+		buf.Fprintf(" with %s", node.CheckOption)
+	}
+}
+
+// TableOption is a table option of CREATE TABLE, like
+// ENGINE=InnoDB. It's also an operation of ALTER TABLE.
+// Name is lowercase, and the CHARACTER SET and DEFAULT
+// CHARSET options are named charset.
+type TableOption struct {
+	Name  []byte
+	Value *Node
+}
+
+func (*TableOption) alterOption() {}
+
+func (node *TableOption) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%s=%v", node.Name, node.Value)
+}
+
+// ColumnDefinition describes a column in a CREATE TABLE statement.
+type ColumnDefinition struct {
+	Name *Node
+	Type ColumnType
+}
+
+func (node *ColumnDefinition) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v %v", node.Name, node.Type)
+}
+
+// ColumnType represents the type and attributes of a column.
+// KeyOpt is one of 0, UNIQUE or KEY (for primary key).
+// Visibility is nil unless VISIBLE or INVISIBLE was specified.
+// EnumValues contains the permitted values of an ENUM or SET.
+// Generated is the expression of a generated column, and
+// Storage is nil, virtual or stored. Check is the expression
+// of a CHECK constraint on the column.
+type ColumnType struct {
+	Type          []byte
+	Length        []byte
+	Scale         []byte
+	EnumValues    []*Node
+	Unsigned      bool
+	Zerofill      bool
+	SRID          *Node
+	Charset       []byte
+	Collate       []byte
+	Generated     *Node
+	Storage       []byte
+	NotNull       bool
+	Default       *Node
+	OnUpdate      *Node
+	Autoincrement bool
+	KeyOpt        int
+	Comment       *Node
+	Visibility    []byte
+	Check         *Node
+}
+
+func (node ColumnType) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%s", node.Type)
+	if node.Length != nil {
+		buf.Fprintf("(%s", node.Length)
+		if node.Scale != nil {
+			buf.Fprintf(",%s", node.Scale)
+		}
+		buf.Fprintf(")")
+	}
+	if node.EnumValues != nil {
+		buf.Fprintf("(")
+		for i, value := range node.EnumValues {
+			if i != 0 {
+				buf.Fprintf(", ")
+			}
+			buf.Fprintf("%v", value)
+		}
+		buf.Fprintf(")")
+	}
+	if node.Unsigned {
+		buf.Fprintf(" unsigned")
+	}
+	if node.Zerofill {
+		buf.Fprintf(" zerofill")
+	}
+	if node.SRID != nil {
+		buf.Fprintf(" srid %v", node.SRID)
+	}
+	if node.Charset != nil {
+		buf.Fprintf(" character set %s", node.Charset)
+	}
+	if node.Collate != nil {
+		buf.Fprintf(" collate %s", node.Collate)
+	}
+	if node.Generated != nil {
+		buf.Fprintf(" generated always as (%v)", node.Generated)
+	}
+	if node.Storage != nil {
+		buf.Fprintf(" %s", node.Storage)
+	}
+	if node.NotNull {
+		buf.Fprintf(" not null")
+	}
+	if node.Default != nil {
+		buf.Fprintf(" default %v", node.Default)
+	}
+	if node.OnUpdate != nil {
+		buf.Fprintf(" on update %v", node.OnUpdate)
+	}
+	if node.Autoincrement {
+		buf.Fprintf(" auto_increment")
+	}
+	switch node.KeyOpt {
+	case UNIQUE:
+		buf.Fprintf(" unique key")
+	case KEY:
+		buf.Fprintf(" primary key")
+	}
+	if node.Comment != nil {
+		buf.Fprintf(" comment %v", node.Comment)
+	}
+	if node.Visibility != nil {
+		buf.Fprintf(" %s", node.Visibility)
+	}
+	if node.Check != nil {
+		buf.Fprintf(" check (%v)", node.Check)
+	}
 }
 
-// Union represents a UNION statement.
-type Union struct {
-	Type             []byte
-	Select1, Select2 SelectStatement
+// Rename represents a RENAME statement.
+type Rename struct {
+	OldName, NewName *Node
+	SchemaVersion    uint64
 }
 
-func (*Union) statement() {}
+func (*Rename) statement() {}
 
-func (*Union) selectStatement() {}
+func (node *Rename) Format(buf *TrackedBuffer) {
+	buf.Fprintf("rename table %v to %v", node.OldName, node.NewName)
+}
 
-func (node *Union) Format(buf *TrackedBuffer) {
-	buf.Fprintf("%v %s %v", node.Select1, node.Type, node.Select2)
+// Explain represents an EXPLAIN statement, which explains a
+// SELECT, INSERT, REPLACE, UPDATE or DELETE. Partitions is set
+// for EXPLAIN PARTITIONS, Extended for EXPLAIN EXTENDED, Analyze
+// for EXPLAIN ANALYZE, which runs the statement, and OutputFormat
+// is the format of EXPLAIN FORMAT=x, like json, or nil.
+type Explain struct {
+	Partitions   bool
+	Extended     bool
+	Analyze      bool
+	OutputFormat []byte
+	Statement    Statement
 }
 
-// Insert represents an INSERT statement.
-type Insert struct {
+func (*Explain) statement() {}
+
+func (node *Explain) Format(buf *TrackedBuffer) {
+	switch {
+	case node.Partitions:
+		buf.Fprintf("explain partitions %v", node.Statement)
+	case node.Extended:
+		buf.Fprintf("explain extended %v", node.Statement)
+	case node.Analyze:
+		buf.Fprintf("explain analyze %v", node.Statement)
+	case node.OutputFormat != nil:
+		buf.Fprintf("explain format=%s %v", node.OutputFormat, node.Statement)
+	default:
+		buf.Fprintf("explain %v", node.Statement)
+	}
+}
+
+// Describe represents a DESCRIBE statement, or its DESC and
+// EXPLAIN synonyms, which lists the columns of Table. Column
+// is nil, a column name, or a pattern for the column names.
+type Describe struct {
 	Comments Comments
 	Table    *Node
-	Columns  Columns
-	Values   SQLNode
-	OnDup    *Node
+	Column   *Node
 }
 
-func (*Insert) statement() {}
+func (*Describe) statement() {}
 
-func (node *Insert) Format(buf *TrackedBuffer) {
-	buf.Fprintf("insert %vinto %v%v %v%v",
-		node.Comments,
-		node.Table, node.Columns, node.Values, node.OnDup)
+func (node *Describe) Format(buf *TrackedBuffer) {
+	buf.Fprintf("describe %v%v", node.Comments, node.Table)
+	if node.Column != nil {
+		buf.Fprintf(" %v", node.Column)
+	}
 }
 
-// Update represents an UPDATE statement.
-type Update struct {
-	Comments Comments
-	Table    *Node
-	List     *Node
-	Where    *Node
-	OrderBy  *Node
-	Limit    *Node
+// ExplainForConnection represents an EXPLAIN FOR CONNECTION
+// statement, which explains the query running in the connection
+// ConnectionID.
+type ExplainForConnection struct {
+	ConnectionID *Node
 }
 
-func (*Update) statement() {}
+func (*ExplainForConnection) statement() {}
 
-func (node *Update) Format(buf *TrackedBuffer) {
-	buf.Fprintf("update %v%v set %v%v%v%v",
-		node.Comments, node.Table,
-		node.List, node.Where, node.OrderBy, node.Limit)
+func (node *ExplainForConnection) Format(buf *TrackedBuffer) {
+	buf.Fprintf("explain for connection %v", node.ConnectionID)
 }
 
-// Delete represents a DELETE statement.
-type Delete struct {
+// Do represents a DO statement.
+type Do struct {
+	Exprs []*Node
+}
+
+func (*Do) statement() {}
+
+func (node *Do) Format(buf *TrackedBuffer) {
+	buf.Fprintf("do ")
+	for i, expr := range node.Exprs {
+		if i != 0 {
+			buf.Fprintf(", ")
+		}
+		buf.Fprintf("%v", expr)
+	}
+}
+
+// Reset represents a RESET statement.
+type Reset struct {
+	Target int
+}
+
+// Reset targets.
+const (
+	RESET_MASTER = iota
+	RESET_QUERY_CACHE
+	RESET_SLAVE
+	RESET_REPLICA
+)
+
+var resetTargetName = []string{
+	"master",
+	"query cache",
+	"slave",
+	"replica",
+}
+
+func (*Reset) statement() {}
+
+func (node *Reset) Format(buf *TrackedBuffer) {
+	buf.Fprintf("reset %s", resetTargetName[node.Target])
+}
+
+// OtherAdmin represents an ANALYZE, OPTIMIZE or REPAIR TABLE
+// statement. Modifier is nil, "local" or "no_write_to_binlog".
+// Options are the lowercased options of REPAIR TABLE, like
+// "quick use_frm", or nil.
+type OtherAdmin struct {
+	Verb     int
+	Modifier []byte
+	Tables   []*Node
+	Options  []byte
+}
+
+// OtherAdmin verbs.
+const (
+	ADMIN_ANALYZE = iota
+	ADMIN_OPTIMIZE
+	ADMIN_REPAIR
+)
+
+var adminVerbName = []string{
+	"analyze",
+	"optimize",
+	"repair",
+}
+
+func (*OtherAdmin) statement() {}
+
+func (node *OtherAdmin) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%s ", adminVerbName[node.Verb])
+	if node.Modifier != nil {
+		buf.Fprintf("%s ", node.Modifier)
+	}
+	buf.Fprintf("table ")
+	for i, table := range node.Tables {
+		if i != 0 {
+			buf.Fprintf(", ")
+		}
+		buf.Fprintf("%v", table)
+	}
+	if node.Options != nil {
+		buf.Fprintf(" %s", node.Options)
+	}
+}
+
+// Flush represents a FLUSH statement. Type is the lowercased
+// list of flushed objects, like "privileges" or "binary logs,
+// status", and is "tables" for FLUSH TABLES, whose Tables are
+// the flushed tables if any. WithLock is set by FLUSH TABLES
+// WITH READ LOCK, and ForExport by FLUSH TABLES ... FOR EXPORT.
+// Modifier is as in OtherAdmin.
+type Flush struct {
+	Modifier  []byte
+	Type      []byte
+	Tables    []*Node
+	WithLock  bool
+	ForExport bool
+}
+
+func (*Flush) statement() {}
+
+func (node *Flush) Format(buf *TrackedBuffer) {
+	buf.Fprintf("flush ")
+	if node.Modifier != nil {
+		buf.Fprintf("%s ", node.Modifier)
+	}
+	buf.Fprintf("%s", node.Type)
+	for i, table := range node.Tables {
+		if i == 0 {
+			buf.Fprintf(" %v", table)
+		} else {
+			buf.Fprintf(", %v", table)
+		}
+	}
+	if node.WithLock {
+		buf.Fprintf(" with read lock")
+	}
+	if node.ForExport {
+		buf.Fprintf(" for export")
+	}
+}
+
+// Load represents a LOAD DATA INFILE statement. Conflict is
+// nil, "replace" or "ignore". IgnoreLines is the number of
+// lines skipped at the start of the file, or nil.
+type Load struct {
+	Local       bool
+	FileName    *Node
+	Conflict    []byte
+	Table       *Node
+	Fields      *FieldsOptions
+	Lines       *LinesOptions
+	IgnoreLines *Node
+	Columns     Columns
+}
+
+func (*Load) statement() {}
+
+func (node *Load) Format(buf *TrackedBuffer) {
+	buf.Fprintf("load data ")
+	if node.Local {
+		buf.Fprintf("local ")
+	}
+	buf.Fprintf("infile %v ", node.FileName)
+	if node.Conflict != nil {
+		buf.Fprintf("%s ", node.Conflict)
+	}
+	buf.Fprintf("into table %v", node.Table)
+	if node.Fields != nil {
+		buf.Fprintf(" %v", node.Fields)
+	}
+	if node.Lines != nil {
+		buf.Fprintf(" %v", node.Lines)
+	}
+	if node.IgnoreLines != nil {
+		buf.Fprintf(" ignore %v lines", node.IgnoreLines)
+	}
+	if node.Columns != nil {
+		buf.Fprintf(" %v", node.Columns)
+	}
+}
+
+// Grant represents a GRANT or REVOKE statement. Action is
+// GRANT or REVOKE. Privileges are lowercase, like "select"
+// or "grant option". On is a table name where the database
+// and the table can be the WILDCARD "*". Raw contains the
+// text of the clauses that follow the users, like WITH
+// GRANT OPTION, which are not parsed.
+type Grant struct {
+	Action     int
+	Privileges []string
+	On         *Node
+	Users      []*UserSpec
+	Raw        []byte
+}
+
+func (*Grant) statement() {}
+
+func (node *Grant) Format(buf *TrackedBuffer) {
+	if node.Action == GRANT {
+		buf.Fprintf("grant ")
+	} else {
+		buf.Fprintf("revoke ")
+	}
+	for i, privilege := range node.Privileges {
+		if i != 0 {
+			buf.Fprintf(", ")
+		}
+		buf.Fprintf("%s", privilege)
+	}
+	buf.Fprintf(" on %v", node.On)
+	if node.Action == GRANT {
+		buf.Fprintf(" to ")
+	} else {
+		buf.Fprintf(" from ")
+	}
+	for i, user := range node.Users {
+		if i != 0 {
+			buf.Fprintf(", ")
+		}
+		buf.Fprintf("%v", user)
+	}
+	if node.Raw != nil {
+		buf.Fprintf(" %s", node.Raw)
+	}
+}
+
+// UserSpec is an account of a GRANT or REVOKE statement.
+// User and Host are strings, or identifiers if they're not
+// quoted. Host is nil if it's not specified.
+type UserSpec struct {
+	User *Node
+	Host *Node
+}
+
+func (node *UserSpec) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v", node.User)
+	if node.Host != nil {
+		buf.Fprintf("@%v", node.Host)
+	}
+}
+
+// LockTables represents a LOCK TABLES statement.
+type LockTables struct {
+	Tables []*TableLock
+}
+
+func (*LockTables) statement() {}
+
+func (node *LockTables) Format(buf *TrackedBuffer) {
+	buf.Fprintf("lock tables ")
+	for i, table := range node.Tables {
+		if i != 0 {
+			buf.Fprintf(", ")
+		}
+		buf.Fprintf("%v", table)
+	}
+}
+
+// TableLock is a table of LOCK TABLES, with its optional
+// alias, and its lock type. Table has no index hints.
+type TableLock struct {
+	Table *AliasedTableExpr
+	Lock  int
+}
+
+// Lock types.
+const (
+	LOCK_READ = iota
+	LOCK_READ_LOCAL
+	LOCK_WRITE
+	LOCK_LOW_PRIORITY_WRITE
+)
+
+var lockTypeName = []string{
+	"read",
+	"read local",
+	"write",
+	"low_priority write",
+}
+
+func (node *TableLock) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v %s", node.Table, lockTypeName[node.Lock])
+}
+
+// UnlockTables represents an UNLOCK TABLES statement.
+type UnlockTables struct{}
+
+func (*UnlockTables) statement() {}
+
+func (node *UnlockTables) Format(buf *TrackedBuffer) {
+	buf.Fprintf("unlock tables")
+}
+
+// Begin represents a BEGIN or START TRANSACTION statement.
+// Modifiers are the characteristics given to START TRANSACTION,
+// like "with consistent snapshot, read only", or nil.
+type Begin struct {
+	Comments  Comments
+	Start     bool
+	Modifiers []byte
+}
+
+func (*Begin) statement() {}
+
+func (node *Begin) Format(buf *TrackedBuffer) {
+	if !node.Start {
+		buf.Fprintf("begin")
+		formatTrailingComments(buf, node.Comments)
+		return
+	}
+	buf.Fprintf("start %vtransaction", node.Comments)
+	if node.Modifiers != nil {
+		buf.Fprintf(" %s", node.Modifiers)
+	}
+}
+
+// Commit represents a COMMIT statement.
+type Commit struct {
 	Comments Comments
-	Table    *Node
-	Where    *Node
-	OrderBy  *Node
-	Limit    *Node
 }
 
-func (*Delete) statement() {}
+func (*Commit) statement() {}
 
-func (node *Delete) Format(buf *TrackedBuffer) {
-	buf.Fprintf("delete %vfrom %v%v%v%v",
-		node.Comments,
-		node.Table, node.Where, node.OrderBy, node.Limit)
+func (node *Commit) Format(buf *TrackedBuffer) {
+	buf.Fprintf("commit")
+	formatTrailingComments(buf, node.Comments)
 }
 
-// Set represents a SET statement.
-type Set struct {
+// Rollback represents a ROLLBACK statement.
+type Rollback struct {
 	Comments Comments
-	Updates  *Node
 }
 
-func (*Set) statement() {}
+func (*Rollback) statement() {}
 
-func (node *Set) Format(buf *TrackedBuffer) {
-	buf.Fprintf("set %v%v", node.Comments, node.Updates)
+func (node *Rollback) Format(buf *TrackedBuffer) {
+	buf.Fprintf("rollback")
+	formatTrailingComments(buf, node.Comments)
 }
 
-// DDLSimple represents a CREATE, ALTER or DROP statement.
-type DDLSimple struct {
-	Action int
-	Table  *Node
+// formatTrailingComments writes comments after a statement
+// that has nothing following them.
+func formatTrailingComments(buf *TrackedBuffer, comments Comments) {
+	for _, comment := range comments {
+		buf.Fprintf(" %s", []byte(comment))
+	}
 }
 
-func (*DDLSimple) statement() {}
+// Use represents a USE statement, which changes
+// the default database to DBName.
+type Use struct {
+	DBName *Node
+}
 
-func (node *DDLSimple) Format(buf *TrackedBuffer) {
-	switch node.Action {
-	case CREATE:
-		buf.Fprintf("create table %v", node.Table)
-	case ALTER:
-		buf.Fprintf("alter table %v", node.Table)
-	case DROP:
-		buf.Fprintf("drop table %v", node.Table)
-	default:
-		panic("unreachable")
+func (*Use) statement() {}
+
+func (node *Use) Format(buf *TrackedBuffer) {
+	buf.Fprintf("use %v", node.DBName)
+}
+
+// Show represents a SHOW statement.
+// VitessObject is set if Type is SHOW_VITESS. OnTable is the
+// table of SHOW COLUMNS and SHOW CREATE TABLE or VIEW, and
+// DBName the database of SHOW TABLES
+// if one was specified. Like and Where are the optional filters.
+// Scope is global or session for SHOW GLOBAL or SESSION VARIABLES
+// or STATUS, and nil if no scope was specified.
+// Limit is the LIMIT of SHOW WARNINGS or ERRORS, or nil, and
+// Count is set by SHOW COUNT(*) WARNINGS or ERRORS.
+// SHOW statements that aren't recognized have the SHOW_OTHER
+// type, and Raw holds their text after SHOW.
+type Show struct {
+	Type         int
+	Scope        []byte
+	VitessObject VitessObject
+	OnTable      *Node
+	DBName       *Node
+	Like         *Node
+	Where        *Node
+	Limit        *Node
+	Count        bool
+	Raw          []byte
+}
+
+// Show types.
+const (
+	SHOW_VITESS = iota
+	SHOW_TABLES
+	SHOW_DATABASES
+	SHOW_COLUMNS
+	SHOW_CREATE_TABLE
+	SHOW_CREATE_VIEW
+	SHOW_VARIABLES
+	SHOW_STATUS
+	SHOW_WARNINGS
+	SHOW_ERRORS
+	SHOW_OTHER
+)
+
+var showTypeName = []string{
+	"vitess",
+	"tables",
+	"databases",
+	"columns",
+	"create table",
+	"create view",
+	"variables",
+	"status",
+	"warnings",
+	"errors",
+	"other",
+}
+
+func (*Show) statement() {}
+
+func (node *Show) Format(buf *TrackedBuffer) {
+	switch node.Type {
+	case SHOW_VITESS:
+		buf.Fprintf("show %v", node.VitessObject)
+		return
+	case SHOW_CREATE_TABLE, SHOW_CREATE_VIEW:
+		buf.Fprintf("show %s %v", showTypeName[node.Type], node.OnTable)
+		return
+	case SHOW_OTHER:
+		buf.Fprintf("show %s", node.Raw)
+		return
+	}
+	buf.Fprintf("show ")
+	if node.Scope != nil {
+		buf.Fprintf("%s ", node.Scope)
+	}
+	if node.Count {
+		buf.Fprintf("count(*) ")
+	}
+	buf.Fprintf("%s", showTypeName[node.Type])
+	if node.OnTable != nil {
+		buf.Fprintf(" from %v", node.OnTable)
+	}
+	if node.DBName != nil {
+		buf.Fprintf(" from %v", node.DBName)
+	}
+	if node.Like != nil {
+		buf.Fprintf(" like %v", node.Like)
+	}
+	if node.Where != nil {
+		buf.Fprintf(" where %v", node.Where)
+	}
+	if node.Limit != nil {
+		buf.Fprintf("%v", node.Limit)
 	}
 }
 
-// Rename represents a RENAME statement.
-type Rename struct {
-	OldName, NewName *Node
+// VitessObject represents the vitess pseudo-object
+// listed by SHOW, like VITESS_KEYSPACES.
+type VitessObject interface {
+	vitessObject()
+	SQLNode
 }
 
-func (*Rename) statement() {}
+// ShowVitessKeyspaces represents SHOW VITESS_KEYSPACES.
+// Like is nil unless a LIKE pattern was specified.
+type ShowVitessKeyspaces struct {
+	Like *Node
+}
 
-func (node *Rename) Format(buf *TrackedBuffer) {
-	buf.Fprintf("rename table %v %v", node.OldName, node.NewName)
+func (*ShowVitessKeyspaces) vitessObject() {}
+
+func (node *ShowVitessKeyspaces) Format(buf *TrackedBuffer) {
+	buf.Fprintf("vitess_keyspaces")
+	if node.Like != nil {
+		buf.Fprintf(" like %v", node.Like)
+	}
+}
+
+// ShowVitessShards represents SHOW VITESS_SHARDS.
+// Like is nil unless a LIKE pattern was specified.
+type ShowVitessShards struct {
+	Like *Node
+}
+
+func (*ShowVitessShards) vitessObject() {}
+
+func (node *ShowVitessShards) Format(buf *TrackedBuffer) {
+	buf.Fprintf("vitess_shards")
+	if node.Like != nil {
+		buf.Fprintf(" like %v", node.Like)
+	}
 }
 
 // Comments represents a list of comments.
@@ -225,7 +1785,8 @@ func (*StarExpr) selectExpr() {}
 
 func (node *StarExpr) Format(buf *TrackedBuffer) {
 	if node.TableName != nil {
-		buf.Fprintf("%s.", node.TableName)
+		formatID(buf, node.TableName)
+		buf.Fprintf(".")
 	}
 	buf.Fprintf("*")
 }
@@ -241,7 +1802,8 @@ func (*NonStarExpr) selectExpr() {}
 func (node *NonStarExpr) Format(buf *TrackedBuffer) {
 	buf.Fprintf("%v", node.Expr)
 	if node.As != nil {
-		buf.Fprintf(" as %s", node.As)
+		buf.Fprintf(" as ")
+		formatID(buf, node.As)
 	}
 }
 
@@ -277,24 +1839,98 @@ type TableExpr interface {
 }
 
 // AliasedTableExpr represents a table expression
-// coupled with an optional alias or index hint.
+// coupled with an optional partition list, alias or
+// index hints.
 type AliasedTableExpr struct {
-	Expr *Node
-	As   []byte
-	Hint *Node
+	Expr       *Node
+	Partitions Partitions
+	As         []byte
+	Hints      IndexHints
 }
 
 func (*AliasedTableExpr) tableExpr() {}
 
 func (node *AliasedTableExpr) Format(buf *TrackedBuffer) {
-	buf.Fprintf("%v", node.Expr)
+	buf.Fprintf("%v%v", node.Expr, node.Partitions)
 	if node.As != nil {
-		buf.Fprintf(" as %s", node.As)
+		buf.Fprintf(" as ")
+		formatID(buf, node.As)
 	}
-	if node.Hint != nil {
-		// Hint node provides the space padding.
-		buf.Fprintf("%v", node.Hint)
+	buf.Fprintf("%v", node.Hints)
+}
+
+// Partitions represents the PARTITION clause that restricts
+// a table reference to the named partitions.
+type Partitions []*Node
+
+func (node Partitions) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	prefix := " partition ("
+	for _, n := range node {
+		buf.Fprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
+	buf.Fprintf(")")
+}
+
+// IndexHints represents the index hints of a table
+// reference, in the order they were given.
+type IndexHints []*IndexHint
+
+func (node IndexHints) Format(buf *TrackedBuffer) {
+	for _, n := range node {
+		buf.Fprintf("%v", n)
+	}
+}
+
+// IndexHint represents an index hint like "use index for
+// order by (a, b)". For is the part of the query the hint
+// applies to. Indexes is empty for "use index ()", which
+// tells MySQL to use no index.
+type IndexHint struct {
+	Type    int
+	For     int
+	Indexes []*Node
+}
+
+// Index hint types.
+const (
+	USE_INDEX = iota
+	IGNORE_INDEX
+	FORCE_INDEX
+)
+
+var indexHintTypeName = []string{
+	"use",
+	"ignore",
+	"force",
+}
+
+// Index hint scopes.
+const (
+	HINT_FOR_ALL = iota
+	HINT_FOR_JOIN
+	HINT_FOR_ORDER_BY
+	HINT_FOR_GROUP_BY
+)
+
+var indexHintForName = []string{
+	"",
+	" for join",
+	" for order by",
+	" for group by",
+}
+
+func (node *IndexHint) Format(buf *TrackedBuffer) {
+	buf.Fprintf(" %s index%s (", indexHintTypeName[node.Type], indexHintForName[node.For])
+	var prefix string
+	for _, n := range node.Indexes {
+		buf.Fprintf("%s%v", prefix, n)
+		prefix = ", "
 	}
+	buf.Fprintf(")")
 }
 
 // ParenTableExpr represents a parenthesized TableExpr.
@@ -325,3 +1961,100 @@ func (node *JoinTableExpr) Format(buf *TrackedBuffer) {
 		buf.Fprintf(" on %v", node.On)
 	}
 }
+
+// OverClause represents the OVER clause of a window function,
+// or the definition of a named window. Name is the window that
+// the clause refers to, and is the only field set by OVER w.
+// Name, PartitionBy, OrderBy and Frame are nil if they're not
+// specified.
+type OverClause struct {
+	Name        *Node
+	PartitionBy *Node
+	OrderBy     *Node
+	Frame       *FrameClause
+}
+
+func (node *OverClause) Format(buf *TrackedBuffer) {
+	if node.Name != nil && node.PartitionBy == nil && node.OrderBy == nil && node.Frame == nil {
+		buf.Fprintf("%v", node.Name)
+		return
+	}
+	buf.Fprintf("(")
+	node.formatSpec(buf)
+	buf.Fprintf(")")
+}
+
+func (node *OverClause) formatSpec(buf *TrackedBuffer) {
+	prefix := ""
+	if node.Name != nil {
+		buf.Fprintf("%v", node.Name)
+		prefix = " "
+	}
+	if node.PartitionBy != nil {
+		buf.Fprintf("partition by %v", node.PartitionBy)
+		prefix = " "
+	}
+	if node.OrderBy != nil {
+		buf.Fprintf("%sorder by %v", prefix, node.OrderBy)
+		prefix = " "
+	}
+	if node.Frame != nil {
+		buf.Fprintf("%s%v", prefix, node.Frame)
+	}
+}
+
+// NamedWindows represents the WINDOW clause of a SELECT.
+type NamedWindows []*NamedWindow
+
+func (node NamedWindows) Format(buf *TrackedBuffer) {
+	prefix := " window "
+	for _, n := range node {
+		buf.Fprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
+}
+
+// NamedWindow is a window defined by the WINDOW clause.
+type NamedWindow struct {
+	Name *Node
+	Spec *OverClause
+}
+
+func (node *NamedWindow) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v as (", node.Name)
+	node.Spec.formatSpec(buf)
+	buf.Fprintf(")")
+}
+
+// FrameClause is the frame of a window, which is the set of
+// rows the function is evaluated on. Unit is rows or range.
+// End is nil if only the start of the frame is specified.
+type FrameClause struct {
+	Unit  []byte
+	Start *FramePoint
+	End   *FramePoint
+}
+
+func (node *FrameClause) Format(buf *TrackedBuffer) {
+	if node.End == nil {
+		buf.Fprintf("%s %v", node.Unit, node.Start)
+		return
+	}
+	buf.Fprintf("%s between %v and %v", node.Unit, node.Start, node.End)
+}
+
+// FramePoint is a bound of a window frame. Type is unbounded
+// preceding, unbounded following, current row, preceding or
+// following. Expr is the number of rows, or the range, of the
+// last two.
+type FramePoint struct {
+	Type []byte
+	Expr *Node
+}
+
+func (node *FramePoint) Format(buf *TrackedBuffer) {
+	if node.Expr != nil {
+		buf.Fprintf("%v ", node.Expr)
+	}
+	buf.Fprintf("%s", node.Type)
+}
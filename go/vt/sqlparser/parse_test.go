@@ -15,6 +15,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strings"
 	"testing"
@@ -32,6 +33,193 @@ func TestGen(t *testing.T) {
 	}
 }
 
+func TestParserOptions(t *testing.T) {
+	testcases := []struct {
+		sqlMode string
+		input   string
+		output  string
+	}{
+		{"", `select "a" from t where a = 1 || b = 2`, "select 'a' from t where a = 1 or b = 2"},
+		{"ANSI_QUOTES", `select "a" from t`, "select a from t"},
+		{"PIPES_AS_CONCAT", "select a || b from t", "select a||b from t"},
+		{"ansi", `select "a" || 'b' from t`, "select a||'b' from t"},
+		{"STRICT_TRANS_TABLES, ANSI_QUOTES", `select "a" from t`, "select a from t"},
+	}
+	for _, tcase := range testcases {
+		tree, err := ParseWithOptions(tcase.input, NewParserOptions(tcase.sqlMode))
+		if err != nil {
+			t.Errorf("ParseWithOptions(%s, %s): %v", tcase.input, tcase.sqlMode, err)
+			continue
+		}
+		if out := String(tree); out != tcase.output {
+			t.Errorf("ParseWithOptions(%s, %s): %s, want %s", tcase.input, tcase.sqlMode, out, tcase.output)
+		}
+	}
+
+	if _, err := Parse("select partitions from t"); err == nil {
+		t.Errorf("Parse(select partitions from t): nil, want error")
+	}
+	options := NewParserOptions("")
+	options.Unreserved = map[string]bool{"partitions": true}
+	tree, err := ParseWithOptions("select partitions from t", options)
+	if err != nil {
+		t.Errorf("ParseWithOptions(select partitions from t): %v", err)
+	} else if out := String(tree); out != "select `partitions` from t" {
+		t.Errorf("ParseWithOptions(select partitions from t): %s, want select `partitions` from t", out)
+	}
+}
+
+func TestVersionComments(t *testing.T) {
+	testcases := []struct {
+		version int
+		input   string
+		output  string
+	}{
+		{0, "select /*!80015 sql_no_cache */ a from t", "select /*!80015 sql_no_cache */ a from t"},
+		{50709, "select a from t /*!80015 lock in share mode */", "select a from t"},
+		{80015, "select a from t /*!80015 lock in share mode */", "select a from t lock in share mode"},
+		{80015, "select a from t where /*!50000 a = 1 and*/ b = 2", "select a from t where a = 1 and b = 2"},
+		{80015, "select a from /*!t*/ where a = 1", "select a from t where a = 1"},
+		{80015, "select a /*!90000 , b */ from t", "select a from t"},
+	}
+	for _, tcase := range testcases {
+		tree, err := ParseWithOptions(tcase.input, ParserOptions{ServerVersion: tcase.version})
+		if err != nil {
+			t.Errorf("%s: %v", tcase.input, err)
+			continue
+		}
+		if out := String(tree); out != tcase.output {
+			t.Errorf("ServerVersion %d, %s: %s, want %s", tcase.version, tcase.input, out, tcase.output)
+		}
+	}
+}
+
+func TestStripProxyHeader(t *testing.T) {
+	testcases := []struct {
+		in       string
+		out      string
+		stripped bool
+	}{
+		{"PROXY TCP4 10.0.0.1 10.0.0.2 56324 3306\r\nselect 1 from t", "select 1 from t", true},
+		{"PROXY TCP6 ::1 ::1 56324 3306\r\nselect 1 from t", "select 1 from t", true},
+		{"PROXY UNKNOWN\r\nselect 1 from t", "select 1 from t", true},
+		{"select 1 from t", "select 1 from t", false},
+		{"PROXY TCP4 10.0.0.1\r\nselect 1 from t", "PROXY TCP4 10.0.0.1\r\nselect 1 from t", false},
+		{"PROXY TCP4 10.0.0.1 10.0.0.2 56324 3306", "PROXY TCP4 10.0.0.1 10.0.0.2 56324 3306", false},
+		{"select 'PROXY TCP4 1 2 3 4\n' from t", "select 'PROXY TCP4 1 2 3 4\n' from t", false},
+	}
+	for _, tcase := range testcases {
+		out, stripped := StripProxyHeader(tcase.in)
+		if out != tcase.out || stripped != tcase.stripped {
+			t.Errorf("StripProxyHeader(%q): %q, %v, want %q, %v", tcase.in, out, stripped, tcase.out, tcase.stripped)
+		}
+	}
+}
+
+func TestUnicodeIdentifiers(t *testing.T) {
+	testcases := []struct {
+		in  string
+		out string
+	}{
+		{"café", "café"},
+		{"`café`", "café"},
+		{"名前", "名前"},
+		{"`名前 x`", "名前 x"},
+		{"naïve_1", "naïve_1"},
+		{"\xffa", ""},
+		{"a\xe5\x90", ""},
+	}
+	for _, tcase := range testcases {
+		tkn := NewStringTokenizer(tcase.in)
+		tok := tkn.Scan()
+		if tcase.out == "" {
+			if tok.Type != LEX_ERROR {
+				t.Errorf("Scan(%q): %d, want LEX_ERROR", tcase.in, tok.Type)
+			}
+			continue
+		}
+		if tok.Type != ID || string(tok.Value) != tcase.out {
+			t.Errorf("Scan(%q): %d %q, want ID %q", tcase.in, tok.Type, tok.Value, tcase.out)
+		}
+		if tok := tkn.Scan(); tok.Type != 0 {
+			t.Errorf("Scan(%q): trailing %q", tcase.in, tok.Value)
+		}
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if out := String(MustParse("select a from t")); out != "select a from t" {
+		t.Errorf("MustParse: %s, want select a from t", out)
+	}
+	defer func() {
+		want := `MustParse("select from t"): syntax error at position 12 near from`
+		if x := recover(); x != want {
+			t.Errorf("MustParse: panic %v, want %s", x, want)
+		}
+	}()
+	MustParse("select from t")
+}
+
+// benchCorpus is a representative set of queries for each
+// category of statement, used to track parser performance.
+var benchCorpus = []struct {
+	category string
+	queries  []string
+}{{
+	"select",
+	[]string{
+		"select a from t",
+		"select /* comment */ a, b as c, count(*) from t where d = 1 and e in (1, 2, 3) group by a having count(*) > 1 order by b desc limit 10",
+		"select * from t as a join u as b on a.id = b.id left join v on v.id = b.id where a.x like 'abc%'",
+		"select a from t where b = (select max(b) from u where u.c = t.c) for update",
+		"select case when a = 1 then 'one' when a = 2 then 'two' else 'many' end from t",
+		"select a from t union all select b from u union select c from v",
+		"select a, sum(b) over (partition by c order by d) from t where e between :lo and :hi",
+	},
+}, {
+	"insert",
+	[]string{
+		"insert into t values (1, 2, 3)",
+		"insert into t(a, b, c) values (1, 'x', :c), (2, 'y', :c2) on duplicate key update b = values(b)",
+		"insert into t set a = 1, b = 'two'",
+		"insert into t(a, b) select c, d from u where e > 0",
+	},
+}, {
+	"update",
+	[]string{
+		"update t set a = 1 where id = 2",
+		"update /* comment */ t set a = a + 1, b = 'x' where c in (select c from u) order by d limit 10",
+	},
+}, {
+	"delete",
+	[]string{
+		"delete from t where id = 1",
+		"delete from t where a < 10 and b is not null order by a limit 100",
+	},
+}, {
+	"ddl",
+	[]string{
+		"create table t (id bigint unsigned not null auto_increment primary key, name varchar(255) default 'x')",
+		"alter table t add column c int, add index (c)",
+		"drop table if exists t",
+		"rename table t to u",
+	},
+}}
+
+func BenchmarkParse(b *testing.B) {
+	for _, category := range benchCorpus {
+		b.Run(category.category, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				sql := category.queries[i%len(category.queries)]
+				if _, err := Parse(sql); err != nil {
+					b.Fatalf("Parse(%s): %v", sql, err)
+				}
+			}
+		})
+	}
+}
+
 var (
 	SQLZERO = sqltypes.MakeString([]byte("0"))
 )
@@ -123,11 +311,12 @@ func TestStreamExec(t *testing.T) {
 }
 
 var actionToString = map[int]string{
-	CREATE: "CREATE",
-	ALTER:  "ALTER",
-	DROP:   "DROP",
-	RENAME: "RENAME",
-	0:      "NONE",
+	CREATE:   "CREATE",
+	ALTER:    "ALTER",
+	DROP:     "DROP",
+	RENAME:   "RENAME",
+	TRUNCATE: "TRUNCATE",
+	0:        "NONE",
 }
 
 func TestDDL(t *testing.T) {
@@ -141,6 +330,12 @@ func TestDDL(t *testing.T) {
 		matchString(t, tcase.lineno, expected["Action"], actionToString[plan.Action])
 		matchString(t, tcase.lineno, expected["TableName"], plan.TableName)
 		matchString(t, tcase.lineno, expected["NewName"], plan.NewName)
+		if temporary, ok := expected["Temporary"]; ok && temporary != plan.Temporary {
+			t.Errorf("Line %d: expected temporary %v, received %v", tcase.lineno, temporary, plan.Temporary)
+		}
+		if view, ok := expected["View"]; ok && view != plan.View {
+			t.Errorf("Line %d: expected view %v, received %v", tcase.lineno, view, plan.View)
+		}
 	}
 }
 
@@ -170,6 +365,835 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestShow(t *testing.T) {
+	testcases := []struct {
+		in  string
+		out string
+	}{
+		{"show tables", "tables"},
+		{"show tables from db like 'a%'", "tables db: like:'a%'"},
+		{"show databases where a = 1", "databases where:a = 1"},
+		{"show columns from t", "columns t:"},
+		{"show fields in t from db where b = :b", "columns db.t: where:b = :b"},
+		{"show vitess_keyspaces like 'a'", "vitess"},
+		{"show processlist", "other raw:processlist"},
+		{"/* comment */ show  global status like 'a%'", "status scope:global like:'a%'"},
+		{"show variables where variable_name in (:a, :b)", "variables where:variable_name in (:a, :b)"},
+		{"show local variables", "variables scope:session"},
+		{"show global foo", "unexpected show foo at position 17 near "},
+		{"show warnings", "warnings"},
+		{"show errors limit :a, 10", "errors limit: limit :a, 10"},
+		{"show count(*) warnings", "warnings count"},
+		{"show warnings where a = 1", "unexpected where at position 27 near "},
+		{"show create table db.t", "create table db.t:"},
+		{"show create view v", "create view v:"},
+		{"show create database d", "other raw:create database d"},
+		{"show create table", "syntax error at position 19 near "},
+		{"show vitess_keyspaces where a = 1", "unexpected where at position 35 near "},
+		{"show columns", "unexpected show columns at position 14 near "},
+		{"show tables from db.t", "expecting database name at position 23 near "},
+		{"show", "syntax error at position 6 near "},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		var out string
+		if err != nil {
+			out = err.Error()
+		} else {
+			show := tree.(*Show)
+			out = showTypeName[show.Type]
+			if show.Scope != nil {
+				out += " scope:" + string(show.Scope)
+			}
+			if show.OnTable != nil {
+				out += " " + String(show.OnTable) + ":"
+			}
+			if show.DBName != nil {
+				out += " " + String(show.DBName) + ":"
+			}
+			if show.Like != nil {
+				out += " like:" + String(show.Like)
+			}
+			if show.Where != nil {
+				out += " where:" + String(show.Where)
+			}
+			if show.Limit != nil {
+				out += " limit:" + String(show.Limit)
+			}
+			if show.Count {
+				out += " count"
+			}
+			if show.Raw != nil {
+				out += " raw:" + string(show.Raw)
+			}
+		}
+		if out != tcase.out {
+			t.Errorf("Parse(%s): %q, want %q", tcase.in, out, tcase.out)
+		}
+	}
+}
+
+func TestSetTransaction(t *testing.T) {
+	testcases := []struct {
+		in  string
+		out string
+	}{
+		{"set transaction isolation level repeatable read", " level:repeatable read"},
+		{"set global transaction read only", "global mode:read only"},
+		{"set local transaction read write, isolation level READ UNCOMMITTED", "session level:read uncommitted mode:read write"},
+		{"set transaction isolation level serializable", " level:serializable"},
+		{"set global transaction", "expecting names or charset at position 24 near "},
+		{"set foo transaction read only", "expecting transaction at position 31 near "},
+		{"set transaction isolation level foo", "unexpected isolation level at position 37 near "},
+		{"set transaction read only, read write", "unexpected transaction characteristic at position 39 near "},
+		{"set transaction read", "expecting names or charset at position 22 near "},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		var out string
+		if err != nil {
+			out = err.Error()
+		} else {
+			chars := tree.(*Set).Transaction
+			out = string(chars.Scope)
+			if chars.IsolationLevel != nil {
+				out += " level:" + string(chars.IsolationLevel)
+			}
+			if chars.AccessMode != nil {
+				out += " mode:" + string(chars.AccessMode)
+			}
+		}
+		if out != tcase.out {
+			t.Errorf("Parse(%s): %q, want %q", tcase.in, out, tcase.out)
+		}
+	}
+}
+
+func TestOtherAdmin(t *testing.T) {
+	testcases := []struct {
+		in  string
+		out string
+	}{
+		{"analyze table t", "0  t"},
+		{"optimize LOCAL tables t, d.u", "1 local t d.u"},
+		{"repair no_write_to_binlog table t", "2 no_write_to_binlog t"},
+		{"analyze remote table t", "expecting local or no_write_to_binlog at position 24 near "},
+		{"optimize local tabels t", "expecting tables at position 25 near "},
+		{"repair tabels t", "expecting tables at position 17 near "},
+		{"repair table t QUICK use_frm", "2  t quick use_frm"},
+		{"repair table t foo", "unexpected option foo at position 19 near foo"},
+		{"optimize table t quick", "unexpected option quick at position 24 near "},
+		{"repair tables t quick", "expecting local or no_write_to_binlog at position 23 near "},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		var out string
+		if err != nil {
+			out = err.Error()
+		} else {
+			admin := tree.(*OtherAdmin)
+			out = fmt.Sprintf("%d %s", admin.Verb, admin.Modifier)
+			for _, table := range admin.Tables {
+				out += " " + String(table)
+			}
+			if admin.Options != nil {
+				out += " " + string(admin.Options)
+			}
+		}
+		if out != tcase.out {
+			t.Errorf("Parse(%s): %q, want %q", tcase.in, out, tcase.out)
+		}
+	}
+}
+
+func TestFlush(t *testing.T) {
+	testcases := []struct {
+		in  string
+		out string
+	}{
+		{"flush tables", " tables lock:false"},
+		{"flush tables t1, d.t2 with read lock", " tables t1 d.t2 lock:true"},
+		{"flush local tables with read lock", "local tables lock:true"},
+		{"flush no_write_to_binlog binary logs, STATUS", "no_write_to_binlog binary logs, status lock:false"},
+		{"flush privileges with read lock", "unexpected with read lock at position 32 near lock"},
+		{"flush tables with foo lock", "expecting read at position 27 near lock"},
+		{"flush tables t u", "unexpected u at position 18 near "},
+		{"flush tables, t", "expecting table name at position 17 near "},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		var out string
+		if err != nil {
+			out = err.Error()
+		} else {
+			flush := tree.(*Flush)
+			out = fmt.Sprintf("%s %s", flush.Modifier, flush.Type)
+			for _, table := range flush.Tables {
+				out += " " + String(table)
+			}
+			out += fmt.Sprintf(" lock:%v", flush.WithLock)
+		}
+		if out != tcase.out {
+			t.Errorf("Parse(%s): %q, want %q", tcase.in, out, tcase.out)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	testcases := []struct {
+		in  string
+		out string
+	}{
+		{"load data infile 'x' into table t", "false x  t <nil> <nil>"},
+		{"load data local infile 'x' replace into table t lines terminated by 'a'", "true x replace t <nil> lines terminated by 'a'"},
+		{"load data infile 'x' into table t columns escaped by 'a' enclosed by 'b'", "false x  t fields enclosed by 'b' escaped by 'a' <nil>"},
+		{"load data infile 'x' into table t lines terminated by 'a' fields terminated by 'b'", "unexpected fields at position 84 near "},
+		{"load data infile 'x' into table t fields terminated by 'a' terminated by 'b'", "unexpected option terminated by at position 78 near "},
+		{"load data infile 'x' into table t fields starting by 'a'", "unexpected option starting by at position 58 near "},
+		{"load data infile 'x' into table t ignore 1 foo", "expecting lines or rows at position 47 near foo"},
+		{"load data outfile 'x' into table t", "expecting infile at position 22 near x"},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.in)
+		var out string
+		if err != nil {
+			out = err.Error()
+		} else {
+			load := tree.(*Load)
+			out = fmt.Sprintf("%v %s %s %v", load.Local, load.FileName.Value, load.Conflict, String(load.Table))
+			fields, lines := "<nil>", "<nil>"
+			if load.Fields != nil {
+				fields = String(load.Fields)
+			}
+			if load.Lines != nil {
+				lines = String(load.Lines)
+			}
+			out += " " + fields + " " + lines
+		}
+		if out != tcase.out {
+			t.Errorf("Parse(%s): %q, want %q", tcase.in, out, tcase.out)
+		}
+	}
+}
+
+func TestColumnTypes(t *testing.T) {
+	tree, err := Parse("create table a (a enum('x', 'y'), b set('p'), c decimal(10,2), d varchar(64), e point)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, col := range tree.(*DDLSimple).TableSpec.Columns {
+		values := make([]string, len(col.Type.EnumValues))
+		for i, value := range col.Type.EnumValues {
+			values[i] = string(value.Value)
+		}
+		got = append(got, fmt.Sprintf("%s:%s:%s:%s", col.Type.Type, col.Type.Length, col.Type.Scale, strings.Join(values, "|")))
+	}
+	want := []string{"enum:::x|y", "set:::p", "decimal:10:2:", "varchar:64::", "point:::"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("column types: %v, want %v", got, want)
+	}
+}
+
+func TestTableSpec(t *testing.T) {
+	tree, err := Parse("create table a (id int, b varchar(10), primary key (id), unique key b_idx (b(4), id), key (b)) engine=InnoDB default charset utf8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := tree.(*DDLSimple).TableSpec
+	var got []string
+	for _, index := range spec.Indexes {
+		var columns []string
+		for _, col := range index.Columns {
+			columns = append(columns, fmt.Sprintf("%s/%s", col.Column.Value, col.Length))
+		}
+		var name []byte
+		if index.Name != nil {
+			name = index.Name.Value
+		}
+		got = append(got, fmt.Sprintf("%s:%s:%s", index.Type, name, strings.Join(columns, "|")))
+	}
+	for _, option := range spec.Options {
+		got = append(got, fmt.Sprintf("%s=%s", option.Name, String(option.Value)))
+	}
+	want := []string{"primary::id/", "unique:b_idx:b/4|id/", "::b/", "engine=innodb", "charset=utf8"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("table spec: %v, want %v", got, want)
+	}
+}
+
+func TestTableConstraints(t *testing.T) {
+	tree, err := Parse("create table a (id int, b int, constraint pk primary key (id), constraint fk foreign key (b) references c(id) on delete cascade, check (b > 0)) partition by hash(id)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := tree.(*DDLSimple).TableSpec
+	var got []string
+	for _, index := range spec.Indexes {
+		got = append(got, fmt.Sprintf("%s:%s", index.Type, String(index.Constraint)))
+	}
+	for _, constraint := range spec.Constraints {
+		if fk := constraint.ForeignKey; fk != nil {
+			got = append(got, fmt.Sprintf("fk:%s:%s:%s:%s:%s", String(constraint.Name), String(fk.Columns), String(fk.ReferencedTable), String(fk.ReferencedColumns), fk.OnDelete))
+		} else {
+			got = append(got, fmt.Sprintf("check:%s", String(constraint.Check)))
+		}
+	}
+	got = append(got, fmt.Sprintf("raw:%s:%d", spec.RawOptions, len(spec.Options)))
+	want := []string{"primary:pk", "fk:fk:(b):c:(id):cascade", "check:b > 0", "raw:partition by hash(id):0"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("table constraints: %v, want %v", got, want)
+	}
+}
+
+func TestAlterOptions(t *testing.T) {
+	tree, err := Parse("alter table a add column b int, drop index c, drop primary key, engine=innodb, disable keys, add primary key (b)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, option := range tree.(*DDLSimple).AlterOptions {
+		got = append(got, fmt.Sprintf("%T:%s", option, String(option)))
+	}
+	want := []string{
+		"*sqlparser.AddColumn:add column b int",
+		"*sqlparser.DropIndex:drop index c",
+		"*sqlparser.DropIndex:drop primary key",
+		"*sqlparser.TableOption:engine=innodb",
+		"*sqlparser.AlterRaw:disable keys",
+		"*sqlparser.AddIndex:add primary key (b)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("alter options: %v, want %v", got, want)
+	}
+}
+
+func TestAlterRaw(t *testing.T) {
+	tree, err := Parse("alter table a add column b int after a, add c int first, drop d, add constraint fk foreign key (a) references u(id)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, option := range tree.(*DDLSimple).AlterOptions {
+		got = append(got, fmt.Sprintf("%T:%s", option, String(option)))
+	}
+	want := []string{
+		"*sqlparser.AlterRaw:add column b int after a",
+		"*sqlparser.AlterRaw:add c int first",
+		"*sqlparser.DropColumn:drop column d",
+		"*sqlparser.AlterRaw:add constraint fk foreign key (a) references u(id)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("alter options: %v, want %v", got, want)
+	}
+}
+
+func TestCreateIndex(t *testing.T) {
+	tree, err := Parse("create unique index idx_a using hash on t (a, b(10) desc) comment 'c'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ddl := tree.(*DDLSimple)
+	index := ddl.AlterOptions[0].(*AddIndex).Index
+	var columns []string
+	for _, col := range index.Columns {
+		columns = append(columns, fmt.Sprintf("%s/%s/%s", col.Column.Value, col.Length, col.Direction))
+	}
+	got := fmt.Sprintf("%s %s:%s:%s:%s:%s", ddl.Table.Value, index.Type, index.Name.Value, strings.Join(columns, "|"), index.Using, String(index.Comment))
+	if want := "t unique:idx_a:a//|b/10/desc:hash:'c'"; got != want {
+		t.Errorf("create index: %s, want %s", got, want)
+	}
+
+	tree, err = Parse("create index i on t (a) visible")
+	if err != nil {
+		t.Fatal(err)
+	}
+	index = tree.(*DDLSimple).AlterOptions[0].(*AddIndex).Index
+	if string(index.Name.Value) != "i" || index.Columns != nil || string(index.Raw) != "(a) visible" {
+		t.Errorf("create index: %s %v %s, want i [] (a) visible", index.Name.Value, index.Columns, index.Raw)
+	}
+}
+
+func TestDropIndex(t *testing.T) {
+	cases := []struct {
+		sql  string
+		want []string
+	}{{
+		sql: "drop index `Idx_a` on t lock=none",
+		want: []string{
+			"*sqlparser.DropIndex:drop index idx_a",
+			"*sqlparser.TableOption:lock=none",
+		},
+	}, {
+		sql: "drop index idx_a on t algorithm=inplace foo bar",
+		want: []string{
+			"*sqlparser.DropIndex:drop index idx_a",
+			"*sqlparser.AlterRaw:algorithm=inplace foo bar",
+		},
+	}}
+	for _, tcase := range cases {
+		tree, err := Parse(tcase.sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ddl := tree.(*DDLSimple)
+		var got []string
+		for _, option := range ddl.AlterOptions {
+			got = append(got, fmt.Sprintf("%T:%s", option, String(option)))
+		}
+		if ddl.Action != ALTER || string(ddl.Table.Value) != "t" || strings.Join(got, "\n") != strings.Join(tcase.want, "\n") {
+			t.Errorf("%s: %d %s %v, want alter t %v", tcase.sql, ddl.Action, ddl.Table.Value, got, tcase.want)
+		}
+	}
+}
+
+func TestWithRecursive(t *testing.T) {
+	tree, err := Parse("with recursive nums (n) as (select 1 from dual union all select n + 1 from nums where n < 10) select * from nums")
+	if err != nil {
+		t.Fatal(err)
+	}
+	with := tree.(*Select).With
+	if !with.Recursive || len(with.CTEs) != 1 {
+		t.Fatalf("with: %s, want a recursive expression", String(with))
+	}
+	cte := with.CTEs[0]
+	union, ok := cte.Subquery.(*Union)
+	if !ok || len(union.Arms) != 1 || union.Arms[0].Type != SETOP_UNION_ALL {
+		t.Fatalf("subquery: %#v, want a union all", cte.Subquery)
+	}
+	if got, want := fmt.Sprintf("%s%v %s", cte.Name.Value, String(cte.Columns), String(union.Select2())), "nums(n) select n+1 from nums where n < 10"; got != want {
+		t.Errorf("expression: %s, want %s", got, want)
+	}
+}
+
+func TestParenUnion(t *testing.T) {
+	tree, err := Parse("(select a from t order by a limit 10) union all (select a from u) order by a desc limit 5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	union := tree.(*Union)
+	left, ok := union.First.(*ParenSelect)
+	if !ok || String(left.Select.(*Select).Limit) != " limit 10" {
+		t.Errorf("left: %#v, want a parenthesized select with its own limit", union.First)
+	}
+	if _, ok := union.Select2().(*ParenSelect); !ok {
+		t.Errorf("right: %#v, want a parenthesized select", union.Select2())
+	}
+	if got, want := String(union.OrderBy)+String(union.Limit), " order by a desc limit 5"; got != want {
+		t.Errorf("union tail: %q, want %q", got, want)
+	}
+}
+
+func TestUnionArms(t *testing.T) {
+	tree, err := Parse("select a from t union select b from u union all select c from v union distinct select d from w")
+	if err != nil {
+		t.Fatal(err)
+	}
+	union := tree.(*Union)
+	if got, want := String(union.First), "select a from t"; got != want {
+		t.Errorf("first: %s, want %s", got, want)
+	}
+	var got []string
+	for _, arm := range union.Arms {
+		got = append(got, String(arm))
+	}
+	want := []string{"union select b from u", "union all select c from v", "union distinct select d from w"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("arms: %q, want %q", got, want)
+	}
+	if got, want := String(union.Select1()), "select a from t union select b from u union all select c from v"; got != want {
+		t.Errorf("Select1: %s, want %s", got, want)
+	}
+	if got, want := String(union.Select2()), "select d from w"; got != want {
+		t.Errorf("Select2: %s, want %s", got, want)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		want string
+	}{{
+		sql:  "select a from t union select b from u intersect select c from v",
+		want: "[a union [b intersect c]]",
+	}, {
+		sql:  "select a from t intersect select b from u union select c from v",
+		want: "[a intersect b union c]",
+	}, {
+		sql:  "select a from t except select b from u intersect all select c from v intersect select d from w minus select e from x",
+		want: "[a except [b intersect all c intersect d] minus e]",
+	}, {
+		sql:  "select a from t union all select b from u intersect distinct (select c from v union select d from w)",
+		want: "[a union all [b intersect distinct ([c union d])]]",
+	}, {
+		sql:  "(select a from t union select b from u) intersect select c from v",
+		want: "[([a union b]) intersect c]",
+	}, {
+		sql:  "select a from t except (select b from u) intersect (select c from v) order by a asc",
+		want: "[a except [(b) intersect (c)]]",
+	}}
+	var group func(sel SelectStatement) string
+	group = func(sel SelectStatement) string {
+		switch sel := sel.(type) {
+		case *Union:
+			parts := []string{group(sel.First)}
+			for _, arm := range sel.Arms {
+				parts = append(parts, setOpName[arm.Type], group(arm.Select))
+			}
+			return "[" + strings.Join(parts, " ") + "]"
+		case *ParenSelect:
+			return "(" + group(sel.Select) + ")"
+		}
+		return String(sel.(*Select).SelectExprs)
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("%s: %v", tcase.sql, err)
+			continue
+		}
+		if got := group(tree.(SelectStatement)); got != tcase.want {
+			t.Errorf("%s: %s, want %s", tcase.sql, got, tcase.want)
+		}
+		if got := String(tree); got != tcase.sql {
+			t.Errorf("%s: formatted as %s", tcase.sql, got)
+		}
+	}
+}
+
+func TestNotExists(t *testing.T) {
+	tree, err := Parse("select * from t where not exists (select 1 from u where u.a = t.a)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	not := tree.(*Select).Where.NodeAt(0)
+	if not.Type != NOT || not.NodeAt(0).Type != EXISTS {
+		t.Errorf("where: %s, want NOT wrapping EXISTS", String(not))
+	}
+	if _, err := Parse("select * from t where not (select 1 from u)"); err == nil || !strings.HasPrefix(err.Error(), "expecting EXISTS") {
+		t.Errorf("not (subquery): %v, want expecting EXISTS", err)
+	}
+}
+
+func TestTuple(t *testing.T) {
+	tree, err := Parse("select * from t where (a, b) > (:x, :y) and (a, b) in ((1, 2), (3, 4)) and (a) = (1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	and := tree.(*Select).Where.NodeAt(0)
+	cmp, in, single := and.NodeAt(0).NodeAt(0), and.NodeAt(0).NodeAt(1), and.NodeAt(1)
+	if cmp.NodeAt(0).Type != TUPLE || cmp.NodeAt(1).Type != TUPLE || cmp.NodeAt(1).Len() != 2 {
+		t.Errorf("comparison: %s, want tuples on both sides", String(cmp))
+	}
+	if list := in.NodeAt(1).NodeAt(0); in.NodeAt(0).Type != TUPLE || list.NodeAt(0).Type != TUPLE || list.NodeAt(1).Type != TUPLE {
+		t.Errorf("in: %s, want tuples in the list", String(in))
+	}
+	if single.NodeAt(0).Type != ID || single.NodeAt(1).Type != NUMBER {
+		t.Errorf("single element: %s, want plain grouping", String(single))
+	}
+	query, err := GenerateFullQuery(tree).GenerateQuery(map[string]interface{}{"x": 1, "y": "a"}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(query), "select * from t where (a, b) > (1, 'a') and (a, b) in ((1, 2), (3, 4)) and a = 1"; got != want {
+		t.Errorf("GenerateQuery: %s, want %s", got, want)
+	}
+}
+
+func TestSelectOptions(t *testing.T) {
+	testcases := []struct {
+		sql      string
+		distinct Distinct
+		options  []string
+	}{
+		{"select a from t", false, nil},
+		{"select SQL_CALC_FOUND_ROWS * from t limit 10", false, []string{"sql_calc_found_rows"}},
+		{"select sql_no_cache distinct straight_join high_priority a from t", true, []string{"sql_no_cache", "straight_join", "high_priority"}},
+		{"select distinct sql_big_result sql_buffer_result 1", true, []string{"sql_big_result", "sql_buffer_result"}},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("%s: %v", tcase.sql, err)
+			continue
+		}
+		sel := tree.(*Select)
+		if sel.Distinct != tcase.distinct || !reflect.DeepEqual(sel.Options, tcase.options) {
+			t.Errorf("%s: %v %q, want %v %q", tcase.sql, sel.Distinct, sel.Options, tcase.distinct, tcase.options)
+		}
+	}
+}
+
+func TestLock(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		lock Lock
+	}{
+		{"select * from t", Lock{Type: NO_LOCK}},
+		{"select * from t for update", Lock{Type: FOR_UPDATE}},
+		{"select * from t for update nowait", Lock{Type: FOR_UPDATE, Wait: LOCK_NOWAIT}},
+		{"select * from t for update skip locked", Lock{Type: FOR_UPDATE, Wait: LOCK_SKIP_LOCKED}},
+		{"select * from t for share", Lock{Type: FOR_SHARE}},
+		{"select * from t for share nowait", Lock{Type: FOR_SHARE, Wait: LOCK_NOWAIT}},
+		{"select * from t lock in share mode", Lock{Type: LOCK_IN_SHARE_MODE}},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("%s: %v", tcase.sql, err)
+			continue
+		}
+		if lock := *tree.(*Select).Lock; lock != tcase.lock {
+			t.Errorf("%s: %+v, want %+v", tcase.sql, lock, tcase.lock)
+		}
+		if out := String(tree); out != tcase.sql {
+			t.Errorf("%s: formatted as %s", tcase.sql, out)
+		}
+	}
+
+	// The words of the lock clause are still identifiers.
+	sql := "select mode, locked, t.share from skip as t where nowait = 1 for share skip locked"
+	tree, err := Parse(sql)
+	if err != nil {
+		t.Fatalf("%s: %v", sql, err)
+	}
+	if lock := *tree.(*Select).Lock; lock != (Lock{Type: FOR_SHARE, Wait: LOCK_SKIP_LOCKED}) {
+		t.Errorf("%s: %+v", sql, lock)
+	}
+}
+
+func TestPartitions(t *testing.T) {
+	testcases := []struct {
+		sql        string
+		partitions []string
+		err        string
+	}{
+		{sql: "select * from t partition (p0) as x", partitions: []string{"p0"}},
+		{sql: "select * from t as x partition (p0, p1)", partitions: []string{"p0", "p1"}},
+		{sql: "select * from t x partition (p1)", partitions: []string{"p1"}},
+		{sql: "update t partition (p0, p1) set a = 1", partitions: []string{"p0", "p1"}},
+		{sql: "delete from t partition (p2) where a = 1", partitions: []string{"p2"}},
+		{sql: "insert into t partition (p3) values (1)", partitions: []string{"p3"}},
+		{sql: "select * from (select 1 from t) partition (p0) as x", err: "partitions can only be selected from a table"},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.sql)
+		if tcase.err != "" {
+			if err == nil || !strings.HasPrefix(err.Error(), tcase.err) {
+				t.Errorf("%s: %v, want %s", tcase.sql, err, tcase.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tcase.sql, err)
+			continue
+		}
+		var partitions Partitions
+		switch tree := tree.(type) {
+		case *Select:
+			partitions = tree.From[0].(*AliasedTableExpr).Partitions
+		case *Update:
+			partitions = tree.Partitions
+		case *Delete:
+			partitions = tree.Partitions
+		case *Insert:
+			partitions = tree.Partitions
+		}
+		var names []string
+		for _, partition := range partitions {
+			names = append(names, string(partition.Value))
+		}
+		if !reflect.DeepEqual(names, tcase.partitions) {
+			t.Errorf("%s: %v, want %v", tcase.sql, names, tcase.partitions)
+		}
+	}
+}
+
+func TestIndexHints(t *testing.T) {
+	tree, err := Parse("select * from t use index for order by (a) ignore key for group by (b, c) force index (primary) use index ()")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hints := tree.(*Select).From[0].(*AliasedTableExpr).Hints
+	want := []struct {
+		typ, scope int
+		indexes    string
+	}{
+		{USE_INDEX, HINT_FOR_ORDER_BY, "a"},
+		{IGNORE_INDEX, HINT_FOR_GROUP_BY, "b,c"},
+		{FORCE_INDEX, HINT_FOR_ALL, "primary"},
+		{USE_INDEX, HINT_FOR_ALL, ""},
+	}
+	if len(hints) != len(want) {
+		t.Fatalf("got %d hints, want %d", len(hints), len(want))
+	}
+	for i, hint := range hints {
+		var indexes []string
+		for _, index := range hint.Indexes {
+			indexes = append(indexes, string(index.Value))
+		}
+		if hint.Type != want[i].typ || hint.For != want[i].scope || strings.Join(indexes, ",") != want[i].indexes {
+			t.Errorf("hint %d: %+v, want %+v", i, hint, want[i])
+		}
+	}
+}
+
+func TestStraightJoin(t *testing.T) {
+	sql := "select * from a straight_join b on a.id = b.id left join c on b.id = c.id"
+	tree, err := Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := String(tree); out != sql {
+		t.Errorf("formatted as %s", out)
+	}
+	left, ok := tree.(*Select).From[0].(*JoinTableExpr)
+	if !ok || !bytes.Equal(left.Join, LJOIN) {
+		t.Fatalf("outer join: %#v, want left join", tree.(*Select).From[0])
+	}
+	straight, ok := left.LeftExpr.(*JoinTableExpr)
+	if !ok || !bytes.Equal(straight.Join, SJOIN) {
+		t.Fatalf("inner join: %#v, want straight_join", left.LeftExpr)
+	}
+	if on := String(straight.On); on != "a.id = b.id" {
+		t.Errorf("straight_join on %s, want a.id = b.id", on)
+	}
+}
+
+func TestConditionalFunctions(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		name string
+		args int
+	}{
+		{"select if(a > 1, b, c) from t", "if", 3},
+		{"select ifnull(a, 0) from t", "ifnull", 2},
+		{"select nullif(a, b) from t", "nullif", 2},
+		{"select coalesce(a, b, 'c', :d) from t", "coalesce", 4},
+		{"select 1 from t where if(a, b, c) = 1", "if", 3},
+		{"select 1 from t where coalesce(a, b) > 1", "coalesce", 2},
+	}
+	for _, tcase := range testcases {
+		tree, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("Parse(%s): %v", tcase.sql, err)
+			continue
+		}
+		sel := tree.(*Select)
+		var fn *Node
+		if sel.Where.Len() != 0 {
+			fn = sel.Where.NodeAt(0).NodeAt(0)
+		} else {
+			fn = sel.SelectExprs[0].(*NonStarExpr).Expr
+		}
+		if fn.Type != FUNCTION || string(fn.Value) != tcase.name {
+			t.Errorf("%s: %s, want function %s", tcase.sql, String(fn), tcase.name)
+			continue
+		}
+		if args := len(fn.At(0).(SelectExprs)); args != tcase.args {
+			t.Errorf("%s: %d arguments, want %d", tcase.sql, args, tcase.args)
+		}
+	}
+
+	// IF is still accepted in DDL.
+	for _, sql := range []string{"create table if not exists a (b int)", "drop table if exists a"} {
+		if _, err := Parse(sql); err != nil {
+			t.Errorf("Parse(%s): %v", sql, err)
+		}
+	}
+}
+
+func TestValuesFunc(t *testing.T) {
+	sql := "insert into t(a, cnt) values (1, 2), (3, 4) on duplicate key update cnt = cnt+values(cnt), a = values(t.a)"
+	tree, err := Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := String(tree); out != sql {
+		t.Errorf("formatted as %s", out)
+	}
+	list := tree.(*Insert).OnDup.NodeAt(0)
+	for i, want := range []string{"cnt", "t.a"} {
+		expr := list.NodeAt(i).NodeAt(1)
+		if expr.Type == '+' {
+			expr = expr.NodeAt(1)
+		}
+		if expr.Type != VALUES_FUNC || String(expr.NodeAt(0)) != want {
+			t.Errorf("assignment %d: %s, want values(%s)", i, String(expr), want)
+		}
+	}
+
+	// Other arguments still make VALUES a plain function.
+	tree, err = Parse("select values(a, b) from t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fn := tree.(*Select).SelectExprs[0].(*NonStarExpr).Expr; fn.Type != FUNCTION {
+		t.Errorf("%s: type %d, want FUNCTION", String(fn), fn.Type)
+	}
+}
+
+func TestTruncateFunction(t *testing.T) {
+	tree, err := Parse("select truncate(a, 2) from t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := tree.(*Select).SelectExprs[0].(*NonStarExpr).Expr
+	if fn.Type != FUNCTION || string(fn.Value) != "truncate" || len(fn.At(0).(SelectExprs)) != 2 {
+		t.Errorf("%s: want function truncate with 2 arguments", String(fn))
+	}
+	if tree, err = Parse("truncate table t"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tree.(*Truncate); !ok {
+		t.Errorf("truncate table t: %T, want *Truncate", tree)
+	}
+}
+
+func TestNonReservedKeywords(t *testing.T) {
+	// These keywords were plain identifiers before they were
+	// added to the grammar, and must still be accepted as names.
+	names := []string{
+		"begin", "commit", "rollback", "next", "at", "member", "of", "do",
+		"temporary", "modify", "change", "column", "any", "some", "reset",
+		"flush", "unlock", "rows", "range",
+	}
+	for _, name := range names {
+		for _, sql := range []string{
+			fmt.Sprintf("select %s from t", name),
+			fmt.Sprintf("select t.%s, a as %s from %s as t where %s = 1 order by %s", name, name, name, name, name),
+			fmt.Sprintf("select a from %s.%s", name, name),
+			fmt.Sprintf("insert into %s(%s) values (1)", name, name),
+			fmt.Sprintf("update %s set %s = 1", name, name),
+			fmt.Sprintf("delete from %s where %s = 1", name, name),
+		} {
+			tree, err := Parse(sql)
+			if err != nil {
+				t.Errorf("%s: %v", sql, err)
+				continue
+			}
+			out := String(tree)
+			if _, err := Parse(out); err != nil {
+				t.Errorf("%s: formatted as %s, which doesn't parse: %v", sql, out, err)
+			}
+		}
+	}
+
+	// The keywords keep their meaning where they're expected.
+	for _, sql := range []string{
+		"select next value for s",
+		"select a from t where b = any (select c from u)",
+		"select a member of ('[1]') from t",
+		"select sum(a) over (rows unbounded preceding) from t",
+		"begin",
+		"flush tables",
+	} {
+		if _, err := Parse(sql); err != nil {
+			t.Errorf("%s: %v", sql, err)
+		}
+	}
+}
+
 func TestRouting(t *testing.T) {
 	tabletkeys := []key.KeyspaceId{
 		"\x00\x00\x00\x00\x00\x00\x00\x02",
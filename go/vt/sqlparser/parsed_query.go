@@ -23,7 +23,21 @@ type ParsedQuery struct {
 
 type EncoderFunc func(value interface{}) ([]byte, error)
 
-func (pq *ParsedQuery) GenerateQuery(bindVariables map[string]interface{}, listVariables []sqltypes.Value) ([]byte, error) {
+// BindVarType is the intended type of a bind variable. It lets
+// GenerateQuery format a value that was supplied as a string as
+// a number, or the other way around.
+type BindVarType int
+
+const (
+	BINDVAR_INT BindVarType = iota
+	BINDVAR_FLOAT
+	BINDVAR_STRING
+)
+
+// GenerateQuery substitutes the bind variables of pq. The values of
+// the bind variables listed in bindVarTypes are formatted according
+// to their type, and the others according to their Go type.
+func (pq *ParsedQuery) GenerateQuery(bindVariables map[string]interface{}, listVariables []sqltypes.Value, bindVarTypes map[string]BindVarType) ([]byte, error) {
 	if len(pq.BindLocations) == 0 {
 		return []byte(pq.Query), nil
 	}
@@ -51,7 +65,11 @@ func (pq *ParsedQuery) GenerateQuery(bindVariables map[string]interface{}, listV
 				return nil, NewParserError("missing bind var %s", varName)
 			}
 		}
-		if err := EncodeValue(buf, supplied); err != nil {
+		if typ, ok := bindVarTypes[varName]; ok {
+			if err := EncodeTypedValue(buf, supplied, typ); err != nil {
+				return nil, NewParserError("bind var %s: %v", varName, err)
+			}
+		} else if err := EncodeValue(buf, supplied); err != nil {
 			return nil, err
 		}
 		current = loc.Offset + loc.Length
@@ -97,3 +115,72 @@ func EncodeValue(buf *bytes.Buffer, value interface{}) error {
 	}
 	return nil
 }
+
+// EncodeTypedValue encodes value like EncodeValue, except that
+// its type is typ instead of being deduced from its Go type.
+func EncodeTypedValue(buf *bytes.Buffer, value interface{}, typ BindVarType) error {
+	switch bindVal := value.(type) {
+	case []sqltypes.Value:
+		for i := 0; i < len(bindVal); i++ {
+			if i != 0 {
+				buf.WriteString(", ")
+			}
+			if err := EncodeTypedValue(buf, bindVal[i], typ); err != nil {
+				return err
+			}
+		}
+		return nil
+	case [][]sqltypes.Value:
+		for i := 0; i < len(bindVal); i++ {
+			if i != 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteByte('(')
+			if err := EncodeTypedValue(buf, bindVal[i], typ); err != nil {
+				return err
+			}
+			buf.WriteByte(')')
+		}
+		return nil
+	}
+	v, err := sqltypes.BuildValue(value)
+	if err != nil {
+		return err
+	}
+	if v.IsNull() {
+		buf.WriteString("null")
+		return nil
+	}
+	raw := v.Raw()
+	switch typ {
+	case BINDVAR_INT:
+		if _, err := strconv.ParseInt(string(raw), 10, 64); err != nil {
+			if _, err := strconv.ParseUint(string(raw), 10, 64); err != nil {
+				return NewParserError("%q is not an int", raw)
+			}
+		}
+		buf.Write(raw)
+	case BINDVAR_FLOAT:
+		if !isFloat(raw) {
+			return NewParserError("%q is not a float", raw)
+		}
+		buf.Write(raw)
+	case BINDVAR_STRING:
+		sqltypes.MakeString(raw).EncodeSql(buf)
+	default:
+		return NewParserError("unexpected bind var type %d", typ)
+	}
+	return nil
+}
+
+// isFloat returns true if b is a decimal number,
+// with an optional fraction and exponent.
+func isFloat(b []byte) bool {
+	for _, ch := range b {
+		if !(ch >= '0' && ch <= '9' || ch == '.' || ch == 'e' || ch == 'E' || ch == '+' || ch == '-') {
+			return false
+		}
+	}
+	_, err := strconv.ParseFloat(string(b), 64)
+	return err == nil
+}
@@ -118,7 +118,7 @@ func TestParsedQuery(t *testing.T) {
 		buf := NewTrackedBuffer(nil)
 		buf.Fprintf("%v", tree)
 		pq := buf.ParsedQuery()
-		bytes, err := pq.GenerateQuery(tcase.bindVars, tcase.listVars)
+		bytes, err := pq.GenerateQuery(tcase.bindVars, tcase.listVars, nil)
 		var got string
 		if err != nil {
 			got = err.Error()
@@ -139,7 +139,7 @@ func TestStarParam(t *testing.T) {
 		sqltypes.MakeNumeric([]byte("1")),
 		sqltypes.MakeString([]byte("aa")),
 	}
-	bytes, err := pq.GenerateQuery(nil, listvars)
+	bytes, err := pq.GenerateQuery(nil, listvars, nil)
 	if err != nil {
 		t.Errorf("generate failed: %v", err)
 		return
@@ -150,3 +150,82 @@ func TestStarParam(t *testing.T) {
 		t.Errorf("got %s, want %s", got, want)
 	}
 }
+
+func TestGenerateQueryTypes(t *testing.T) {
+	tcases := []struct {
+		desc     string
+		bindVars map[string]interface{}
+		types    map[string]BindVarType
+		output   string
+	}{
+		{
+			"no types",
+			map[string]interface{}{"a": 1, "b": "2", "c": 1.5},
+			nil,
+			"select * from t where a = 1 and b = '2' and c = 1.5",
+		}, {
+			"int",
+			map[string]interface{}{"a": "12", "b": -3, "c": []byte("18446744073709551615")},
+			map[string]BindVarType{"a": BINDVAR_INT, "b": BINDVAR_INT, "c": BINDVAR_INT},
+			"select * from t where a = 12 and b = -3 and c = 18446744073709551615",
+		}, {
+			"string",
+			map[string]interface{}{"a": 12, "b": "x'y", "c": 1.5},
+			map[string]BindVarType{"a": BINDVAR_STRING, "b": BINDVAR_STRING, "c": BINDVAR_STRING},
+			"select * from t where a = '12' and b = 'x\\'y' and c = '1.5'",
+		}, {
+			"float",
+			map[string]interface{}{"a": "1.25", "b": 3, "c": "-2e10"},
+			map[string]BindVarType{"a": BINDVAR_FLOAT, "b": BINDVAR_FLOAT, "c": BINDVAR_FLOAT},
+			"select * from t where a = 1.25 and b = 3 and c = -2e10",
+		}, {
+			"null",
+			map[string]interface{}{"a": nil, "b": nil, "c": nil},
+			map[string]BindVarType{"a": BINDVAR_INT, "b": BINDVAR_STRING},
+			"select * from t where a = null and b = null and c = null",
+		}, {
+			"bad int",
+			map[string]interface{}{"a": "1 or 1 = 1", "b": 1, "c": 1},
+			map[string]BindVarType{"a": BINDVAR_INT},
+			`bind var a: "1 or 1 = 1" is not an int`,
+		}, {
+			"bad float",
+			map[string]interface{}{"a": 1, "b": 1, "c": "NaN"},
+			map[string]BindVarType{"c": BINDVAR_FLOAT},
+			`bind var c: "NaN" is not a float`,
+		},
+	}
+	for _, tcase := range tcases {
+		tree, err := Parse("select * from t where a = :a and b = :b and c = :c")
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := NewTrackedBuffer(nil)
+		buf.Fprintf("%v", tree)
+		bytes, err := buf.ParsedQuery().GenerateQuery(tcase.bindVars, nil, tcase.types)
+		var got string
+		if err != nil {
+			got = err.Error()
+		} else {
+			got = string(bytes)
+		}
+		if got != tcase.output {
+			t.Errorf("for test case: %s, got: '%s', want '%s'", tcase.desc, got, tcase.output)
+		}
+	}
+
+	// Types apply to the values of lists too.
+	buf := NewTrackedBuffer(nil)
+	buf.Fprintf("select * from t where a in (%a)", "vals")
+	bytes, err := buf.ParsedQuery().GenerateQuery(
+		map[string]interface{}{"vals": []sqltypes.Value{sqltypes.MakeString([]byte("1")), sqltypes.MakeString([]byte("2"))}},
+		nil,
+		map[string]BindVarType{"vals": BINDVAR_INT},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "select * from t where a in (1, 2)"; string(bytes) != want {
+		t.Errorf("list: %s, want %s", bytes, want)
+	}
+}
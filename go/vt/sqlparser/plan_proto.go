@@ -0,0 +1,361 @@
+// Copyright 2013, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Field numbers and wire types of the messages
+// defined in sqlparser.proto.
+const (
+	protoFieldTree = 1
+
+	protoFieldType  = 1
+	protoFieldSet   = 2
+	protoFieldElems = 3
+	protoFieldBytes = 4
+	protoFieldInt   = 5
+	protoFieldUint  = 6
+	protoFieldBool  = 7
+
+	protoWireVarint  = 0
+	protoWireFixed64 = 1
+	protoWireBytes   = 2
+	protoWireFixed32 = 5
+)
+
+// protoTypes maps the names used in the type field of a Value
+// message to the types that can be stored in the interfaces
+// of the parse tree.
+var protoTypes = make(map[string]reflect.Type)
+
+func init() {
+	for _, node := range []SQLNode{
+		&Node{}, &Select{}, &Lock{}, &SelectInto{}, &FieldsOptions{},
+		&LinesOptions{}, &Union{}, &UnionArm{}, &ParenSelect{}, &With{},
+		&CommonTableExpr{}, &Insert{}, &Replace{}, &RowAlias{},
+		&NextValueFor{}, &Update{}, &Delete{}, &Set{}, &TransactionChars{},
+		SetExprs{}, &SetExpr{}, &DDLSimple{}, &DBDDL{}, &Truncate{},
+		&AlterCharset{}, &AddColumn{}, &ChangeColumn{}, &ModifyColumn{},
+		&AddIndex{}, &DropColumn{}, &DropIndex{}, &RenameTable{},
+		&AlterRaw{}, &IndexDefinition{}, &IndexColumn{}, &TableSpec{},
+		&ConstraintDefinition{}, &ForeignKeyDefinition{}, &OptLike{},
+		&ViewSpec{}, &TableOption{}, &ColumnDefinition{},
+		ColumnType{}, &Rename{}, &Explain{}, &Describe{},
+		&ExplainForConnection{}, &Do{}, &Reset{}, &OtherAdmin{}, &Flush{},
+		&Load{}, &Grant{}, &UserSpec{}, &LockTables{}, &TableLock{},
+		&UnlockTables{}, &Begin{}, &Commit{}, &Rollback{}, &Use{}, &Show{},
+		&ShowVitessKeyspaces{}, &ShowVitessShards{}, Comments{}, Comment{},
+		Distinct(false), SelectExprs{}, &StarExpr{}, &NonStarExpr{}, Columns{},
+		TableExprs{}, &AliasedTableExpr{}, Partitions{}, IndexHints{},
+		&IndexHint{}, &ParenTableExpr{}, &JoinTableExpr{}, &OverClause{},
+		NamedWindows{}, &NamedWindow{}, &FrameClause{}, &FramePoint{},
+	} {
+		t := reflect.TypeOf(node)
+		protoTypes[protoTypeName(t)] = t
+	}
+}
+
+// protoTypeName returns the name of t without
+// the package, like "*Select".
+func protoTypeName(t reflect.Type) string {
+	return strings.Replace(t.String(), "sqlparser.", "", 1)
+}
+
+// MarshalPlanProto encodes stmt as a Statement protobuf message.
+// Every value of the tree is encoded, including the ones that
+// aren't part of the SQL, like the schema version of DDLs.
+func MarshalPlanProto(stmt Statement) ([]byte, error) {
+	if stmt == nil {
+		return nil, fmt.Errorf("cannot marshal a nil statement")
+	}
+	tree, err := marshalValue(reflect.ValueOf(&stmt).Elem())
+	if err != nil {
+		return nil, err
+	}
+	return appendProtoBytes(nil, protoFieldTree, tree), nil
+}
+
+func marshalValue(v reflect.Value) ([]byte, error) {
+	var b []byte
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		elem := v.Elem()
+		name := protoTypeName(elem.Type())
+		if protoTypes[name] != elem.Type() {
+			return nil, fmt.Errorf("cannot marshal a value of type %s", elem.Type())
+		}
+		value, err := marshalValue(elem)
+		if err != nil {
+			return nil, err
+		}
+		b = appendProtoBytes(b, protoFieldType, []byte(name))
+		return append(b, value...), nil
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil, nil
+		}
+		value, err := marshalValue(v.Elem())
+		if err != nil {
+			return nil, err
+		}
+		b = appendProtoVarint(b, protoFieldSet, 1)
+		return append(b, value...), nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		b = appendProtoVarint(b, protoFieldSet, 1)
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return appendProtoBytes(b, protoFieldBytes, v.Bytes()), nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			elem, err := marshalValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			b = appendProtoBytes(b, protoFieldElems, elem)
+		}
+		return b, nil
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				return nil, fmt.Errorf("cannot marshal the unexported field %s of %s", v.Type().Field(i).Name, v.Type())
+			}
+			field, err := marshalValue(v.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			b = appendProtoBytes(b, protoFieldElems, field)
+		}
+		return b, nil
+	case reflect.String:
+		if v.Len() != 0 {
+			b = appendProtoBytes(b, protoFieldBytes, []byte(v.String()))
+		}
+		return b, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() != 0 {
+			b = appendProtoVarint(b, protoFieldInt, zigzag(v.Int()))
+		}
+		return b, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() != 0 {
+			b = appendProtoVarint(b, protoFieldUint, v.Uint())
+		}
+		return b, nil
+	case reflect.Bool:
+		if v.Bool() {
+			b = appendProtoVarint(b, protoFieldBool, 1)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("cannot marshal a value of type %s", v.Type())
+}
+
+// UnmarshalPlanProto decodes a Statement protobuf message produced
+// by MarshalPlanProto back into a parse tree. Unknown fields are
+// skipped.
+func UnmarshalPlanProto(b []byte) (Statement, error) {
+	var tree []byte
+	err := readProtoFields(b, func(field, wire, value uint64, data []byte) {
+		if field == protoFieldTree && wire == protoWireBytes {
+			tree = data
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tree == nil {
+		return nil, fmt.Errorf("invalid plan proto: missing tree")
+	}
+	var stmt Statement
+	if err := unmarshalValue(tree, reflect.ValueOf(&stmt).Elem()); err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return nil, fmt.Errorf("invalid plan proto: missing tree")
+	}
+	return stmt, nil
+}
+
+// protoValue holds the fields of a Value message.
+type protoValue struct {
+	typ       string
+	set       bool
+	elems     [][]byte
+	bytes     []byte
+	intValue  int64
+	uintValue uint64
+	boolValue bool
+}
+
+func unmarshalValue(b []byte, v reflect.Value) error {
+	pv := &protoValue{}
+	err := readProtoFields(b, func(field, wire, value uint64, data []byte) {
+		switch {
+		case field == protoFieldType && wire == protoWireBytes:
+			pv.typ = string(data)
+		case field == protoFieldSet && wire == protoWireVarint:
+			pv.set = value != 0
+		case field == protoFieldElems && wire == protoWireBytes:
+			pv.elems = append(pv.elems, data)
+		case field == protoFieldBytes && wire == protoWireBytes:
+			pv.bytes = data
+		case field == protoFieldInt && wire == protoWireVarint:
+			pv.intValue = unzigzag(value)
+		case field == protoFieldUint && wire == protoWireVarint:
+			pv.uintValue = value
+		case field == protoFieldBool && wire == protoWireVarint:
+			pv.boolValue = value != 0
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return pv.decode(v)
+}
+
+// decode stores pv in v, which must be settable.
+func (pv *protoValue) decode(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Interface:
+		if pv.typ == "" {
+			return nil
+		}
+		t, ok := protoTypes[pv.typ]
+		if !ok || !t.Implements(v.Type()) {
+			return fmt.Errorf("invalid plan proto: unexpected type %s for %s", pv.typ, v.Type())
+		}
+		elem := reflect.New(t).Elem()
+		if err := pv.decode(elem); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Ptr:
+		if !pv.set {
+			return nil
+		}
+		elem := reflect.New(v.Type().Elem())
+		if err := pv.decode(elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Slice:
+		if !pv.set {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes(append([]byte{}, pv.bytes...))
+			return nil
+		}
+		slice := reflect.MakeSlice(v.Type(), len(pv.elems), len(pv.elems))
+		for i, elem := range pv.elems {
+			if err := unmarshalValue(elem, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.Struct:
+		if len(pv.elems) != v.NumField() {
+			return fmt.Errorf("invalid plan proto: %d fields for %s, want %d", len(pv.elems), v.Type(), v.NumField())
+		}
+		for i, elem := range pv.elems {
+			if err := unmarshalValue(elem, v.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		v.SetString(string(pv.bytes))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(pv.intValue)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(pv.uintValue)
+	case reflect.Bool:
+		v.SetBool(pv.boolValue)
+	default:
+		return fmt.Errorf("invalid plan proto: cannot unmarshal a value of type %s", v.Type())
+	}
+	return nil
+}
+
+// readProtoFields calls fn with every field of the message b. value
+// is the value of varint fields, and data the contents of the
+// length-delimited ones.
+func readProtoFields(b []byte, fn func(field, wire, value uint64, data []byte)) error {
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return fmt.Errorf("invalid plan proto: bad field key")
+		}
+		b = b[n:]
+		field, wire := key>>3, key&7
+		var value uint64
+		var data []byte
+		switch wire {
+		case protoWireVarint:
+			value, n = binary.Uvarint(b)
+			if n <= 0 {
+				return fmt.Errorf("invalid plan proto: bad varint in field %d", field)
+			}
+			b = b[n:]
+		case protoWireFixed64, protoWireFixed32:
+			size := 8
+			if wire == protoWireFixed32 {
+				size = 4
+			}
+			if len(b) < size {
+				return fmt.Errorf("invalid plan proto: truncated field %d", field)
+			}
+			b = b[size:]
+		case protoWireBytes:
+			length, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < length {
+				return fmt.Errorf("invalid plan proto: truncated field %d", field)
+			}
+			data = b[n : n+int(length)]
+			b = b[n+int(length):]
+		default:
+			return fmt.Errorf("invalid plan proto: unsupported wire type %d", wire)
+		}
+		fn(field, wire, value, data)
+	}
+	return nil
+}
+
+func appendProtoVarint(b []byte, field int, v uint64) []byte {
+	b = appendUvarint(b, uint64(field<<3|protoWireVarint))
+	return appendUvarint(b, v)
+}
+
+func appendProtoBytes(b []byte, field int, data []byte) []byte {
+	b = appendUvarint(b, uint64(field<<3|protoWireBytes))
+	b = appendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+// zigzag maps signed integers to unsigned ones like
+// the sint64 protobuf type, so that small negative
+// numbers have short encodings.
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+func unzigzag(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
@@ -0,0 +1,91 @@
+// Copyright 2013, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanProto(t *testing.T) {
+	for tcase := range iterateFiles("sqlparser_test/parse_pass.sql") {
+		tree, err := Parse(tcase.input)
+		if err != nil {
+			t.Errorf("Parse(%s): %v", tcase.input, err)
+			continue
+		}
+		b, err := MarshalPlanProto(tree)
+		if err != nil {
+			t.Errorf("MarshalPlanProto(%s): %v", tcase.input, err)
+			continue
+		}
+		out, err := UnmarshalPlanProto(b)
+		if err != nil {
+			t.Errorf("UnmarshalPlanProto(%s): %v", tcase.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(out, tree) {
+			t.Errorf("round trip of %s: %s, want %s", tcase.input, String(out), String(tree))
+		}
+	}
+}
+
+func TestPlanProtoSchemaVersion(t *testing.T) {
+	tree, err := Parse("alter table a add column b int")
+	if err != nil {
+		t.Fatal(err)
+	}
+	StampVersion(tree, 300)
+	b, err := MarshalPlanProto(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := UnmarshalPlanProto(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ver := SchemaVersion(out); ver != 300 {
+		t.Errorf("SchemaVersion: %d, want 300", ver)
+	}
+
+	// Unknown fields are skipped.
+	b = append([]byte{3<<3 | protoWireFixed32, 1, 2, 3, 4}, b...)
+	if _, err := UnmarshalPlanProto(b); err != nil {
+		t.Errorf("UnmarshalPlanProto with unknown field: %v", err)
+	}
+}
+
+func TestPlanProtoErrors(t *testing.T) {
+	testcases := []struct {
+		in  []byte
+		err string
+	}{
+		{nil, "invalid plan proto: missing tree"},
+		{[]byte{protoFieldTree<<3 | protoWireBytes, 0}, "invalid plan proto: missing tree"},
+		{[]byte{protoFieldTree<<3 | protoWireBytes, 10, 's'}, "invalid plan proto: truncated field 1"},
+		{[]byte{protoFieldTree<<3 | 3}, "invalid plan proto: unsupported wire type 3"},
+		{
+			appendProtoBytes(nil, protoFieldTree, appendProtoBytes(nil, protoFieldType, []byte("*Foo"))),
+			"invalid plan proto: unexpected type *Foo for sqlparser.Statement",
+		},
+		{
+			appendProtoBytes(nil, protoFieldTree, appendProtoBytes(nil, protoFieldType, []byte("*StarExpr"))),
+			"invalid plan proto: unexpected type *StarExpr for sqlparser.Statement",
+		},
+		{
+			appendProtoBytes(nil, protoFieldTree, appendProtoVarint(appendProtoBytes(nil, protoFieldType, []byte("*Use")), protoFieldSet, 1)),
+			"invalid plan proto: 0 fields for sqlparser.Use, want 1",
+		},
+	}
+	for _, tcase := range testcases {
+		_, err := UnmarshalPlanProto(tcase.in)
+		if err == nil || err.Error() != tcase.err {
+			t.Errorf("UnmarshalPlanProto(%v): %v, want %s", tcase.in, err, tcase.err)
+		}
+	}
+	if _, err := MarshalPlanProto(nil); err == nil {
+		t.Errorf("MarshalPlanProto(nil): nil, want error")
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2013, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"bytes"
+)
+
+// Query types returned by QueryType.
+const (
+	QUERY_UNKNOWN = iota
+	QUERY_SELECT
+	QUERY_INSERT
+	QUERY_UPDATE
+	QUERY_DELETE
+	QUERY_SET
+	QUERY_DDL
+	QUERY_EXPLAIN
+	QUERY_DO
+	QUERY_RESET
+	QUERY_LOCK
+	QUERY_UNLOCK
+	QUERY_SHOW
+	QUERY_REPLACE
+	QUERY_BEGIN
+	QUERY_COMMIT
+	QUERY_ROLLBACK
+	QUERY_USE
+	QUERY_ADMIN
+	QUERY_LOAD
+)
+
+var queryTypeName = []string{
+	"unknown",
+	"select",
+	"insert",
+	"update",
+	"delete",
+	"set",
+	"ddl",
+	"explain",
+	"do",
+	"reset",
+	"lock",
+	"unlock",
+	"show",
+	"replace",
+	"begin",
+	"commit",
+	"rollback",
+	"use",
+	"admin",
+	"load",
+}
+
+// QueryTypeName returns the name of a query type
+// returned by QueryType.
+func QueryTypeName(queryType int) string {
+	return queryTypeName[queryType]
+}
+
+// firstTokenQueryType maps the first token of a
+// statement to its query type.
+var firstTokenQueryType = map[int]int{
+	SELECT:   QUERY_SELECT,
+	'(':      QUERY_SELECT,
+	WITH:     QUERY_SELECT,
+	INSERT:   QUERY_INSERT,
+	UPDATE:   QUERY_UPDATE,
+	DELETE:   QUERY_DELETE,
+	SET:      QUERY_SET,
+	CREATE:   QUERY_DDL,
+	ALTER:    QUERY_DDL,
+	DROP:     QUERY_DDL,
+	RENAME:   QUERY_DDL,
+	TRUNCATE: QUERY_DDL,
+	EXPLAIN:  QUERY_EXPLAIN,
+	DO:       QUERY_DO,
+	RESET:    QUERY_RESET,
+	LOCK:     QUERY_LOCK,
+	UNLOCK:   QUERY_UNLOCK,
+	SHOW:     QUERY_SHOW,
+	REPLACE:  QUERY_REPLACE,
+	DESCRIBE: QUERY_EXPLAIN,
+	DESC:     QUERY_EXPLAIN,
+	BEGIN:    QUERY_BEGIN,
+	COMMIT:   QUERY_COMMIT,
+	ROLLBACK: QUERY_ROLLBACK,
+	USE:      QUERY_USE,
+	ANALYZE:  QUERY_ADMIN,
+	OPTIMIZE: QUERY_ADMIN,
+	REPAIR:   QUERY_ADMIN,
+	FLUSH:    QUERY_ADMIN,
+	LOAD:     QUERY_LOAD,
+	GRANT:    QUERY_ADMIN,
+	REVOKE:   QUERY_ADMIN,
+}
+
+// QueryType classifies sql by its first token, without parsing
+// it. multi is true if sql contains more than one statement, that
+// is if a ';' is followed by anything other than comments or other
+// ';'. START TRANSACTION is QUERY_BEGIN. Statements that don't start
+// with a known keyword, and input that can't be tokenized, are
+// QUERY_UNKNOWN.
+func QueryType(sql string) (queryType int, multi bool) {
+	tokenizer := NewStringTokenizer(sql)
+	first := true
+	ended := false
+	for {
+		token := tokenizer.Scan()
+		switch token.Type {
+		case 0:
+			return queryType, false
+		case LEX_ERROR:
+			return QUERY_UNKNOWN, false
+		case COMMENT:
+			continue
+		case ';':
+			ended = true
+			continue
+		}
+		if ended {
+			return queryType, true
+		}
+		if first {
+			queryType = firstTokenQueryType[token.Type]
+			if token.Type == ID && bytes.EqualFold(token.Value, START) {
+				queryType = QUERY_BEGIN
+			}
+			first = false
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2013, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import "testing"
+
+func TestQueryType(t *testing.T) {
+	testcases := []struct {
+		sql       string
+		queryType string
+		multi     bool
+	}{
+		{"select 1 from t", "select", false},
+		{"select 1 from t;", "select", false},
+		{"select 1 from t ; ;", "select", false},
+		{"select 1 from t; /* done */", "select", false},
+		{"select 1 from t; select 2 from t", "select", true},
+		{"select 1 from t;delete from t", "select", true},
+		{"select 'a;b' from t where c = \"d;e\"", "select", false},
+		{"select 1 from t /* ; drop table t */", "select", false},
+		{"/* leading */ insert into t values (1)", "insert", false},
+		{"(select 1 from t) union (select 2 from u)", "select", false},
+		{"with c as (select 1 from t) select * from c", "select", false},
+		{"update t set a = 1; drop table t", "update", true},
+		{"delete from t", "delete", false},
+		{"set autocommit = 1", "set", false},
+		{"alter table t add column a int", "ddl", false},
+		{"explain select 1 from t", "explain", false},
+		{"lock tables t read; unlock tables", "lock", true},
+		{"unlock tables", "unlock", false},
+		{"show vitess_keyspaces", "show", false},
+		{"replace into t values (1)", "replace", false},
+		{"truncate t", "ddl", false},
+		{"desc t", "explain", false},
+		{"begin", "begin", false},
+		{"/* c */ START TRANSACTION READ ONLY", "begin", false},
+		{"commit work", "commit", false},
+		{"rollback; begin", "rollback", true},
+		{"started", "unknown", false},
+		{"use db", "use", false},
+		{"analyze table t", "admin", false},
+		{"/* c */ optimize local table t, u", "admin", false},
+		{"repair table t", "admin", false},
+		{"flush tables with read lock", "admin", false},
+		{"load data infile 'x' into table t", "load", false},
+		{"grant select on d.* to 'app'@'%'", "admin", false},
+		{"revoke all on *.* from app", "admin", false},
+		{"", "unknown", false},
+		{"select 'unterminated", "unknown", false},
+	}
+	for _, tcase := range testcases {
+		queryType, multi := QueryType(tcase.sql)
+		if name := QueryTypeName(queryType); name != tcase.queryType || multi != tcase.multi {
+			t.Errorf("QueryType(%s): %s, %v, want %s, %v", tcase.sql, name, multi, tcase.queryType, tcase.multi)
+		}
+	}
+}
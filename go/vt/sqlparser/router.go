@@ -13,6 +13,8 @@ import (
 const (
 	ROUTE_BY_CONDITION = iota
 	ROUTE_BY_VALUE
+	ROUTE_TO_SINGLE_SHARD
+	ROUTE_TO_ALL_SHARDS
 )
 
 const (
@@ -48,6 +50,14 @@ func shardListFromPlan(plan *RoutingPlan, bindVariables map[string]interface{},
 		return []int{index}
 	}
 
+	if plan.routingType == ROUTE_TO_SINGLE_SHARD {
+		return []int{0}
+	}
+
+	if plan.routingType == ROUTE_TO_ALL_SHARDS {
+		return makeList(0, len(tabletKeys))
+	}
+
 	if plan.criteria == nil {
 		return makeList(0, len(tabletKeys))
 	}
@@ -77,12 +87,26 @@ func shardListFromPlan(plan *RoutingPlan, bindVariables map[string]interface{},
 
 func getRoutingPlan(statement Statement) (plan *RoutingPlan) {
 	plan = &RoutingPlan{}
-	if ins, ok := statement.(*Insert); ok {
-		if sel, ok := ins.Values.(SelectStatement); ok {
-			return getRoutingPlan(sel)
-		}
-		plan.routingType = ROUTE_BY_VALUE
-		plan.criteria = ins.Values.(*Node).NodeAt(0).routingAnalyzeValues()
+	switch stmt := statement.(type) {
+	case *Do:
+		// DO statements have no result set and are typically
+		// used for side effects. One shard is enough.
+		plan.routingType = ROUTE_TO_SINGLE_SHARD
+		return plan
+	case *DBDDL:
+		// Databases can't be created or dropped shard by shard.
+		panic(NewParserError("database ddl can't be routed"))
+	case *Insert:
+		return getValuesRoutingPlan(stmt.Values)
+	case *Replace:
+		return getValuesRoutingPlan(stmt.Values)
+	case *Reset:
+		// Every shard has its binary logs, query cache and
+		// replication state. The router only picks shards:
+		// the tablets of a shard that run it, the master or
+		// the replicas depending on the target, are chosen
+		// by the caller.
+		plan.routingType = ROUTE_TO_ALL_SHARDS
 		return plan
 	}
 	var where *Node
@@ -101,6 +125,19 @@ func getRoutingPlan(statement Statement) (plan *RoutingPlan) {
 	return plan
 }
 
+// getValuesRoutingPlan returns the routing plan of an INSERT or
+// REPLACE, which routes by the inserted values or by the select
+// providing them.
+func getValuesRoutingPlan(values SQLNode) *RoutingPlan {
+	if sel, ok := values.(SelectStatement); ok {
+		return getRoutingPlan(sel)
+	}
+	return &RoutingPlan{
+		routingType: ROUTE_BY_VALUE,
+		criteria:    values.(*Node).NodeAt(0).routingAnalyzeValues(),
+	}
+}
+
 func (node *Node) routingAnalyzeValues() *Node {
 	// Analyze first value of every item in the list
 	for i := 0; i < node.Len(); i++ {
@@ -1,10 +1,16 @@
+// Code generated by goyacc -o sql.go sql.y. DO NOT EDIT.
+
 //line sql.y:6
 package sqlparser
 
 import __yyfmt__ "fmt"
 
 //line sql.y:6
-import "bytes"
+
+import (
+	"bytes"
+	"strings"
+)
 
 func SetParseTree(yylex interface{}, stmt Statement) {
 	tn := yylex.(*Tokenizer)
@@ -21,128 +27,862 @@ func ForceEOF(yylex interface{}) {
 	tn.ForceEOF = true
 }
 
+// setColumnAttribute applies the column attribute name, with its
+// optional value, to columnType. It returns false if the attribute
+// is not recognized.
+func setColumnAttribute(columnType *ColumnType, name []byte, value *Node) bool {
+	switch string(name) {
+	case "unsigned":
+		columnType.Unsigned = value == nil
+	case "zerofill":
+		columnType.Zerofill = value == nil
+	case "auto_increment":
+		columnType.Autoincrement = value == nil
+	case "visible", "invisible":
+		columnType.Visibility = name
+		return value == nil
+	case "comment":
+		columnType.Comment = value
+		return value != nil && value.Type == STRING
+	case "srid":
+		columnType.SRID = value
+		return value != nil && value.Type == NUMBER
+	case "virtual", "stored":
+		columnType.Storage = name
+		return value == nil && columnType.Generated != nil
+	default:
+		return false
+	}
+	return value == nil
+}
+
+// updateListToValues converts the assignments of an INSERT ... SET
+// into the equivalent column list and single row VALUES clause.
+func updateListToValues(updateList *Node) (Columns, *Node) {
+	columns := make(Columns, updateList.Len())
+	row := NewSimpleParseNode(NODE_LIST, "node_list")
+	for i := 0; i < updateList.Len(); i++ {
+		columns[i] = &NonStarExpr{Expr: updateList.NodeAt(i).NodeAt(0)}
+		row.Push(updateList.NodeAt(i).NodeAt(1))
+	}
+	rows := NewSimpleParseNode(NODE_LIST, "node_list")
+	rows.Push(NewSimpleParseNode('(', "(").Push(row))
+	return columns, NewSimpleParseNode(VALUES, "values").Push(rows)
+}
+
+// valuesColumn returns the column of args if name and args
+// are a VALUES(col) reference to the value that an INSERT
+// would have inserted, like in ON DUPLICATE KEY UPDATE.
+func valuesColumn(name *Node, args SelectExprs) (*Node, bool) {
+	if name.Type != VALUES || len(args) != 1 {
+		return nil, false
+	}
+	expr, ok := args[0].(*NonStarExpr)
+	if !ok || expr.As != nil {
+		return nil, false
+	}
+	switch expr.Expr.Type {
+	case ID, '.':
+		return expr.Expr, true
+	}
+	return nil, false
+}
+
+// isBooleanValue returns true if node is TRUE
+// or FALSE, possibly parenthesized.
+func isBooleanValue(node *Node) bool {
+	for node.Type == '(' {
+		inner, ok := node.At(0).(*Node)
+		if !ok {
+			return false
+		}
+		node = inner
+	}
+	return node.Type == TRUE || node.Type == FALSE
+}
+
+// isSubquery returns true if node is a parenthesized select.
+func isSubquery(node *Node) bool {
+	if node.Type != '(' {
+		return false
+	}
+	_, ok := node.At(0).(SelectStatement)
+	return ok
+}
+
+// setShowFilter sets the LIKE or WHERE filter of show. It
+// returns false if the filter isn't allowed, which is the case
+// of WHERE with the vitess objects.
+func setShowFilter(show *Show, filter *Node) bool {
+	switch {
+	case filter == nil:
+	case filter.Type == WHERE && show.VitessObject != nil:
+		return false
+	case filter.Type == WHERE:
+		show.Where = filter.NodeAt(0)
+	default:
+		switch object := show.VitessObject.(type) {
+		case *ShowVitessKeyspaces:
+			object.Like = filter.NodeAt(0)
+		case *ShowVitessShards:
+			object.Like = filter.NodeAt(0)
+		default:
+			show.Like = filter.NodeAt(0)
+		}
+	}
+	return true
+}
+
+// setDBOptions sets the character set and collation of ddl
+// from options. It returns false if one of the options isn't
+// allowed for a database.
+func setDBOptions(ddl *DBDDL, options []*TableOption) bool {
+	for _, option := range options {
+		switch {
+		case bytes.Equal(option.Name, CHARSET):
+			ddl.Charset = string(option.Value.Value)
+		case bytes.Equal(option.Name, COLLATE_OPTION):
+			ddl.Collate = string(option.Value.Value)
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// newFramePoint returns the window frame bound made of expr
+// followed by word. expr is the unbounded or current keyword,
+// or the offset of the bound. It returns false if the bound
+// isn't valid.
+func newFramePoint(expr *Node, word []byte) (*FramePoint, bool) {
+	switch {
+	case expr.Type == ID && bytes.Equal(expr.Value, UNBOUNDED):
+		if bytes.Equal(word, PRECEDING) || bytes.Equal(word, FOLLOWING) {
+			return &FramePoint{Type: []byte("unbounded " + string(word))}, true
+		}
+	case expr.Type == ID && bytes.Equal(expr.Value, CURRENT):
+		if bytes.Equal(word, ROW) {
+			return &FramePoint{Type: []byte("current row")}, true
+		}
+	case bytes.Equal(word, PRECEDING), bytes.Equal(word, FOLLOWING):
+		return &FramePoint{Type: word, Expr: expr}, true
+	}
+	return nil, false
+}
+
+// newUnion returns the union of left and right. If left is
+// itself a union that isn't closed by trailing clauses, right
+// is appended to its arms, so that chains of unions stay flat.
+// Since INTERSECT binds tighter than the other set operations,
+// an intersect arm that follows one of them is instead grouped
+// with the select of the last arm.
+func newUnion(left SelectStatement, typ int, right SelectStatement) *Union {
+	union, ok := left.(*Union)
+	if !ok || union.OrderBy != nil {
+		return &Union{First: left, Arms: []*UnionArm{{Type: typ, Select: right}}}
+	}
+	if last := union.Arms[len(union.Arms)-1]; IsIntersect(typ) && !IsIntersect(last.Type) {
+		last.Select = newUnion(last.Select, typ, right)
+		return union
+	}
+	union.Arms = append(union.Arms, &UnionArm{Type: typ, Select: right})
+	return union
+}
+
+// setUnionTail sets the clauses that follow the parenthesized
+// last select of union, unless they're all empty.
+func setUnionTail(union *Union, orderBy, limit *Node, lock *Lock) {
+	if orderBy.Len() == 0 && limit.Len() == 0 && lock.Type == NO_LOCK {
+		return
+	}
+	union.OrderBy, union.Limit, union.Lock = orderBy, limit, lock
+}
+
+// selectOptions splits the keywords between SELECT and the
+// select expressions into the DISTINCT flag and the other
+// select options, which keep their order.
+func selectOptions(nodes []*Node) (distinct Distinct, options []string) {
+	for _, node := range nodes {
+		if node.Type == DISTINCT {
+			distinct = true
+			continue
+		}
+		options = append(options, string(node.Value))
+	}
+	return distinct, options
+}
+
+// setScope returns the scope named by the SET scope keyword
+// or @@scope prefix name, or nil if name is not a scope.
+func setScope(name []byte) []byte {
+	switch {
+	case bytes.EqualFold(name, GLOBAL):
+		return GLOBAL
+	case bytes.EqualFold(name, SESSION), bytes.EqualFold(name, LOCAL):
+		return SESSION
+	}
+	return nil
+}
+
+// resolveSetScopes sets the scope of the assignments of a SET
+// statement. A scope keyword applies to the system variables that
+// follow it up to the next scope keyword, and the @@ and @@scope.
+// prefixes are moved from the variable names into their scope.
+// Once a scope was set, the system variables without a scope
+// keyword get their session scope explicitly, so that the
+// formatted statement doesn't change their meaning.
+func resolveSetScopes(exprs SetExprs) {
+	var scope []byte
+	for _, expr := range exprs {
+		if expr.Scope != nil {
+			scope = expr.Scope
+			continue
+		}
+		name := expr.Name
+		switch {
+		case name.Type == '.' && name.NodeAt(0).Type == ID && bytes.HasPrefix(name.NodeAt(0).Value, []byte("@@")):
+			if s := setScope(name.NodeAt(0).Value[2:]); s != nil {
+				expr.Scope, expr.Name = s, name.NodeAt(1)
+			}
+		case name.Type == ID && bytes.HasPrefix(name.Value, []byte("@@")):
+			expr.Scope = SESSION
+			name.Value = name.Value[2:]
+		case name.Type == ID && bytes.HasPrefix(name.Value, []byte("@")):
+		default:
+			expr.Scope = scope
+		}
+		if expr.Scope != nil && scope == nil {
+			scope = SESSION
+		}
+	}
+}
+
+// newTransactionChars returns the TransactionChars of the words
+// of a SET TRANSACTION statement, which start with the optional
+// scope and TRANSACTION, and contain ',' nodes between the
+// characteristics. It returns an error message if they're invalid.
+func newTransactionChars(words []*Node) (*TransactionChars, string) {
+	chars := &TransactionChars{Scope: setScope(words[0].Value)}
+	if chars.Scope != nil {
+		words = words[1:]
+	}
+	if words[0].Type != ID || !bytes.EqualFold(words[0].Value, TRANSACTION) {
+		return nil, "expecting transaction"
+	}
+	if len(words) == 1 {
+		return nil, "expecting transaction characteristic"
+	}
+	var characteristic []byte
+	for i, word := range words[1:] {
+		if word.Type == ID {
+			if characteristic != nil {
+				characteristic = append(characteristic, ' ')
+			}
+			characteristic = append(characteristic, bytes.ToLower(word.Value)...)
+			if i != len(words)-2 {
+				continue
+			}
+		}
+		switch level := bytes.TrimPrefix(characteristic, []byte("isolation level ")); {
+		case len(level) < len(characteristic) && chars.IsolationLevel == nil:
+			for _, isolationLevel := range [][]byte{REPEATABLE_READ, READ_COMMITTED, READ_UNCOMMITTED, SERIALIZABLE} {
+				if bytes.Equal(level, isolationLevel) {
+					chars.IsolationLevel = isolationLevel
+				}
+			}
+			if chars.IsolationLevel == nil {
+				return nil, "unexpected isolation level"
+			}
+		case bytes.Equal(characteristic, READ_ONLY) && chars.AccessMode == nil:
+			chars.AccessMode = READ_ONLY
+		case bytes.Equal(characteristic, READ_WRITE) && chars.AccessMode == nil:
+			chars.AccessMode = READ_WRITE
+		default:
+			return nil, "unexpected transaction characteristic"
+		}
+		characteristic = nil
+	}
+	return chars, ""
+}
+
+// lockType returns the LOCK TABLES lock type named by words,
+// and the lowercased name. ok is false if it's not a lock type.
+func lockType(words ...*Node) (lock int, name string, ok bool) {
+	for i, word := range words {
+		if i != 0 {
+			name += " "
+		}
+		name += string(bytes.ToLower(word.Value))
+	}
+	for lock, lockName := range lockTypeName {
+		if name == lockName {
+			return lock, name, true
+		}
+	}
+	return 0, name, false
+}
+
+// adminModifier returns the lowercased modifier of an ANALYZE,
+// OPTIMIZE or REPAIR statement. ok is false if it's not one.
+func adminModifier(word *Node) (modifier []byte, ok bool) {
+	modifier = bytes.ToLower(word.Value)
+	if !bytes.Equal(modifier, LOCAL) && !bytes.Equal(modifier, NO_WRITE_TO_BINLOG) {
+		return nil, false
+	}
+	return modifier, true
+}
+
+// newFlush builds a FLUSH statement from its comma separated
+// options. A leading modifier is recognized, and the names
+// that follow TABLES are the flushed tables. The other options
+// are kept as the lowercased type. On error, it returns nil
+// and the error message.
+func newFlush(options [][]*Node) (*Flush, string) {
+	flush := &Flush{}
+	first := options[0]
+	if len(first) > 1 {
+		if modifier, ok := adminModifier(first[0]); ok {
+			flush.Modifier = modifier
+			first = first[1:]
+		}
+	}
+	if first[0].Type == TABLE || bytes.EqualFold(first[0].Value, TABLES) {
+		flush.Type = TABLES
+		if len(first) > 2 {
+			return nil, "unexpected " + string(first[2].Value)
+		}
+		if len(first) == 2 {
+			flush.Tables = append(flush.Tables, first[1])
+		}
+		for _, option := range options[1:] {
+			if flush.Tables == nil || len(option) != 1 {
+				return nil, "expecting table name"
+			}
+			flush.Tables = append(flush.Tables, option[0])
+		}
+		return flush, ""
+	}
+	var types [][]byte
+	for i, option := range options {
+		if i == 0 {
+			option = first
+		}
+		words := make([][]byte, len(option))
+		for j, word := range option {
+			if word.Type != ID {
+				return nil, "unexpected flush option " + String(word)
+			}
+			words[j] = bytes.ToLower(word.Value)
+		}
+		types = append(types, bytes.Join(words, []byte(" ")))
+	}
+	flush.Type = bytes.Join(types, []byte(", "))
+	return flush, ""
+}
+
+// newLoadOptions builds the FIELDS and LINES clauses of LOAD DATA
+// and SELECT ... INTO OUTFILE from their options. Every option is
+// a list of words followed by the value. FIELDS (or COLUMNS) and
+// LINES are the first word of the first option of their clause. On
+// error, it returns the error message.
+func newLoadOptions(options [][]*Node) (fields *FieldsOptions, lines *LinesOptions, msg string) {
+	var clause string
+	for _, option := range options {
+		words, value := option[:len(option)-1], option[len(option)-1]
+		switch strings.ToLower(string(words[0].Value)) {
+		case "fields", "columns":
+			if fields != nil || lines != nil {
+				return nil, nil, "unexpected " + string(words[0].Value)
+			}
+			clause, words, fields = "fields", words[1:], &FieldsOptions{}
+		case "lines":
+			if lines != nil {
+				return nil, nil, "unexpected lines"
+			}
+			clause, words, lines = "lines", words[1:], &LinesOptions{}
+		}
+		var name string
+		for i, word := range words {
+			if i != 0 {
+				name += " "
+			}
+			name += strings.ToLower(string(word.Value))
+		}
+		switch {
+		case clause == "fields" && name == "terminated" && fields.TerminatedBy == nil:
+			fields.TerminatedBy = value
+		case clause == "fields" && (name == "enclosed" || name == "optionally enclosed") && fields.EnclosedBy == nil:
+			fields.EnclosedBy, fields.OptionallyEnclosed = value, name == "optionally enclosed"
+		case clause == "fields" && name == "escaped" && fields.EscapedBy == nil:
+			fields.EscapedBy = value
+		case clause == "lines" && name == "starting" && lines.StartingBy == nil:
+			lines.StartingBy = value
+		case clause == "lines" && name == "terminated" && lines.TerminatedBy == nil:
+			lines.TerminatedBy = value
+		default:
+			return nil, nil, "unexpected option " + name + " by"
+		}
+	}
+	return fields, lines, ""
+}
+
+// rawTail returns the text of the query that starts at
+// the last token scanned. It's used after a syntax error
+// to keep the clauses that are not parsed.
+func rawTail(yylex interface{}) []byte {
+	tkn := yylex.(*Tokenizer)
+	if tkn.tokenStart >= len(tkn.sql) {
+		return nil
+	}
+	return bytes.TrimSpace([]byte(tkn.sql[tkn.tokenStart:]))
+}
+
+// markAlterOption records where the next ALTER TABLE operation
+// starts in the query. The reduction doesn't need a lookahead, so
+// the tokenizer is just after the previous token.
+func markAlterOption(yylex interface{}) {
+	tkn := yylex.(*Tokenizer)
+	tkn.alterMarks = append(tkn.alterMarks, tkn.position-1)
+	tkn.skippedDDL = false
+}
+
+// alterRawText sets the text of the AlterRaw operations, which
+// extends up to the comma that precedes the next operation, and
+// drops the empty ones.
+func alterRawText(yylex interface{}, options []AlterOption) []AlterOption {
+	tkn := yylex.(*Tokenizer)
+	var result []AlterOption
+	for i, option := range options {
+		if raw, ok := option.(*AlterRaw); ok {
+			end := len(tkn.sql)
+			if i+1 < len(tkn.alterMarks) {
+				end = tkn.alterMarks[i+1] - 1
+			}
+			if start := tkn.alterMarks[i]; start < end {
+				raw.Raw = bytes.TrimSpace([]byte(tkn.sql[start:end]))
+			}
+			if len(raw.Raw) == 0 {
+				continue
+			}
+		}
+		result = append(result, option)
+	}
+	return result
+}
+
+// markRawText records where the clauses that are kept as raw
+// text if they can't be parsed start in the query: the table
+// options of CREATE TABLE, or the columns of CREATE INDEX. The
+// reduction doesn't need a lookahead, so the tokenizer is just
+// after the previous token.
+func markRawText(yylex interface{}) {
+	tkn := yylex.(*Tokenizer)
+	tkn.rawStart = tkn.position - 1
+}
+
+// rawText returns the text of the query that starts at
+// the position recorded by markRawText, or nil if it's empty.
+func rawText(yylex interface{}) []byte {
+	tkn := yylex.(*Tokenizer)
+	if tkn.rawStart >= len(tkn.sql) {
+		return nil
+	}
+	raw := bytes.TrimSpace([]byte(tkn.sql[tkn.rawStart:]))
+	if len(raw) == 0 {
+		return nil
+	}
+	return raw
+}
+
+// createIndex returns CREATE INDEX as an alter of table that adds
+// index. Its options are USING, COMMENT, ALGORITHM and LOCK. The
+// columns and the options are kept as written if one of them is
+// something else.
+func createIndex(yylex interface{}, table *Node, index *IndexDefinition, options []AlterOption) *DDLSimple {
+	ddl := &DDLSimple{Action: ALTER, Table: table, AlterOptions: []AlterOption{&AddIndex{Index: index}}}
+	using := index.Using
+	for _, option := range options {
+		option := option.(*TableOption)
+		switch {
+		case bytes.Equal(option.Name, USING_OPTION):
+			index.Using = option.Value.Value
+		case bytes.Equal(option.Name, COMMENT_OPTION) && option.Value.Type == STRING:
+			index.Comment = option.Value
+		case isLockOption(option):
+			ddl.AlterOptions = append(ddl.AlterOptions, option)
+		default:
+			index.Columns, index.Using, index.Comment, index.Raw = nil, using, nil, rawText(yylex)
+			ddl.AlterOptions = ddl.AlterOptions[:1]
+			return ddl
+		}
+	}
+	return ddl
+}
+
+// isLockOption returns true if option is the ALGORITHM
+// or LOCK that can follow CREATE INDEX and DROP INDEX.
+func isLockOption(option AlterOption) bool {
+	name := option.(*TableOption).Name
+	return bytes.Equal(name, ALGORITHM) || bytes.Equal(name, LOCK_OPTION)
+}
+
+// viewOption is an option of CREATE VIEW. User is the value
+// of ALGORITHM and DEFINER, and Value the one of SQL SECURITY.
+type viewOption struct {
+	Name  []byte
+	User  *UserSpec
+	Value []byte
+}
+
+// setViewOption sets option in spec. It returns false
+// if the option or its value is not recognized.
+func setViewOption(spec *ViewSpec, option *viewOption) bool {
+	switch {
+	case bytes.Equal(option.Name, ALGORITHM):
+		if option.User.Host != nil || option.User.User.Type != ID {
+			return false
+		}
+		algorithm := bytes.ToLower(option.User.User.Value)
+		switch string(algorithm) {
+		case "undefined", "merge", "temptable":
+			spec.Algorithm = algorithm
+			return true
+		}
+	case bytes.Equal(option.Name, DEFINER):
+		spec.Definer = option.User
+		return true
+	case bytes.Equal(option.Name, SECURITY):
+		switch string(option.Value) {
+		case "definer", "invoker":
+			spec.Security = option.Value
+			return true
+		}
+	}
+	return false
+}
+
+// skipDDLClause records that a clause of an ALTER TABLE operation
+// isn't understood, so that the operation is kept as raw text: MySQL
+// accepts more clauses than the grammar parses. Outside of ALTER
+// TABLE, it reports message as an error and returns false.
+func skipDDLClause(yylex interface{}, message string) bool {
+	tkn := yylex.(*Tokenizer)
+	if len(tkn.alterMarks) == 0 {
+		tkn.Error(message)
+		return false
+	}
+	tkn.skippedDDL = true
+	return true
+}
+
+// takeSkippedDDL returns true if skipDDLClause was called
+// since the last call, and resets it.
+func takeSkippedDDL(yylex interface{}) bool {
+	tkn := yylex.(*Tokenizer)
+	skipped := tkn.skippedDDL
+	tkn.skippedDDL = false
+	return skipped
+}
+
 var (
-	LJOIN = []byte("left join")
-	RJOIN = []byte("right join")
-	CJOIN = []byte("cross join")
-	NJOIN = []byte("natural join")
-	SHARE = []byte("share")
-	MODE  = []byte("mode")
+	LJOIN              = []byte("left join")
+	RJOIN              = []byte("right join")
+	CJOIN              = []byte("cross join")
+	NJOIN              = []byte("natural join")
+	SJOIN              = []byte("straight_join")
+	OUTFILE            = []byte("outfile")
+	DUMPFILE           = []byte("dumpfile")
+	CHARACTER          = []byte("character")
+	CHARSET            = []byte("charset")
+	NULLS              = []byte("nulls")
+	FIRST              = []byte("first")
+	LAST               = []byte("last")
+	PRIMARY            = []byte("primary")
+	QUERY              = []byte("query")
+	CACHE              = []byte("cache")
+	ENUM               = []byte("enum")
+	TIME               = []byte("time")
+	ZONE               = []byte("zone")
+	TABLES             = []byte("tables")
+	CONNECTION         = []byte("connection")
+	VALUE              = []byte("value")
+	FORMAT             = []byte("format")
+	START              = []byte("start")
+	TRANSACTION        = []byte("transaction")
+	WORK               = []byte("work")
+	LOCAL              = []byte("local")
+	NAMES              = []byte("names")
+	SPATIAL            = []byte("spatial")
+	ALGORITHM          = []byte("algorithm")
+	DEFINER            = []byte("definer")
+	SQL                = []byte("sql")
+	SECURITY           = []byte("security")
+	RESTRICT           = []byte("restrict")
+	CASCADE            = []byte("cascade")
+	COMMENT_OPTION     = []byte("comment")
+	LOCK_OPTION        = []byte("lock")
+	USING_OPTION       = []byte("using")
+	COLLATE_OPTION     = []byte("collate")
+	NO_WRITE_TO_BINLOG = []byte("no_write_to_binlog")
+	READ               = []byte("read")
+	EXPORT             = []byte("export")
+	DATA               = []byte("data")
+	INFILE             = []byte("infile")
+	LINES              = []byte("lines")
+	COUNT              = []byte("count")
+	WARNINGS           = []byte("warnings")
+	ERRORS             = []byte("errors")
+	EXTENDED           = []byte("extended")
+	UNBOUNDED          = []byte("unbounded")
+	CURRENT            = []byte("current")
+	ROW                = []byte("row")
+	PRECEDING          = []byte("preceding")
+	FOLLOWING          = []byte("following")
 )
 
-//line sql.y:36
+//line sql.y:643
 type yySymType struct {
-	yys         int
-	node        *Node
-	statement   Statement
-	comments    Comments
-	str         []byte
-	distinct    Distinct
-	selectExprs SelectExprs
-	selectExpr  SelectExpr
-	columns     Columns
-	tableExprs  TableExprs
-	tableExpr   TableExpr
-	sqlNode     SQLNode
+	yys                  int
+	node                 *Node
+	statement            Statement
+	comments             Comments
+	str                  []byte
+	distinct             Distinct
+	selectExprs          SelectExprs
+	selectExpr           SelectExpr
+	columns              Columns
+	tableExprs           TableExprs
+	tableExpr            TableExpr
+	sqlNode              SQLNode
+	boolean              bool
+	nodes                []*Node
+	tableSpec            *TableSpec
+	columnDefinition     *ColumnDefinition
+	columnType           ColumnType
+	rowAlias             *RowAlias
+	selectInto           *SelectInto
+	alterOption          AlterOption
+	alterOptions         []AlterOption
+	indexDefinition      *IndexDefinition
+	tableOption          *TableOption
+	tableOptions         []*TableOption
+	indexColumn          *IndexColumn
+	indexColumns         []*IndexColumn
+	overClause           *OverClause
+	tableLock            *TableLock
+	tableLocks           []*TableLock
+	lockType             int
+	bytes                []byte
+	setExpr              *SetExpr
+	setExprs             SetExprs
+	viewSpec             *ViewSpec
+	viewOption           *viewOption
+	constraintDefinition *ConstraintDefinition
+	foreignKey           *ForeignKeyDefinition
+	ddl                  *DDLSimple
+	verb                 int
+	nodeLists            [][]*Node
+	load                 *Load
+	strs                 []string
+	text                 string
+	userSpec             *UserSpec
+	userSpecs            []*UserSpec
+	cte                  *CommonTableExpr
+	ctes                 []*CommonTableExpr
+	frameClause          *FrameClause
+	framePoint           *FramePoint
+	namedWindow          *NamedWindow
+	namedWindows         NamedWindows
+	parenSelect          *ParenSelect
+	setOp                int
+	lock                 *Lock
+	lockWait             int
+	partitions           Partitions
+	indexHint            *IndexHint
+	indexHints           IndexHints
+	hintType             int
+	hintFor              int
 }
 
 const SELECT = 57346
 const INSERT = 57347
-const UPDATE = 57348
-const DELETE = 57349
-const FROM = 57350
-const WHERE = 57351
-const GROUP = 57352
-const HAVING = 57353
-const ORDER = 57354
-const BY = 57355
-const LIMIT = 57356
-const COMMENT = 57357
-const FOR = 57358
-const ALL = 57359
-const DISTINCT = 57360
-const AS = 57361
-const EXISTS = 57362
-const IN = 57363
-const IS = 57364
-const LIKE = 57365
-const BETWEEN = 57366
-const NULL = 57367
-const ASC = 57368
-const DESC = 57369
-const VALUES = 57370
-const INTO = 57371
-const DUPLICATE = 57372
-const KEY = 57373
-const DEFAULT = 57374
-const SET = 57375
-const LOCK = 57376
-const ID = 57377
-const STRING = 57378
-const NUMBER = 57379
-const VALUE_ARG = 57380
-const LE = 57381
-const GE = 57382
-const NE = 57383
-const NULL_SAFE_EQUAL = 57384
-const LEX_ERROR = 57385
-const UNION = 57386
-const MINUS = 57387
-const EXCEPT = 57388
-const INTERSECT = 57389
-const JOIN = 57390
-const STRAIGHT_JOIN = 57391
-const LEFT = 57392
-const RIGHT = 57393
-const INNER = 57394
-const OUTER = 57395
-const CROSS = 57396
-const NATURAL = 57397
-const USE = 57398
-const FORCE = 57399
-const ON = 57400
-const AND = 57401
-const OR = 57402
-const NOT = 57403
-const UNARY = 57404
-const CASE = 57405
-const WHEN = 57406
-const THEN = 57407
-const ELSE = 57408
-const END = 57409
-const CREATE = 57410
-const ALTER = 57411
-const DROP = 57412
-const RENAME = 57413
-const TABLE = 57414
-const INDEX = 57415
-const VIEW = 57416
-const TO = 57417
-const IGNORE = 57418
-const IF = 57419
-const UNIQUE = 57420
-const USING = 57421
-const NODE_LIST = 57422
-const UPLUS = 57423
-const UMINUS = 57424
-const CASE_WHEN = 57425
-const WHEN_LIST = 57426
-const FUNCTION = 57427
-const NO_LOCK = 57428
-const FOR_UPDATE = 57429
-const LOCK_IN_SHARE_MODE = 57430
-const NOT_IN = 57431
-const NOT_LIKE = 57432
-const NOT_BETWEEN = 57433
-const IS_NULL = 57434
-const IS_NOT_NULL = 57435
-const UNION_ALL = 57436
-const INDEX_LIST = 57437
-const TABLE_EXPR = 57438
-
-var yyToknames = []string{
+const REPLACE = 57348
+const UPDATE = 57349
+const DELETE = 57350
+const FROM = 57351
+const WHERE = 57352
+const GROUP = 57353
+const HAVING = 57354
+const ORDER = 57355
+const BY = 57356
+const LIMIT = 57357
+const COMMENT = 57358
+const FOR = 57359
+const EXPLAIN = 57360
+const PARTITIONS = 57361
+const DO = 57362
+const RESET = 57363
+const PROCEDURE = 57364
+const MEMBER = 57365
+const OF = 57366
+const OVER = 57367
+const UNLOCK = 57368
+const SHOW = 57369
+const BEGIN = 57370
+const COMMIT = 57371
+const ROLLBACK = 57372
+const ANALYZE = 57373
+const OPTIMIZE = 57374
+const REPAIR = 57375
+const FLUSH = 57376
+const LOAD = 57377
+const GRANT = 57378
+const REVOKE = 57379
+const ALL = 57380
+const DISTINCT = 57381
+const AS = 57382
+const EXISTS = 57383
+const IN = 57384
+const IS = 57385
+const LIKE = 57386
+const BETWEEN = 57387
+const NULL = 57388
+const TRUE = 57389
+const FALSE = 57390
+const ASC = 57391
+const DESC = 57392
+const VALUES = 57393
+const INTO = 57394
+const DUPLICATE = 57395
+const KEY = 57396
+const DEFAULT = 57397
+const SET = 57398
+const LOCK = 57399
+const STRING = 57400
+const NUMBER = 57401
+const VALUE_ARG = 57402
+const EXTENSION_EXPR = 57403
+const OUTER_JOIN_MARKER = 57404
+const LE = 57405
+const GE = 57406
+const NE = 57407
+const NULL_SAFE_EQUAL = 57408
+const LEX_ERROR = 57409
+const USER_NAME = 57410
+const NEXT = 57411
+const ID = 57412
+const UNION = 57413
+const MINUS = 57414
+const EXCEPT = 57415
+const INTERSECT = 57416
+const JOIN = 57417
+const STRAIGHT_JOIN = 57418
+const LEFT = 57419
+const RIGHT = 57420
+const INNER = 57421
+const OUTER = 57422
+const CROSS = 57423
+const NATURAL = 57424
+const USE = 57425
+const FORCE = 57426
+const ON = 57427
+const AND = 57428
+const OR = 57429
+const NOT = 57430
+const CONCAT_PIPE = 57431
+const UNARY = 57432
+const COLLATE = 57433
+const AT = 57434
+const CASE = 57435
+const WHEN = 57436
+const THEN = 57437
+const ELSE = 57438
+const END = 57439
+const CREATE = 57440
+const ALTER = 57441
+const DROP = 57442
+const RENAME = 57443
+const TRUNCATE = 57444
+const DESCRIBE = 57445
+const CONVERT = 57446
+const ADD = 57447
+const CHANGE = 57448
+const MODIFY = 57449
+const COLUMN = 57450
+const FULLTEXT = 57451
+const TABLE = 57452
+const INDEX = 57453
+const VIEW = 57454
+const TO = 57455
+const IGNORE = 57456
+const IF = 57457
+const UNIQUE = 57458
+const USING = 57459
+const WITH = 57460
+const TEMPORARY = 57461
+const DATABASE = 57462
+const SCHEMA = 57463
+const RECURSIVE = 57464
+const ROWS = 57465
+const RANGE = 57466
+const WINDOW = 57467
+const ANY = 57468
+const SOME = 57469
+const CONSTRAINT = 57470
+const FOREIGN = 57471
+const REFERENCES = 57472
+const CHECK = 57473
+const GENERATED = 57474
+const SQL_CALC_FOUND_ROWS = 57475
+const SQL_CACHE = 57476
+const SQL_NO_CACHE = 57477
+const SQL_SMALL_RESULT = 57478
+const SQL_BIG_RESULT = 57479
+const SQL_BUFFER_RESULT = 57480
+const HIGH_PRIORITY = 57481
+const SHARE = 57482
+const MODE = 57483
+const NOWAIT = 57484
+const SKIP = 57485
+const LOCKED = 57486
+const PARTITION = 57487
+const ASSIGN = 57488
+const JSON_EXTRACT_OP = 57489
+const JSON_UNQUOTE_EXTRACT_OP = 57490
+const NODE_LIST = 57491
+const UPLUS = 57492
+const UMINUS = 57493
+const CASE_WHEN = 57494
+const WHEN_LIST = 57495
+const FUNCTION = 57496
+const NO_LOCK = 57497
+const FOR_UPDATE = 57498
+const FOR_SHARE = 57499
+const LOCK_IN_SHARE_MODE = 57500
+const NOT_IN = 57501
+const NOT_LIKE = 57502
+const NOT_BETWEEN = 57503
+const IS_NULL = 57504
+const IS_NOT_NULL = 57505
+const UNION_ALL = 57506
+const TUPLE = 57507
+const TABLE_EXPR = 57508
+const VALUES_FUNC = 57509
+const NULLS_FIRST = 57510
+const NULLS_LAST = 57511
+const MEMBER_OF = 57512
+const AT_TIME_ZONE = 57513
+const SET_NAMES = 57514
+const SET_CHARSET = 57515
+const WILDCARD = 57516
+
+var yyToknames = [...]string{
+	"$end",
+	"error",
+	"$unk",
 	"SELECT",
 	"INSERT",
+	"REPLACE",
 	"UPDATE",
 	"DELETE",
 	"FROM",
@@ -154,6 +894,26 @@ var yyToknames = []string{
 	"LIMIT",
 	"COMMENT",
 	"FOR",
+	"EXPLAIN",
+	"PARTITIONS",
+	"DO",
+	"RESET",
+	"PROCEDURE",
+	"MEMBER",
+	"OF",
+	"OVER",
+	"UNLOCK",
+	"SHOW",
+	"BEGIN",
+	"COMMIT",
+	"ROLLBACK",
+	"ANALYZE",
+	"OPTIMIZE",
+	"REPAIR",
+	"FLUSH",
+	"LOAD",
+	"GRANT",
+	"REVOKE",
 	"ALL",
 	"DISTINCT",
 	"AS",
@@ -163,6 +923,8 @@ var yyToknames = []string{
 	"LIKE",
 	"BETWEEN",
 	"NULL",
+	"TRUE",
+	"FALSE",
 	"ASC",
 	"DESC",
 	"VALUES",
@@ -172,25 +934,29 @@ var yyToknames = []string{
 	"DEFAULT",
 	"SET",
 	"LOCK",
-	"ID",
 	"STRING",
 	"NUMBER",
 	"VALUE_ARG",
+	"EXTENSION_EXPR",
+	"OUTER_JOIN_MARKER",
 	"LE",
 	"GE",
 	"NE",
 	"NULL_SAFE_EQUAL",
 	"LEX_ERROR",
-	" (",
-	" =",
-	" <",
-	" >",
-	" ~",
+	"'('",
+	"'='",
+	"'<'",
+	"'>'",
+	"'~'",
+	"USER_NAME",
+	"NEXT",
+	"ID",
 	"UNION",
 	"MINUS",
 	"EXCEPT",
 	"INTERSECT",
-	" ,",
+	"','",
 	"JOIN",
 	"STRAIGHT_JOIN",
 	"LEFT",
@@ -205,16 +971,19 @@ var yyToknames = []string{
 	"AND",
 	"OR",
 	"NOT",
-	" &",
-	" |",
-	" ^",
-	" +",
-	" -",
-	" *",
-	" /",
-	" %",
-	" .",
+	"'&'",
+	"'|'",
+	"'^'",
+	"CONCAT_PIPE",
+	"'+'",
+	"'-'",
+	"'*'",
+	"'/'",
+	"'%'",
+	"'.'",
 	"UNARY",
+	"COLLATE",
+	"AT",
 	"CASE",
 	"WHEN",
 	"THEN",
@@ -224,6 +993,14 @@ var yyToknames = []string{
 	"ALTER",
 	"DROP",
 	"RENAME",
+	"TRUNCATE",
+	"DESCRIBE",
+	"CONVERT",
+	"ADD",
+	"CHANGE",
+	"MODIFY",
+	"COLUMN",
+	"FULLTEXT",
 	"TABLE",
 	"INDEX",
 	"VIEW",
@@ -232,6 +1009,37 @@ var yyToknames = []string{
 	"IF",
 	"UNIQUE",
 	"USING",
+	"WITH",
+	"TEMPORARY",
+	"DATABASE",
+	"SCHEMA",
+	"RECURSIVE",
+	"ROWS",
+	"RANGE",
+	"WINDOW",
+	"ANY",
+	"SOME",
+	"CONSTRAINT",
+	"FOREIGN",
+	"REFERENCES",
+	"CHECK",
+	"GENERATED",
+	"SQL_CALC_FOUND_ROWS",
+	"SQL_CACHE",
+	"SQL_NO_CACHE",
+	"SQL_SMALL_RESULT",
+	"SQL_BIG_RESULT",
+	"SQL_BUFFER_RESULT",
+	"HIGH_PRIORITY",
+	"SHARE",
+	"MODE",
+	"NOWAIT",
+	"SKIP",
+	"LOCKED",
+	"PARTITION",
+	"ASSIGN",
+	"JSON_EXTRACT_OP",
+	"JSON_UNQUOTE_EXTRACT_OP",
 	"NODE_LIST",
 	"UPLUS",
 	"UMINUS",
@@ -240,6 +1048,7 @@ var yyToknames = []string{
 	"FUNCTION",
 	"NO_LOCK",
 	"FOR_UPDATE",
+	"FOR_SHARE",
 	"LOCK_IN_SHARE_MODE",
 	"NOT_IN",
 	"NOT_LIKE",
@@ -247,316 +1056,1199 @@ var yyToknames = []string{
 	"IS_NULL",
 	"IS_NOT_NULL",
 	"UNION_ALL",
-	"INDEX_LIST",
+	"TUPLE",
 	"TABLE_EXPR",
+	"VALUES_FUNC",
+	"NULLS_FIRST",
+	"NULLS_LAST",
+	"MEMBER_OF",
+	"AT_TIME_ZONE",
+	"SET_NAMES",
+	"SET_CHARSET",
+	"WILDCARD",
+	"')'",
 }
-var yyStatenames = []string{}
+
+var yyStatenames = [...]string{}
 
 const yyEofCode = 1
 const yyErrCode = 2
-const yyMaxDepth = 200
+const yyInitialStackSize = 16
 
 //line yacctab:1
-var yyExca = []int{
+var yyExca = [...]int16{
 	-1, 1,
 	1, -1,
 	-2, 0,
+	-1, 40,
+	126, 138,
+	-2, 633,
+	-1, 41,
+	40, 590,
+	-2, 0,
+	-1, 90,
+	1, 317,
+	-2, 0,
+	-1, 131,
+	1, 648,
+	58, 648,
+	75, 648,
+	-2, 645,
+	-1, 161,
+	92, 443,
+	93, 443,
+	-2, 391,
+	-1, 162,
+	92, 444,
+	93, 444,
+	-2, 392,
+	-1, 279,
+	1, 318,
+	-2, 0,
+	-1, 300,
+	40, 590,
+	-2, 0,
+	-1, 363,
+	92, 444,
+	93, 444,
+	-2, 480,
+	-1, 443,
+	69, 646,
+	161, 646,
+	-2, 617,
+	-1, 500,
+	68, 471,
+	-2, 662,
+	-1, 501,
+	68, 472,
+	-2, 663,
+	-1, 544,
+	104, 649,
+	-2, 647,
+	-1, 545,
+	104, 648,
+	-2, 645,
+	-1, 672,
+	1, 88,
+	-2, 0,
+	-1, 845,
+	1, 254,
+	-2, 0,
+	-1, 912,
+	1, 160,
+	-2, 0,
+	-1, 1032,
+	58, 645,
+	-2, 582,
 }
 
-const yyNprod = 189
 const yyPrivate = 57344
 
-var yyTokenNames []string
-var yyStates []string
-
-const yyLast = 601
-
-var yyAct = []int{
-
-	82, 75, 52, 312, 341, 148, 232, 80, 194, 184,
-	277, 107, 69, 155, 164, 72, 157, 349, 349, 116,
-	53, 147, 3, 70, 243, 244, 245, 246, 247, 74,
-	248, 249, 55, 213, 275, 60, 116, 116, 63, 54,
-	121, 122, 67, 43, 213, 172, 66, 22, 23, 24,
-	25, 22, 23, 24, 25, 58, 22, 23, 24, 25,
-	106, 37, 322, 38, 22, 23, 24, 25, 239, 114,
-	269, 178, 110, 118, 321, 109, 350, 348, 304, 145,
-	149, 59, 297, 150, 32, 255, 34, 211, 62, 120,
-	35, 176, 300, 274, 179, 266, 264, 39, 158, 55,
-	159, 162, 55, 214, 168, 103, 54, 144, 146, 54,
-	301, 156, 329, 330, 298, 99, 169, 105, 167, 267,
-	189, 168, 166, 145, 145, 193, 182, 212, 199, 200,
-	61, 203, 204, 205, 206, 207, 208, 209, 210, 318,
-	188, 201, 271, 175, 177, 174, 235, 40, 41, 42,
-	320, 191, 192, 215, 129, 130, 131, 132, 133, 134,
-	135, 136, 55, 121, 122, 217, 219, 145, 190, 230,
-	223, 113, 222, 236, 224, 225, 220, 231, 158, 288,
-	159, 268, 228, 202, 289, 237, 319, 12, 13, 14,
-	15, 158, 292, 159, 221, 234, 253, 215, 240, 259,
-	260, 256, 294, 295, 218, 258, 85, 286, 254, 291,
-	290, 89, 287, 101, 94, 263, 16, 257, 134, 135,
-	136, 73, 86, 87, 88, 213, 326, 327, 307, 102,
-	78, 265, 336, 165, 92, 165, 273, 115, 276, 49,
-	222, 129, 130, 131, 132, 133, 134, 135, 136, 335,
-	195, 284, 285, 77, 161, 154, 187, 90, 91, 71,
-	153, 152, 61, 303, 95, 186, 17, 18, 20, 19,
-	132, 133, 134, 135, 136, 56, 55, 241, 93, 101,
-	310, 313, 116, 308, 299, 305, 296, 309, 97, 65,
-	12, 100, 314, 243, 244, 245, 246, 247, 216, 248,
-	249, 252, 302, 281, 323, 129, 130, 131, 132, 133,
-	134, 135, 136, 280, 181, 346, 325, 251, 145, 215,
-	145, 187, 331, 333, 22, 23, 24, 25, 338, 313,
-	186, 339, 68, 347, 98, 119, 342, 342, 55, 180,
-	344, 343, 340, 163, 85, 54, 332, 111, 334, 89,
-	353, 61, 94, 354, 108, 355, 104, 50, 64, 56,
-	86, 87, 88, 324, 306, 12, 85, 48, 78, 262,
-	352, 89, 92, 196, 94, 197, 198, 170, 112, 96,
-	46, 73, 86, 87, 88, 44, 278, 317, 279, 227,
-	78, 77, 26, 233, 92, 90, 91, 316, 283, 12,
-	165, 51, 95, 158, 351, 159, 28, 29, 30, 31,
-	337, 12, 27, 77, 171, 85, 93, 90, 91, 71,
-	89, 33, 238, 94, 95, 173, 36, 57, 229, 160,
-	56, 86, 87, 88, 270, 345, 328, 85, 93, 78,
-	311, 315, 89, 92, 282, 94, 79, 84, 81, 83,
-	272, 226, 56, 86, 87, 88, 123, 76, 293, 12,
-	185, 78, 77, 242, 183, 92, 90, 91, 250, 117,
-	45, 21, 47, 95, 11, 10, 9, 8, 7, 6,
-	89, 5, 4, 94, 77, 2, 1, 93, 90, 91,
-	56, 86, 87, 88, 0, 95, 0, 0, 0, 151,
-	0, 0, 89, 92, 0, 94, 0, 0, 0, 93,
-	0, 0, 56, 86, 87, 88, 0, 0, 0, 0,
-	0, 151, 0, 0, 0, 92, 90, 91, 0, 0,
-	0, 0, 0, 95, 0, 0, 0, 0, 124, 128,
-	126, 127, 0, 0, 0, 0, 0, 93, 90, 91,
-	0, 0, 0, 0, 0, 95, 140, 141, 142, 143,
-	0, 0, 137, 138, 139, 0, 0, 261, 0, 93,
-	129, 130, 131, 132, 133, 134, 135, 136, 0, 0,
-	0, 0, 0, 0, 125, 129, 130, 131, 132, 133,
-	134, 135, 136, 129, 130, 131, 132, 133, 134, 135,
-	136,
+const yyLast = 4690
+
+var yyAct = [...]int16{
+	186, 1222, 728, 1186, 568, 604, 690, 944, 422, 297,
+	943, 1120, 741, 1152, 1069, 1100, 168, 1168, 1137, 1005,
+	1092, 1113, 111, 1000, 1096, 1048, 1031, 746, 752, 1035,
+	362, 1016, 833, 1033, 1047, 161, 1185, 859, 1065, 738,
+	275, 96, 935, 846, 916, 794, 316, 3, 134, 433,
+	197, 200, 200, 202, 834, 860, 982, 731, 653, 539,
+	869, 742, 732, 950, 817, 901, 385, 624, 636, 631,
+	171, 167, 763, 673, 432, 845, 691, 598, 537, 164,
+	280, 257, 582, 781, 637, 675, 270, 221, 389, 585,
+	276, 281, 383, 182, 108, 214, 279, 287, 277, 378,
+	291, 294, 269, 441, 252, 265, 180, 659, 160, 597,
+	397, 213, 220, 270, 376, 300, 109, 311, 288, 403,
+	304, 113, 305, 605, 398, 312, 76, 100, 694, 298,
+	325, 71, 80, 928, 929, 930, 931, 932, 477, 933,
+	934, 132, 72, 73, 74, 75, 1210, 31, 1099, 1204,
+	1099, 215, 1099, 1099, 132, 72, 73, 74, 75, 1164,
+	79, 477, 1099, 1099, 1109, 1057, 1054, 1024, 82, 83,
+	84, 85, 1099, 979, 978, 976, 974, 132, 122, 123,
+	890, 890, 132, 958, 888, 913, 204, 205, 206, 207,
+	208, 805, 816, 694, 811, 293, 709, 700, 381, 124,
+	528, 358, 360, 388, 694, 694, 399, 400, 399, 399,
+	629, 527, 238, 361, 528, 477, 132, 132, 450, 241,
+	526, 446, 364, 434, 249, 693, 1039, 254, 951, 952,
+	1040, 104, 954, 849, 757, 261, 895, 1121, 1218, 104,
+	272, 104, 1023, 1144, 307, 357, 78, 639, 1207, 759,
+	918, 919, 364, 240, 377, 670, 420, 757, 1146, 424,
+	1145, 104, 1143, 1142, 428, 938, 1088, 443, 660, 419,
+	104, 1108, 1105, 1104, 309, 310, 427, 453, 306, 289,
+	281, 1157, 1098, 440, 281, 463, 464, 593, 468, 910,
+	891, 889, 132, 472, 887, 287, 294, 116, 908, 725,
+	937, 865, 281, 825, 132, 132, 486, 132, 417, 412,
+	810, 312, 790, 447, 702, 695, 693, 130, 258, 583,
+	458, 461, 365, 366, 529, 476, 386, 1162, 449, 496,
+	465, 405, 401, 402, 337, 338, 339, 340, 341, 342,
+	343, 344, 345, 475, 1158, 346, 347, 522, 523, 482,
+	384, 751, 365, 366, 132, 104, 132, 1077, 1079, 240,
+	456, 411, 404, 404, 462, 844, 71, 132, 314, 284,
+	999, 534, 211, 421, 270, 270, 536, 395, 429, 396,
+	549, 850, 32, 303, 240, 434, 104, 503, 104, 497,
+	299, 358, 358, 104, 104, 119, 120, 1078, 481, 425,
+	290, 584, 132, 107, 105, 106, 1043, 668, 117, 471,
+	594, 902, 431, 705, 435, 361, 104, 479, 1114, 541,
+	494, 494, 210, 474, 409, 448, 483, 358, 490, 915,
+	607, 739, 621, 754, 610, 524, 525, 270, 473, 554,
+	555, 898, 103, 623, 71, 1183, 292, 942, 114, 102,
+	116, 633, 646, 269, 612, 560, 105, 106, 281, 654,
+	489, 635, 654, 627, 627, 103, 283, 544, 547, 104,
+	281, 564, 102, 286, 667, 294, 552, 410, 270, 104,
+	542, 546, 281, 132, 635, 639, 104, 638, 32, 132,
+	132, 630, 553, 941, 671, 322, 323, 324, 657, 726,
+	132, 132, 754, 132, 104, 753, 625, 625, 559, 379,
+	616, 380, 704, 32, 104, 628, 754, 282, 454, 199,
+	658, 589, 101, 104, 688, 620, 587, 588, 602, 601,
+	285, 608, 603, 1119, 692, 680, 278, 104, 652, 701,
+	697, 561, 617, 379, 699, 380, 622, 566, 567, 346,
+	347, 681, 203, 103, 373, 703, 99, 393, 404, 404,
+	102, 682, 456, 107, 105, 106, 664, 326, 663, 293,
+	715, 905, 661, 379, 753, 380, 551, 714, 240, 34,
+	35, 36, 37, 374, 717, 718, 373, 327, 753, 283,
+	394, 599, 315, 240, 34, 35, 36, 37, 470, 511,
+	337, 338, 339, 340, 341, 342, 343, 344, 345, 104,
+	734, 346, 347, 736, 270, 270, 1217, 600, 862, 443,
+	355, 356, 749, 802, 1093, 747, 416, 744, 710, 861,
+	743, 743, 1072, 858, 453, 440, 756, 418, 787, 86,
+	282, 785, 64, 765, 492, 495, 772, 512, 777, 706,
+	132, 711, 326, 552, 484, 416, 240, 64, 654, 740,
+	713, 786, 1011, 755, 423, 281, 415, 1012, 1009, 789,
+	862, 791, 1097, 1010, 801, 1097, 806, 721, 1074, 808,
+	132, 1073, 1176, 132, 1175, 412, 1015, 1014, 1013, 417,
+	747, 543, 343, 344, 345, 926, 104, 346, 347, 270,
+	270, 745, 270, 283, 1205, 132, 995, 32, 384, 948,
+	830, 792, 962, 766, 764, 862, 784, 584, 843, 1171,
+	840, 758, 32, 104, 648, 528, 809, 271, 457, 1085,
+	640, 694, 426, 852, 162, 783, 799, 800, 720, 642,
+	803, 796, 797, 798, 962, 456, 72, 73, 74, 75,
+	104, 807, 1174, 870, 282, 866, 870, 873, 949, 104,
+	862, 999, 870, 737, 1090, 826, 586, 716, 627, 819,
+	678, 765, 864, 821, 883, 643, 104, 556, 641, 104,
+	437, 805, 856, 358, 997, 438, 875, 949, 436, 793,
+	645, 894, 547, 544, 329, 547, 1129, 633, 1037, 842,
+	904, 655, 656, 848, 847, 822, 542, 839, 824, 33,
+	1032, 625, 857, 1224, 1118, 644, 871, 455, 104, 868,
+	877, 460, 878, 992, 1116, 1091, 946, 867, 906, 881,
+	649, 647, 104, 467, 104, 923, 1062, 897, 885, 886,
+	999, 766, 764, 882, 104, 341, 342, 343, 344, 345,
+	466, 922, 346, 347, 957, 104, 445, 1019, 104, 444,
+	750, 925, 533, 270, 903, 900, 899, 896, 911, 104,
+	283, 595, 967, 966, 698, 870, 104, 104, 893, 743,
+	959, 242, 872, 940, 921, 936, 250, 558, 596, 255,
+	104, 939, 1018, 892, 719, 457, 984, 801, 924, 276,
+	359, 363, 987, 104, 276, 367, 990, 991, 831, 104,
+	654, 994, 286, 998, 953, 955, 313, 273, 104, 909,
+	829, 282, 557, 965, 964, 928, 929, 930, 931, 932,
+	839, 933, 934, 104, 454, 972, 973, 827, 132, 986,
+	676, 708, 985, 707, 988, 677, 1030, 674, 104, 996,
+	983, 666, 662, 104, 619, 253, 393, 391, 1041, 591,
+	590, 270, 392, 488, 478, 469, 1004, 414, 993, 1049,
+	1049, 1049, 1046, 1044, 302, 301, 270, 743, 1003, 1017,
+	218, 1007, 1008, 209, 863, 1055, 1199, 1020, 276, 394,
+	1059, 390, 298, 1038, 328, 1063, 947, 460, 998, 1042,
+	1209, 459, 1189, 1068, 1050, 1051, 740, 1102, 1103, 1182,
+	1173, 960, 613, 1139, 460, 387, 480, 543, 1045, 104,
+	839, 839, 104, 112, 112, 1067, 1101, 112, 1061, 504,
+	240, 837, 494, 110, 1064, 494, 494, 1056, 1066, 337,
+	338, 339, 340, 341, 342, 343, 344, 345, 1060, 1080,
+	346, 347, 1081, 1049, 1049, 1053, 1058, 1052, 977, 1049,
+	1083, 1049, 1084, 1112, 1089, 498, 1115, 1117, 508, 975,
+	510, 1082, 513, 514, 515, 516, 517, 518, 519, 520,
+	521, 1094, 112, 970, 969, 968, 375, 1106, 1107, 876,
+	359, 359, 779, 1110, 64, 1111, 778, 760, 1136, 112,
+	1049, 735, 1123, 532, 685, 1125, 358, 679, 358, 651,
+	1130, 1127, 1128, 494, 1126, 1148, 1135, 650, 615, 1134,
+	372, 839, 1153, 1140, 1141, 1138, 359, 565, 371, 1150,
+	782, 780, 1131, 980, 1147, 317, 4, 219, 1163, 880,
+	879, 1163, 1163, 1149, 774, 491, 773, 1156, 775, 776,
+	1122, 1180, 1124, 729, 837, 1160, 1170, 1132, 1086, 1161,
+	981, 1165, 1166, 1179, 1169, 815, 1163, 1163, 788, 1184,
+	1184, 1177, 1153, 782, 1192, 724, 563, 531, 530, 270,
+	1181, 722, 614, 270, 1198, 1188, 1159, 989, 1202, 1195,
+	692, 1196, 1197, 730, 1201, 743, 1203, 1200, 1227, 298,
+	239, 237, 971, 1208, 1206, 771, 1211, 1228, 761, 1133,
+	712, 963, 1212, 94, 1215, 961, 1216, 945, 723, 1219,
+	611, 1223, 1223, 1225, 1226, 337, 338, 339, 340, 341,
+	342, 343, 344, 345, 260, 198, 346, 347, 132, 532,
+	762, 683, 684, 98, 837, 837, 1102, 1103, 505, 93,
+	506, 507, 97, 88, 687, 391, 1022, 851, 190, 1025,
+	1026, 665, 487, 689, 92, 634, 1172, 884, 609, 296,
+	247, 248, 142, 149, 370, 140, 141, 89, 151, 509,
+	135, 136, 137, 1187, 91, 1155, 150, 201, 118, 390,
+	841, 550, 430, 174, 392, 115, 1167, 121, 178, 195,
+	196, 245, 246, 188, 243, 244, 95, 1194, 1193, 1071,
+	175, 176, 177, 169, 920, 820, 606, 423, 818, 1070,
+	166, 1006, 747, 1027, 185, 812, 138, 540, 337, 338,
+	339, 340, 341, 342, 343, 344, 345, 1022, 727, 346,
+	347, 733, 1221, 1220, 1178, 837, 165, 262, 770, 295,
+	81, 183, 184, 538, 321, 8, 320, 7, 804, 139,
+	194, 319, 6, 318, 5, 55, 46, 828, 382, 193,
+	369, 189, 669, 145, 144, 146, 581, 854, 855, 580,
+	223, 224, 187, 225, 226, 126, 143, 191, 192, 256,
+	152, 153, 874, 147, 148, 1213, 632, 672, 912, 795,
+	1028, 1095, 451, 233, 452, 251, 917, 154, 155, 156,
+	157, 158, 236, 1151, 231, 90, 274, 127, 907, 87,
+	813, 814, 59, 337, 338, 339, 340, 341, 342, 343,
+	344, 345, 1190, 232, 346, 347, 1191, 1154, 1076, 1075,
+	408, 1087, 548, 190, 592, 413, 1034, 832, 212, 1036,
+	485, 222, 264, 1029, 748, 263, 268, 142, 149, 267,
+	140, 141, 956, 151, 853, 135, 136, 137, 173, 170,
+	172, 150, 499, 330, 179, 163, 835, 927, 174, 159,
+	696, 570, 359, 178, 195, 196, 259, 77, 188, 227,
+	229, 228, 125, 26, 25, 175, 176, 177, 169, 24,
+	23, 22, 230, 234, 21, 166, 20, 19, 18, 185,
+	235, 138, 540, 686, 17, 16, 337, 338, 339, 340,
+	341, 342, 343, 344, 345, 15, 14, 346, 347, 13,
+	12, 165, 11, 10, 30, 29, 183, 184, 538, 28,
+	27, 41, 39, 9, 139, 194, 2, 914, 1, 0,
+	0, 0, 0, 0, 193, 532, 189, 0, 145, 144,
+	146, 0, 0, 0, 0, 0, 0, 187, 178, 195,
+	196, 143, 191, 192, 0, 152, 153, 0, 147, 148,
+	175, 176, 177, 0, 0, 0, 0, 733, 190, 0,
+	769, 0, 154, 155, 156, 157, 158, 104, 0, 0,
+	0, 0, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	0, 768, 767, 174, 0, 0, 0, 1214, 178, 195,
+	196, 0, 0, 188, 0, 0, 0, 0, 0, 0,
+	175, 176, 177, 169, 0, 0, 0, 0, 0, 0,
+	166, 0, 1002, 0, 185, 733, 138, 540, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 165, 0, 0, 0,
+	0, 183, 184, 538, 0, 0, 0, 0, 0, 139,
+	194, 0, 0, 0, 0, 0, 0, 0, 0, 193,
+	0, 189, 0, 145, 144, 146, 0, 0, 0, 0,
+	0, 0, 187, 0, 0, 0, 143, 191, 192, 0,
+	152, 153, 0, 147, 148, 0, 0, 0, 190, 0,
+	0, 0, 0, 0, 0, 0, 1002, 154, 155, 156,
+	157, 158, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	0, 571, 0, 174, 0, 0, 0, 0, 178, 195,
+	196, 0, 535, 188, 0, 0, 0, 0, 0, 0,
+	175, 176, 177, 169, 0, 0, 0, 0, 0, 0,
+	166, 0, 0, 0, 185, 0, 138, 540, 0, 0,
+	0, 0, 0, 0, 572, 359, 532, 359, 0, 0,
+	0, 0, 0, 0, 0, 0, 165, 0, 0, 0,
+	0, 183, 184, 538, 0, 0, 0, 0, 0, 139,
+	194, 0, 0, 0, 0, 0, 0, 0, 0, 193,
+	0, 189, 0, 145, 144, 146, 0, 0, 0, 0,
+	0, 0, 187, 0, 1002, 0, 143, 191, 192, 0,
+	152, 153, 0, 147, 148, 0, 0, 0, 190, 0,
+	573, 574, 575, 576, 577, 578, 579, 154, 155, 156,
+	157, 158, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	0, 571, 0, 174, 0, 0, 0, 0, 178, 195,
+	196, 0, 0, 188, 0, 0, 0, 0, 0, 0,
+	175, 176, 177, 169, 0, 0, 0, 0, 0, 0,
+	166, 0, 0, 0, 185, 0, 569, 540, 0, 0,
+	0, 0, 0, 0, 572, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 165, 0, 0, 0,
+	0, 183, 184, 538, 0, 0, 0, 0, 0, 139,
+	194, 0, 0, 0, 0, 0, 0, 0, 0, 193,
+	0, 189, 0, 145, 144, 146, 0, 0, 0, 0,
+	0, 0, 187, 0, 0, 0, 143, 191, 192, 0,
+	152, 153, 0, 147, 148, 0, 0, 0, 190, 0,
+	573, 574, 575, 576, 577, 578, 579, 154, 155, 156,
+	157, 158, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	0, 0, 0, 174, 0, 0, 0, 0, 178, 195,
+	196, 0, 0, 188, 0, 0, 0, 0, 0, 0,
+	175, 176, 177, 169, 0, 0, 0, 0, 0, 0,
+	166, 0, 0, 0, 185, 0, 138, 181, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 165, 0, 0, 0,
+	0, 183, 184, 0, 0, 0, 0, 0, 0, 139,
+	194, 379, 0, 380, 0, 0, 0, 0, 0, 193,
+	0, 189, 0, 145, 144, 146, 0, 0, 0, 0,
+	0, 0, 187, 0, 0, 0, 143, 191, 192, 0,
+	152, 153, 0, 147, 148, 0, 240, 0, 190, 0,
+	0, 0, 0, 0, 0, 0, 0, 154, 155, 156,
+	157, 158, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	0, 0, 0, 174, 0, 0, 0, 0, 178, 195,
+	196, 0, 0, 188, 0, 0, 0, 0, 0, 0,
+	175, 176, 177, 169, 0, 0, 0, 0, 0, 0,
+	166, 0, 0, 0, 185, 0, 138, 181, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 165, 0, 0, 0,
+	0, 183, 184, 0, 0, 0, 0, 0, 0, 139,
+	194, 0, 0, 0, 0, 0, 0, 0, 0, 193,
+	0, 189, 0, 145, 144, 146, 0, 0, 0, 0,
+	0, 0, 187, 0, 0, 32, 143, 191, 192, 0,
+	152, 153, 0, 147, 148, 0, 0, 0, 190, 0,
+	0, 0, 0, 0, 0, 0, 0, 154, 155, 156,
+	157, 158, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	0, 0, 0, 174, 0, 0, 0, 0, 178, 195,
+	196, 0, 0, 188, 0, 0, 0, 626, 0, 0,
+	175, 176, 177, 169, 0, 0, 0, 0, 0, 0,
+	166, 0, 0, 0, 185, 0, 138, 181, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 165, 0, 0, 0,
+	0, 183, 184, 0, 0, 0, 0, 0, 0, 139,
+	194, 0, 0, 0, 0, 0, 0, 0, 0, 193,
+	0, 189, 0, 145, 144, 146, 0, 0, 0, 0,
+	0, 0, 187, 0, 0, 0, 143, 191, 192, 0,
+	152, 153, 0, 147, 148, 0, 0, 0, 190, 0,
+	0, 0, 0, 0, 0, 0, 0, 154, 155, 156,
+	157, 158, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	0, 0, 0, 174, 0, 0, 0, 0, 178, 195,
+	196, 0, 0, 188, 0, 0, 0, 0, 0, 0,
+	175, 176, 177, 169, 0, 0, 0, 0, 0, 0,
+	166, 0, 0, 0, 185, 0, 138, 540, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 165, 0, 0, 0,
+	0, 183, 184, 538, 0, 0, 0, 0, 0, 139,
+	194, 0, 0, 0, 0, 0, 0, 0, 0, 193,
+	0, 189, 0, 145, 144, 146, 0, 0, 0, 0,
+	0, 0, 187, 0, 0, 0, 143, 191, 192, 0,
+	152, 153, 0, 147, 148, 0, 0, 0, 190, 0,
+	0, 0, 0, 0, 0, 0, 0, 154, 155, 156,
+	157, 158, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	0, 0, 0, 174, 0, 0, 0, 0, 178, 195,
+	196, 0, 0, 188, 0, 0, 0, 0, 0, 0,
+	175, 176, 177, 169, 0, 0, 0, 0, 0, 0,
+	166, 0, 0, 0, 185, 0, 138, 181, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 165, 0, 0, 0,
+	0, 183, 184, 0, 0, 0, 0, 0, 0, 139,
+	194, 0, 0, 0, 0, 0, 0, 0, 0, 193,
+	0, 189, 0, 145, 144, 146, 0, 0, 0, 0,
+	0, 0, 187, 0, 0, 0, 143, 191, 192, 0,
+	152, 153, 0, 147, 148, 0, 240, 0, 190, 0,
+	0, 0, 0, 0, 0, 0, 0, 154, 155, 156,
+	157, 158, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 178, 195,
+	196, 0, 0, 188, 0, 0, 0, 0, 0, 0,
+	175, 176, 177, 169, 0, 0, 0, 0, 0, 0,
+	368, 0, 0, 0, 185, 0, 138, 181, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 183, 184, 0, 0, 0, 0, 0, 0, 139,
+	194, 0, 0, 0, 0, 0, 0, 0, 0, 193,
+	0, 189, 0, 145, 144, 146, 0, 0, 0, 0,
+	0, 0, 187, 0, 0, 32, 143, 191, 192, 0,
+	152, 153, 0, 147, 148, 0, 0, 0, 190, 0,
+	0, 0, 0, 0, 0, 0, 0, 154, 155, 156,
+	157, 158, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 1001, 178, 195,
+	196, 0, 0, 188, 0, 0, 0, 0, 0, 0,
+	175, 176, 177, 169, 0, 0, 0, 0, 0, 0,
+	368, 0, 0, 0, 185, 0, 138, 181, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 183, 184, 0, 0, 0, 0, 0, 0, 139,
+	194, 0, 0, 0, 0, 0, 0, 0, 0, 193,
+	0, 189, 0, 145, 144, 146, 0, 0, 0, 0,
+	0, 0, 187, 0, 0, 0, 143, 191, 192, 0,
+	152, 153, 0, 147, 148, 0, 0, 0, 190, 0,
+	0, 0, 0, 0, 0, 0, 0, 154, 155, 156,
+	157, 158, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	502, 0, 0, 0, 0, 0, 0, 0, 178, 195,
+	196, 0, 0, 188, 0, 0, 0, 0, 0, 0,
+	175, 176, 177, 169, 0, 0, 0, 0, 0, 0,
+	368, 0, 0, 0, 185, 0, 138, 181, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 183, 184, 0, 0, 0, 0, 0, 0, 139,
+	194, 0, 0, 0, 0, 0, 0, 0, 0, 193,
+	0, 189, 0, 145, 144, 146, 0, 0, 0, 0,
+	0, 0, 187, 0, 0, 0, 143, 191, 192, 0,
+	152, 153, 0, 500, 501, 0, 0, 0, 190, 0,
+	0, 0, 0, 0, 0, 0, 0, 154, 155, 156,
+	157, 158, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 178, 195,
+	196, 0, 0, 188, 0, 0, 0, 0, 0, 0,
+	175, 176, 177, 169, 0, 0, 0, 0, 0, 0,
+	368, 0, 0, 0, 185, 0, 138, 181, 0, 142,
+	149, 0, 140, 141, 0, 151, 0, 135, 136, 137,
+	0, 0, 0, 150, 0, 0, 0, 0, 0, 0,
+	0, 183, 184, 0, 0, 0, 0, 0, 0, 139,
+	194, 0, 0, 0, 445, 442, 0, 444, 0, 193,
+	0, 189, 0, 145, 144, 146, 0, 0, 0, 0,
+	0, 0, 187, 138, 181, 0, 143, 191, 192, 0,
+	152, 153, 0, 147, 148, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 154, 155, 156,
+	157, 158, 0, 373, 0, 0, 139, 0, 0, 142,
+	149, 0, 140, 141, 0, 151, 0, 135, 136, 137,
+	145, 144, 146, 150, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 143, 0, 0, 0, 152, 153, 0,
+	147, 148, 0, 0, 445, 442, 0, 444, 0, 240,
+	0, 0, 0, 0, 154, 155, 156, 157, 158, 0,
+	0, 0, 0, 138, 439, 142, 149, 0, 140, 141,
+	0, 151, 0, 135, 136, 137, 0, 0, 0, 150,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 373, 0, 0, 139, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	145, 144, 146, 836, 0, 0, 0, 0, 0, 138,
+	838, 0, 0, 143, 0, 0, 0, 152, 153, 0,
+	147, 148, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 154, 155, 156, 157, 158, 0,
+	0, 0, 139, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 145, 144, 146, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 32, 143,
+	0, 0, 0, 152, 153, 0, 147, 148, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	154, 155, 156, 157, 158, 129, 0, 133, 142, 149,
+	0, 140, 141, 0, 151, 0, 135, 136, 137, 128,
+	0, 0, 150, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 142, 149, 0, 140, 141, 0,
+	151, 0, 135, 136, 137, 0, 0, 0, 150, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 138, 131, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 142, 149, 0,
+	140, 141, 836, 151, 0, 135, 136, 137, 138, 838,
+	0, 150, 0, 0, 0, 139, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 145,
+	144, 146, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 139, 143, 0, 0, 0, 152, 153, 0, 147,
+	148, 138, 545, 0, 0, 145, 144, 146, 0, 0,
+	0, 0, 0, 154, 155, 156, 157, 158, 143, 0,
+	0, 0, 152, 153, 0, 147, 148, 0, 823, 0,
+	0, 0, 0, 0, 139, 0, 0, 0, 0, 154,
+	155, 156, 157, 158, 0, 0, 0, 0, 145, 144,
+	146, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 143, 0, 0, 0, 152, 153, 0, 147, 148,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 154, 155, 156, 157, 158, 142, 149, 0,
+	140, 141, 0, 151, 0, 135, 136, 137, 0, 0,
+	0, 150, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 142, 149, 0, 140, 141, 0, 151,
+	0, 135, 136, 137, 0, 0, 0, 150, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 138, 217, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 142, 149, 0, 140,
+	141, 0, 151, 0, 135, 136, 137, 138, 217, 0,
+	150, 0, 0, 0, 139, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 145, 144,
+	146, 0, 216, 0, 0, 0, 0, 326, 0, 0,
+	139, 143, 0, 0, 0, 152, 153, 0, 147, 148,
+	138, 407, 0, 0, 145, 144, 146, 0, 0, 0,
+	0, 0, 154, 155, 156, 157, 158, 143, 0, 0,
+	0, 152, 153, 0, 147, 148, 0, 0, 0, 0,
+	0, 0, 0, 139, 0, 0, 0, 0, 154, 155,
+	156, 157, 158, 0, 0, 0, 0, 145, 144, 146,
+	0, 406, 0, 0, 0, 0, 0, 0, 0, 0,
+	143, 0, 0, 0, 152, 153, 0, 147, 148, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 154, 155, 156, 157, 158, 142, 149, 0, 140,
+	141, 0, 151, 0, 135, 136, 137, 0, 0, 0,
+	150, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	138, 217, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 138, 181, 0, 0,
+	0, 0, 0, 139, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 145, 144, 146,
+	0, 308, 0, 0, 0, 0, 138, 217, 0, 139,
+	143, 0, 0, 0, 152, 153, 0, 147, 148, 0,
+	0, 0, 0, 145, 144, 146, 0, 0, 0, 0,
+	0, 154, 155, 156, 157, 158, 143, 0, 0, 139,
+	152, 153, 0, 147, 148, 0, 0, 0, 0, 0,
+	0, 0, 0, 145, 144, 146, 0, 154, 155, 156,
+	157, 158, 0, 0, 0, 0, 143, 0, 0, 0,
+	152, 153, 0, 147, 148, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 154, 155, 156,
+	157, 158, 142, 149, 0, 140, 141, 0, 151, 0,
+	135, 136, 137, 0, 0, 0, 150, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 142, 149,
+	0, 140, 141, 0, 151, 0, 135, 136, 137, 0,
+	0, 0, 150, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 138, 493, 142, 149,
+	0, 140, 141, 0, 151, 0, 135, 136, 137, 0,
+	0, 0, 150, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 138, 1021, 0, 0, 0, 0, 0, 139,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 145, 144, 146, 0, 0, 0, 0,
+	0, 0, 138, 104, 0, 139, 143, 0, 0, 0,
+	152, 153, 0, 147, 148, 0, 0, 0, 0, 145,
+	144, 146, 0, 0, 0, 0, 0, 154, 155, 156,
+	157, 158, 143, 0, 0, 139, 152, 153, 0, 147,
+	148, 0, 0, 0, 0, 0, 0, 0, 0, 145,
+	144, 146, 0, 154, 155, 156, 157, 158, 0, 0,
+	0, 0, 143, 0, 0, 0, 152, 153, 0, 147,
+	148, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 154, 155, 156, 157, 158, 142, 149,
+	0, 140, 141, 0, 151, 0, 135, 136, 137, 0,
+	0, 0, 150, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 142, 149, 0, 140, 141, 0,
+	151, 0, 135, 136, 137, 0, 0, 0, 150, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 138, 618, 142, 149, 0, 140, 141, 0,
+	151, 0, 135, 136, 137, 0, 0, 0, 150, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 138, 562,
+	0, 0, 0, 0, 0, 139, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 145,
+	144, 146, 0, 0, 0, 0, 0, 0, 138, 545,
+	0, 139, 143, 0, 0, 0, 152, 153, 0, 147,
+	148, 0, 0, 0, 0, 145, 144, 146, 0, 0,
+	0, 0, 0, 154, 155, 156, 157, 158, 143, 0,
+	0, 139, 152, 153, 0, 147, 148, 0, 0, 0,
+	0, 0, 0, 0, 0, 145, 144, 146, 0, 154,
+	155, 156, 157, 158, 0, 0, 0, 0, 143, 0,
+	0, 0, 152, 153, 0, 147, 148, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 154,
+	155, 156, 157, 158, 142, 149, 0, 140, 141, 0,
+	151, 0, 135, 136, 137, 0, 0, 0, 150, 53,
+	34, 35, 36, 37, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 47, 0, 48, 49, 0, 0, 0,
+	0, 51, 52, 54, 56, 57, 68, 69, 70, 60,
+	61, 62, 63, 0, 0, 0, 0, 0, 138, 266,
+	0, 0, 0, 0, 0, 66, 0, 0, 0, 0,
+	0, 38, 50, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 64, 0, 0, 0, 0, 0, 0,
+	67, 139, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 58, 145, 144, 146, 0, 0,
+	0, 0, 0, 0, 0, 334, 0, 0, 143, 0,
+	0, 0, 152, 153, 0, 147, 148, 0, 40, 42,
+	44, 43, 45, 65, 331, 336, 333, 335, 0, 154,
+	155, 156, 157, 158, 0, 0, 0, 0, 32, 0,
+	0, 0, 0, 0, 0, 351, 352, 353, 354, 0,
+	0, 348, 349, 350, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 332, 337, 338, 339,
+	340, 341, 342, 343, 344, 345, 0, 0, 346, 347,
 }
-var yyPact = []int{
-
-	183, -1000, -1000, 275, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -3, -28, 10,
-	60, 407, 368, -1000, -1000, -1000, 362, -1000, 338, 322,
-	393, 240, -37, -7, 227, -1000, 1, 227, -1000, 323,
-	-46, 227, -46, -1000, -1000, 346, -1000, 364, 322, 301,
-	39, 322, 160, -1000, 184, -1000, 29, 321, 50, 227,
-	-1000, -1000, 319, -1000, -18, 312, 358, 107, 227, 229,
-	-1000, -1000, 316, 13, 98, 517, -1000, 417, 395, -1000,
-	-1000, 477, 217, 216, -1000, 211, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, 324, -1000, 210, 240, 308,
-	391, 240, 417, 227, -1000, 357, -49, -1000, 59, -1000,
-	304, -1000, -1000, 279, -1000, 221, 346, -1000, -1000, 227,
-	95, 417, 417, 477, 206, 352, 477, 477, 116, 477,
-	477, 477, 477, 477, 477, 477, 477, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, 517, -25, 15, -9, 517,
-	-1000, 455, 186, 346, 407, 112, 19, -1000, 417, 417,
-	361, 240, 226, -1000, 381, 417, -1000, -1000, -1000, -1000,
-	-1000, 82, 227, -1000, -22, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, 224, 239, 282, 286, 9, -1000, -1000,
-	-1000, -1000, -1000, 525, -1000, 455, 206, 477, 477, 525,
-	502, -1000, 344, 199, 199, 199, 145, 145, -1000, -1000,
-	-1000, -1000, -1000, 477, -1000, 525, -1000, -16, 346, -17,
-	7, -1000, -1000, 99, -10, -1000, 78, 206, 275, -19,
-	-1000, 381, 372, 375, 98, 278, -1000, -1000, 268, -1000,
-	388, 221, 221, -1000, -1000, 153, 125, 156, 155, 138,
-	140, -1000, 251, -30, 2, 249, -20, -2, -1000, 525,
-	237, 477, -1000, 525, -1000, -34, -1000, -1000, -1000, 417,
-	-1000, 334, 175, -1000, -1000, 240, 372, -1000, 477, 477,
-	-1000, -1000, 386, 374, 239, 75, -1000, 132, -1000, 96,
-	-1000, -1000, -1000, -1000, -14, -26, -1000, -1000, -1000, -1000,
-	-1000, -1000, 477, 525, -1000, -1000, 332, 206, -1000, -1000,
-	173, 174, -1000, 86, -1000, 381, 417, 477, 417, -1000,
-	-1000, 205, 188, 525, 404, -1000, 477, 477, -1000, -1000,
-	-1000, 372, 98, 172, 98, 227, 227, 240, 525, -1000,
-	299, -35, -1000, -36, 160, -1000, 398, 349, -1000, 227,
-	-1000, -1000, 227, -1000, 227, -1000,
+
+var yyPact = [...]int16{
+	4505, -1000, -1000, -1000, 670, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, 670, 109, 670, -1000, -1000, -1000, -1000, -1000, 1209,
+	429, 1031, 321, 283, 269, -1000, -1000, 3468, 2562, 675,
+	394, 394, 439, -1000, -1000, -1000, -1000, -1000, 908, 297,
+	3677, 905, 1376, 1376, 1026, -1000, -1000, -1000, -1000, -1000,
+	-1000, 1026, 1266, -1000, 1263, 1232, 1026, 880, -1000, 1026,
+	164, -1000, 1182, 3942, 1338, 4474, -1000, -1000, 3942, 873,
+	534, -1000, -1000, -1000, -1000, 243, 404, 149, 275, 675,
+	319, 1343, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, 1229, 3912, 265, 675, 900, -1000, 899, 258, 675,
+	148, 148, 3886, 3942, 858, 574, 589, 589, 589, 675,
+	-1000, 463, 483, -1000, 925, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 714,
+	-1000, -1000, 4582, -1000, 528, 2562, 2142, -1000, 160, -1000,
+	3122, 1249, 1060, -1000, 1052, -1000, -1000, -1000, -1000, -1000,
+	-1000, 482, 479, -1000, -1000, -1000, 1018, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, 2002, -1000, -1000, 675, 3942, -1000,
+	-1000, -1000, 947, 252, -1000, 675, 675, 675, 675, -1000,
+	3942, 3746, 344, 3677, -1000, -1000, -1000, 463, 892, 575,
+	1376, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 546, 79, 66,
+	-1000, -1000, 1304, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	1304, 652, -1000, 959, -1000, 1304, 168, -1000, -1000, 1276,
+	3942, 63, 3942, 708, 705, -1000, 3269, 152, -1000, -1000,
+	-1000, -1000, -1000, 3942, 138, -1000, 878, -1000, -1000, 815,
+	-1000, 945, 752, 411, 675, 675, 775, 675, 890, 504,
+	149, -1000, 675, -1000, 843, 311, 249, 135, -1000, 889,
+	1014, 411, 221, 148, 563, 675, 1221, 888, 3942, -1000,
+	858, -1000, -1000, -1000, -1000, -1000, -1000, 670, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, 1086, 4082, 4082, 675, 2562,
+	2982, 961, 1206, 3122, 1255, 3122, 553, 3122, 3122, 3122,
+	3122, 3122, 3122, 3122, 3122, 3122, 675, 675, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, 2562, 2562, -1000, -1000, 4582,
+	30, 21, 134, 4582, -1000, 1120, 1119, 443, 2702, -1000,
+	794, 1582, 249, 4334, 4138, 1252, 464, 434, -1000, 2562,
+	2562, -1000, 697, -1000, 847, -1000, -1000, 407, 1245, 4304,
+	1118, 2562, 3122, -1000, -1000, 3942, 3942, 1862, -1000, -1000,
+	186, -1000, -1000, 686, -1000, 686, 3942, 3703, -1000, 3677,
+	885, 884, -1000, 281, 813, 516, 1376, -1000, 516, -1000,
+	-1000, -1000, 1279, 1302, 1279, 670, 880, 1228, 1279, 1168,
+	-1000, 956, 1126, -1000, 1050, 63, 4278, -1000, 879, 450,
+	-1000, 326, 801, -1000, -1000, -1000, 2282, 2282, 20, -1000,
+	341, 684, -1000, 1049, 1041, -1000, -1000, 411, 743, 752,
+	-1000, 743, -1000, 136, 136, -1000, -1000, 877, -1000, 411,
+	1220, 876, -1000, 675, 280, 122, -1000, 3912, -1000, -1000,
+	-1000, 648, 872, 865, 870, 690, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 505, 1039,
+	-1000, -1000, -1000, -1000, 2702, 961, 3122, 3122, 505, 1036,
+	1421, -1000, 1208, 746, 746, 746, 746, 591, 591, 443,
+	443, 443, -1000, 675, -1000, -1000, -1000, -1000, 3122, -1000,
+	-1000, -1000, 505, 156, -1000, -1000, 125, -1000, -1000, 834,
+	440, 7, -1000, 435, -1000, -1000, -1000, -1000, -1000, 124,
+	2422, -1000, -1000, 400, 303, -1000, 3942, 868, 866, 6,
+	-1000, 1245, 548, -1000, 528, 1130, -1000, -1000, 651, 675,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, 687, -1000, 675, 675, 819, 3942, 686, 686, 3677,
+	1124, -1000, 1166, -1000, -1000, -1000, 1117, 171, 395, -1000,
+	-1000, 1376, 1329, 1722, 1136, -1000, 3122, 1136, -1000, 1033,
+	1136, 3942, 380, 3912, 3912, 865, 1312, -1000, 3179, -1000,
+	-1000, 802, -1000, -1000, -1000, -1000, -1000, 190, -1000, -1000,
+	-1000, -1000, -1000, 462, 379, 675, 103, -1000, 1029, 1154,
+	-1000, 1194, 1522, 1341, 1151, 675, 1090, 675, 1028, 1024,
+	1072, 1115, -1000, -1000, -1000, -1000, -1000, 743, -1000, 550,
+	675, 547, 1110, -1000, 648, -1000, -1000, -1000, 675, -1000,
+	166, -1000, 709, 621, -1000, 701, -1000, -1000, 675, 249,
+	120, 4, -1000, 505, 1233, 3122, 3122, -1000, 1107, 505,
+	2, 1305, 65, 1301, 2422, -1000, -1000, -1000, 4138, 3537,
+	-1000, 4138, -1000, 113, -1000, 2562, -1000, 862, 845, 675,
+	-1000, 833, 3122, 3494, 1279, 1273, 186, 675, -1000, -1000,
+	-1000, 819, -1000, 240, -1000, 775, 516, 775, -1000, 226,
+	1215, 653, -1000, 1328, -1000, 249, -1000, 63, 542, 961,
+	-1000, 538, -1000, 915, 680, 111, 1304, 2562, -1000, -1000,
+	-1000, 2282, 675, -1000, -1000, 675, 828, 379, -1000, 1021,
+	2562, 675, -1000, -1000, -1000, 1018, -1000, 1081, 1080, 2562,
+	1522, -1000, -1000, 675, -1000, -1000, -1000, 1227, 2562, 2562,
+	104, 101, -1000, 100, -1000, 818, -1000, 803, -1000, -1000,
+	675, 90, -1000, -1000, -1000, -1000, 318, 288, 288, 448,
+	170, 850, -1000, 161, -1000, 793, -1000, -1000, -1000, -5,
+	-1000, -1000, 3122, 239, 505, -1000, -1000, 112, 1300, 1305,
+	3122, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 760,
+	-1000, 1245, 505, 615, 844, 225, 3325, -1000, 389, 343,
+	1165, 751, -1000, -1000, 3942, 707, -1000, -1000, 678, 71,
+	71, 77, 3122, 675, -1000, -1000, -7, 955, 1162, 664,
+	-1000, 1158, 3912, 2562, 1304, -1000, 1279, 528, -1000, 1017,
+	-1000, 1016, 1015, 1148, 675, -1000, 2562, -14, 1001, -1000,
+	-1000, -15, -1000, -1000, 990, -16, -17, -1000, 1074, -1000,
+	1102, -1000, -1000, -1000, -1000, 675, 621, -1000, 675, -1000,
+	126, 675, -1000, 675, 1133, 675, 675, 748, -1000, 743,
+	675, -1000, 704, -1000, 505, -1000, -1000, 2842, -1000, -1000,
+	3122, 112, 645, -1000, -1000, 1310, 3494, 3494, -1000, -1000,
+	587, 581, 607, 606, 605, -1000, 817, 63, 4108, 52,
+	-23, 4082, 4082, -1000, 1314, 735, -1000, 723, -1000, 775,
+	-1000, -1000, 67, -1000, 74, -1000, -1000, 675, -1000, 355,
+	3912, -1000, 961, -1000, -1000, -1000, 1279, -1000, 675, 675,
+	675, 989, 987, -24, -1000, 3912, -1000, 2562, -1000, -1000,
+	-25, -1000, 988, 980, -1000, -1000, -1000, 675, -1000, -1000,
+	-1000, -1000, -1000, -1000, 780, -1000, -1000, 783, 752, 752,
+	-1000, 3122, 944, 653, -1000, 1307, 1295, 844, 541, -1000,
+	600, -1000, 597, -1000, -1000, -1000, 268, -1000, -1000, 4082,
+	-1000, 63, -1000, -1000, -1000, -1000, -1000, 3494, 723, 649,
+	1100, -1000, -1000, 137, 723, -1000, 750, -1000, -1000, -1000,
+	-1000, -1000, 533, 961, 635, -1000, -1000, 92, -1000, 958,
+	83, 82, 675, 675, -1000, 81, -26, -1000, 675, -1000,
+	675, -1000, 675, 312, -1000, 769, 759, 441, -1000, 97,
+	2562, 3122, 2562, -1000, -1000, -1000, 379, -1000, -1000, -1000,
+	268, 268, -1000, 615, -1000, 721, -1000, 959, 1073, -1000,
+	1099, -1000, -1000, 1156, 632, 533, -1000, 675, -1000, 675,
+	-1000, 954, -1000, -1000, -1000, -1000, 73, 72, 98, -1000,
+	70, 68, 312, -1000, 675, -1000, -1000, -1000, -1000, 3122,
+	1304, 675, 528, 645, 528, 1268, 268, 1310, -1000, -1000,
+	-1000, 206, -1000, 1132, 533, -1000, 959, 195, -1000, -31,
+	195, 195, -1000, -1000, 3942, -1000, -1000, -1000, -1000, -1000,
+	1279, 639, -1000, 1226, 942, 671, 1307, -1000, -1000, 1337,
+	-1000, -1000, 675, 1093, 1197, 195, 195, 941, 313, 313,
+	1261, 675, 934, 675, -1000, 1294, 1293, 97, 3912, -1000,
+	-1000, -1000, 3912, 675, 928, -1000, 1165, 675, -1000, 156,
+	-41, 624, -1000, -1000, -1000, 1304, 590, 58, -1000, -1000,
+	1136, -1000, 932, -44, -1000, 675, 1279, -1000, -1000, 1437,
+	-1000, -1000, 1261, 525, -1000, 48, 1136, 1335, -1000, -1000,
+	757, 757, -1000, 675, 1152, -1000, -1000, -1000, -1000,
 }
-var yyPgo = []int{
-
-	0, 486, 485, 21, 482, 481, 479, 478, 477, 476,
-	475, 474, 392, 472, 471, 470, 12, 23, 469, 468,
-	15, 464, 9, 463, 460, 239, 458, 14, 29, 457,
-	456, 451, 450, 8, 5, 1, 449, 448, 447, 13,
-	16, 7, 446, 444, 441, 6, 440, 3, 436, 10,
-	435, 434, 429, 428, 4, 2, 20, 289, 427, 426,
-	425, 422, 421, 414, 0, 11, 412,
+
+var yyPgo = [...]int16{
+	0, 1548, 1546, 46, 147, 1135, 1363, 1361, 1356, 1354,
+	1543, 1542, 1541, 1540, 1539, 1535, 1534, 1137, 112, 87,
+	109, 77, 43, 75, 1533, 1532, 1530, 1529, 1526, 1525,
+	1515, 1514, 1508, 1507, 1506, 1504, 1501, 368, 1500, 1499,
+	1494, 1493, 1492, 1243, 1487, 132, 1486, 126, 110, 1481,
+	4, 78, 1480, 42, 59, 1479, 83, 32, 54, 1477,
+	1476, 151, 27, 79, 35, 1475, 1474, 1473, 1472, 39,
+	37, 55, 30, 734, 1470, 1469, 1468, 114, 99, 16,
+	71, 19, 14, 8, 57, 62, 1464, 1462, 5, 123,
+	20, 22, 9, 12, 61, 67, 105, 1459, 1456, 1455,
+	103, 1454, 1453, 1452, 85, 1450, 119, 111, 29, 1449,
+	1448, 33, 1446, 1445, 1444, 1441, 95, 26, 1440, 89,
+	2, 63, 74, 49, 31, 1439, 1438, 1437, 1436, 1432,
+	1422, 1252, 122, 118, 121, 1419, 1418, 0, 1417, 106,
+	317, 93, 1416, 1415, 116, 127, 94, 104, 809, 44,
+	13, 11, 1413, 23, 1406, 1405, 18, 28, 15, 80,
+	98, 96, 58, 40, 1404, 1402, 639, 3, 1401, 24,
+	10, 7, 1400, 36, 1399, 45, 1398, 1397, 17, 73,
+	69, 1396, 84, 1395, 68, 1392, 72, 1, 25, 34,
+	1265, 107, 1389, 1385, 1379, 1376, 82, 60, 21, 1372,
+	70, 76, 64, 1370, 6, 92, 1368, 1367, 88, 66,
+	1366, 117, 1365, 56, 65, 1358, 38, 124, 1235, 1350,
 }
-var yyR1 = []int{
 
+var yyR1 = [...]uint8{
 	0, 1, 2, 2, 2, 2, 2, 2, 2, 2,
-	2, 3, 3, 4, 5, 6, 7, 8, 8, 8,
-	9, 9, 9, 10, 11, 11, 11, 66, 12, 13,
-	13, 14, 14, 14, 14, 14, 15, 15, 16, 16,
-	17, 17, 17, 20, 20, 18, 18, 18, 21, 21,
-	22, 22, 22, 22, 19, 19, 19, 23, 23, 23,
-	23, 23, 23, 23, 23, 23, 24, 24, 24, 25,
-	25, 26, 26, 26, 27, 27, 28, 28, 28, 28,
-	28, 29, 29, 29, 29, 29, 29, 29, 29, 29,
-	29, 30, 30, 30, 30, 30, 30, 30, 31, 31,
-	32, 32, 33, 33, 34, 34, 35, 35, 35, 35,
-	35, 35, 35, 35, 35, 35, 35, 35, 35, 35,
-	35, 35, 35, 35, 36, 36, 37, 37, 37, 38,
-	38, 39, 39, 40, 40, 41, 41, 42, 42, 42,
-	42, 43, 43, 44, 44, 45, 45, 46, 46, 47,
-	48, 48, 48, 49, 49, 49, 50, 50, 50, 52,
-	52, 53, 53, 54, 54, 51, 51, 55, 55, 56,
-	57, 57, 58, 58, 59, 59, 60, 60, 60, 60,
-	60, 61, 61, 62, 62, 63, 63, 64, 65,
+	2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+	2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+	3, 3, 4, 4, 4, 4, 4, 5, 5, 5,
+	5, 148, 148, 155, 155, 147, 36, 6, 6, 6,
+	192, 192, 192, 7, 7, 7, 7, 8, 9, 10,
+	10, 24, 24, 24, 24, 24, 24, 24, 24, 24,
+	24, 24, 24, 24, 11, 143, 12, 12, 12, 12,
+	145, 145, 146, 146, 144, 199, 199, 199, 25, 25,
+	25, 25, 25, 177, 177, 179, 175, 175, 175, 175,
+	175, 175, 175, 175, 175, 175, 175, 175, 175, 214,
+	214, 180, 180, 180, 180, 180, 184, 184, 181, 181,
+	181, 181, 182, 183, 183, 183, 187, 187, 187, 187,
+	156, 156, 156, 178, 178, 178, 178, 213, 190, 190,
+	190, 157, 157, 185, 185, 197, 197, 189, 189, 188,
+	188, 158, 158, 158, 174, 174, 198, 198, 26, 27,
+	27, 27, 27, 27, 176, 176, 176, 173, 173, 173,
+	173, 215, 215, 104, 104, 105, 105, 28, 28, 29,
+	29, 193, 138, 37, 37, 37, 37, 37, 37, 210,
+	210, 211, 211, 211, 30, 30, 30, 30, 30, 30,
+	38, 38, 212, 39, 40, 217, 217, 194, 194, 195,
+	195, 196, 196, 41, 31, 32, 32, 13, 13, 13,
+	13, 119, 119, 130, 130, 130, 106, 106, 14, 110,
+	110, 107, 107, 116, 116, 118, 118, 118, 15, 113,
+	113, 114, 114, 114, 111, 111, 112, 112, 108, 109,
+	109, 115, 115, 115, 16, 16, 16, 17, 17, 18,
+	18, 19, 19, 19, 19, 19, 19, 19, 19, 19,
+	19, 19, 19, 19, 19, 19, 20, 20, 21, 21,
+	23, 23, 22, 22, 22, 22, 33, 34, 35, 35,
+	35, 35, 35, 35, 35, 35, 208, 208, 209, 209,
+	209, 218, 218, 206, 206, 205, 205, 205, 205, 207,
+	207, 42, 42, 142, 142, 142, 142, 160, 160, 161,
+	161, 161, 159, 159, 159, 159, 162, 162, 162, 216,
+	216, 163, 164, 164, 164, 164, 164, 56, 56, 165,
+	165, 165, 165, 165, 165, 165, 165, 165, 165, 165,
+	165, 165, 165, 165, 165, 186, 186, 186, 186, 186,
+	186, 219, 45, 46, 46, 47, 47, 47, 47, 47,
+	47, 47, 47, 47, 47, 48, 48, 49, 49, 49,
+	49, 49, 49, 49, 49, 49, 50, 50, 51, 51,
+	51, 54, 54, 55, 55, 52, 52, 52, 57, 57,
+	58, 58, 58, 58, 58, 58, 58, 53, 53, 53,
+	59, 59, 59, 59, 59, 59, 59, 59, 59, 60,
+	60, 60, 60, 122, 122, 123, 61, 61, 61, 124,
+	124, 125, 126, 126, 126, 127, 127, 127, 127, 129,
+	129, 62, 62, 63, 63, 64, 64, 64, 64, 64,
+	65, 65, 65, 65, 65, 65, 65, 65, 65, 65,
+	65, 65, 66, 66, 67, 67, 67, 67, 67, 67,
+	67, 68, 68, 68, 69, 69, 70, 70, 71, 71,
+	72, 72, 73, 73, 73, 73, 73, 73, 73, 73,
+	73, 73, 73, 73, 73, 73, 73, 73, 73, 73,
+	73, 73, 73, 73, 73, 73, 73, 200, 200, 200,
+	203, 203, 204, 204, 151, 151, 152, 152, 150, 201,
+	201, 149, 149, 149, 154, 154, 153, 202, 202, 74,
+	74, 74, 74, 74, 74, 74, 75, 75, 75, 76,
+	76, 77, 77, 78, 78, 79, 79, 79, 79, 80,
+	80, 80, 80, 80, 81, 81, 82, 82, 83, 83,
+	84, 84, 85, 86, 86, 86, 87, 87, 88, 88,
+	89, 89, 167, 167, 167, 170, 170, 171, 171, 172,
+	102, 102, 117, 120, 120, 120, 120, 121, 121, 121,
+	91, 91, 92, 92, 128, 128, 168, 168, 169, 90,
+	90, 93, 93, 94, 99, 99, 96, 96, 96, 103,
+	103, 103, 97, 97, 98, 98, 98, 100, 100, 100,
+	101, 101, 95, 95, 95, 132, 132, 133, 133, 131,
+	131, 44, 44, 43, 43, 134, 134, 135, 135, 135,
+	135, 136, 136, 191, 191, 137, 139, 139, 140, 140,
+	141, 141, 141, 141, 141, 141, 141, 141, 141, 141,
+	141, 141, 141, 141, 141, 141, 141, 141, 141, 141,
+	141, 141, 141, 141, 166,
 }
-var yyR2 = []int{
 
+var yyR2 = [...]int8{
 	0, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 12, 3, 7, 8, 7, 3, 5, 8, 4,
-	6, 7, 4, 5, 4, 5, 5, 0, 2, 0,
-	2, 1, 2, 1, 1, 1, 0, 1, 1, 3,
-	1, 2, 3, 1, 1, 0, 1, 2, 1, 3,
-	3, 3, 3, 5, 0, 1, 2, 1, 1, 2,
-	3, 2, 3, 2, 2, 2, 1, 3, 3, 1,
-	3, 0, 5, 5, 0, 2, 1, 3, 3, 2,
-	3, 3, 3, 4, 3, 4, 5, 6, 3, 4,
-	4, 1, 1, 1, 1, 1, 1, 1, 2, 1,
-	1, 3, 3, 3, 1, 3, 1, 1, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 2, 3,
-	4, 5, 4, 1, 1, 1, 1, 1, 1, 3,
-	4, 1, 2, 4, 2, 1, 3, 1, 1, 1,
-	1, 0, 3, 0, 2, 0, 3, 1, 3, 2,
-	0, 1, 1, 0, 2, 4, 0, 2, 4, 0,
-	3, 1, 3, 1, 3, 0, 5, 1, 3, 3,
-	0, 2, 0, 3, 0, 1, 1, 1, 1, 1,
-	1, 0, 1, 0, 1, 0, 2, 1, 0,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 4, 15, 7, 17, 3, 6, 3, 6, 3,
+	6, 3, 3, 1, 3, 6, 7, 10, 12, 11,
+	0, 1, 1, 6, 6, 8, 8, 9, 8, 3,
+	3, 2, 3, 3, 5, 3, 3, 4, 12, 12,
+	9, 2, 2, 5, 5, 3, 3, 4, 5, 6,
+	1, 2, 3, 3, 4, 0, 3, 4, 5, 6,
+	4, 4, 4, 2, 4, 0, 1, 2, 3, 2,
+	4, 3, 2, 3, 3, 3, 3, 3, 1, 0,
+	1, 7, 7, 7, 8, 8, 1, 2, 1, 2,
+	4, 5, 12, 0, 4, 4, 1, 2, 2, 2,
+	0, 3, 3, 0, 3, 3, 2, 0, 0, 1,
+	1, 1, 1, 0, 1, 0, 1, 1, 3, 2,
+	5, 0, 1, 1, 6, 5, 0, 2, 5, 6,
+	7, 8, 4, 4, 0, 2, 3, 3, 3, 3,
+	3, 0, 1, 1, 3, 1, 3, 4, 3, 4,
+	3, 3, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 0, 1, 1, 3, 3, 3, 3, 3, 4,
+	3, 4, 1, 3, 3, 0, 1, 0, 1, 1,
+	3, 3, 2, 2, 2, 2, 3, 4, 3, 5,
+	4, 0, 2, 1, 1, 1, 1, 3, 3, 1,
+	3, 1, 2, 1, 1, 0, 3, 2, 10, 2,
+	3, 0, 1, 1, 0, 1, 1, 2, 3, 1,
+	2, 0, 3, 3, 6, 7, 6, 1, 3, 1,
+	2, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 3, 1, 1,
+	1, 3, 1, 1, 2, 3, 3, 2, 3, 3,
+	6, 4, 5, 7, 4, 4, 1, 1, 0, 2,
+	2, 1, 1, 1, 3, 2, 3, 4, 4, 1,
+	2, 0, 1, 1, 3, 3, 3, 0, 1, 1,
+	2, 3, 3, 4, 3, 2, 1, 1, 1, 0,
+	1, 2, 1, 4, 6, 4, 4, 1, 3, 1,
+	2, 3, 3, 4, 2, 3, 3, 4, 7, 5,
+	5, 3, 2, 3, 3, 1, 1, 1, 2, 2,
+	3, 0, 2, 0, 2, 1, 2, 2, 1, 1,
+	2, 2, 1, 2, 2, 0, 2, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 3, 1, 2,
+	3, 1, 1, 1, 3, 0, 1, 2, 1, 3,
+	3, 4, 4, 5, 3, 3, 5, 0, 1, 2,
+	1, 1, 2, 3, 2, 3, 2, 2, 2, 1,
+	3, 3, 3, 0, 1, 4, 1, 3, 3, 0,
+	2, 6, 1, 1, 1, 0, 2, 3, 3, 0,
+	1, 0, 2, 1, 1, 1, 3, 3, 2, 3,
+	3, 6, 3, 4, 3, 4, 6, 5, 6, 3,
+	4, 4, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 2, 1, 1, 3, 3, 3,
+	1, 3, 1, 1, 1, 2, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 2, 1, 2,
+	3, 4, 1, 3, 5, 3, 3, 3, 4, 5,
+	4, 2, 3, 4, 0, 2, 1, 3, 5, 0,
+	3, 0, 2, 5, 1, 1, 2, 0, 3, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 3,
+	4, 1, 2, 4, 2, 1, 3, 3, 5, 1,
+	1, 1, 1, 1, 0, 3, 0, 2, 0, 3,
+	1, 3, 3, 0, 1, 1, 0, 2, 0, 1,
+	2, 4, 0, 4, 5, 0, 1, 3, 2, 2,
+	1, 3, 1, 0, 3, 3, 4, 0, 1, 2,
+	0, 3, 1, 3, 1, 3, 0, 1, 3, 0,
+	5, 1, 3, 3, 1, 3, 3, 3, 1, 3,
+	2, 3, 1, 2, 2, 4, 3, 1, 1, 1,
+	1, 1, 1, 1, 3, 0, 2, 0, 3, 1,
+	1, 0, 1, 0, 1, 0, 1, 1, 1, 1,
+	1, 0, 1, 0, 2, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 0,
 }
-var yyChk = []int{
-
-	-1000, -1, -2, -3, -4, -5, -6, -7, -8, -9,
-	-10, -11, 4, 5, 6, 7, 33, 83, 84, 86,
-	85, -14, 49, 50, 51, 52, -12, -66, -12, -12,
-	-12, -12, 87, -62, 89, 93, -59, 89, 91, 87,
-	87, 88, 89, -3, 17, -15, 18, -13, 29, -25,
-	35, 8, -55, -56, -41, -64, 35, -58, 92, 88,
-	-64, 35, 87, -64, 35, -57, 92, -64, -57, -16,
-	-17, 73, -20, 35, -28, -35, -29, 67, 44, -42,
-	-41, -37, -64, -36, -38, 20, 36, 37, 38, 25,
-	71, 72, 48, 92, 28, 78, 15, -25, 33, 76,
-	-25, 53, 45, 76, 35, 67, -64, -65, 35, -65,
-	90, 35, 20, 64, -64, 8, 53, -18, -64, 19,
-	76, 65, 66, -30, 21, 67, 23, 24, 22, 68,
-	69, 70, 71, 72, 73, 74, 75, 45, 46, 47,
-	39, 40, 41, 42, -28, -35, -28, -3, -34, -35,
-	-35, 44, 44, 44, 44, -39, -20, -40, 79, 81,
-	-52, 44, -55, 35, -27, 9, -56, -20, -64, -65,
-	20, -63, 94, -60, 86, 84, 32, 85, 12, 35,
-	35, 35, -65, -21, -22, -24, 44, 35, -17, -64,
-	73, -28, -28, -35, -33, 44, 21, 23, 24, -35,
-	-35, 25, 67, -35, -35, -35, -35, -35, -35, -35,
-	-35, 112, 112, 53, 112, -35, 112, -16, 18, -16,
-	-3, 82, -40, -39, -20, -20, -31, 28, -3, -53,
-	-41, -27, -45, 12, -28, 64, -64, -65, -61, 90,
-	-27, 53, -23, 54, 55, 56, 57, 58, 60, 61,
-	-19, 35, 19, -22, -3, 76, -34, -3, -33, -35,
-	-35, 65, 25, -35, 112, -16, 112, 112, 82, 80,
-	-51, 64, -32, -33, 112, 53, -45, -49, 14, 13,
-	35, 35, -43, 10, -22, -22, 54, 59, 54, 59,
-	54, 54, 54, -26, 62, 63, 35, 112, 112, 35,
-	112, 112, 65, -35, 112, -20, 30, 53, -41, -49,
-	-35, -46, -47, -35, -65, -44, 11, 13, 64, 54,
-	54, 88, 88, -35, 31, -33, 53, 53, -48, 26,
-	27, -45, -28, -34, -28, 44, 44, 6, -35, -47,
-	-49, -54, -64, -54, -55, -50, 16, 34, 112, 53,
-	112, 6, 21, -64, -64, -64,
+
+var yyChk = [...]int16{
+	-1000, -1, -2, -3, -5, -6, -7, -8, -9, -10,
+	-24, -25, -26, -27, -28, -29, -30, -31, -32, -33,
+	-34, -35, -36, -38, -39, -40, -41, -13, -14, -15,
+	-16, -4, 133, -148, 5, 6, 7, 8, 56, -11,
+	113, -12, 114, 116, 115, 117, -210, 18, 20, 21,
+	57, 26, 27, 4, 28, -212, 29, 30, 89, -130,
+	34, 35, 36, 37, 68, 118, 50, 75, 31, 32,
+	33, -47, 76, 77, 78, 79, -47, -44, 137, -47,
+	-45, -219, -45, -45, -45, -45, -166, -135, 44, 68,
+	-143, 75, 55, 40, 4, -190, -137, -131, -43, 127,
+	-145, 93, 131, 124, 75, 135, 136, 134, -146, -144,
+	2, -91, 68, -134, 127, -131, 129, 125, -43, 126,
+	127, -131, -45, -45, -61, -42, -193, -138, 31, 17,
+	-140, 75, -141, 19, -137, 28, 29, 30, 74, 107,
+	23, 24, 20, 134, 122, 121, 123, 141, 142, 21,
+	34, 26, 138, 139, 155, 156, 157, 158, 159, -55,
+	-54, -64, -73, -65, -63, 94, 68, -80, -79, 61,
+	-75, -200, -74, -76, 41, 58, 59, 60, 46, -66,
+	-139, 75, -141, 99, 100, 72, -137, 130, 51, 119,
+	6, 135, 136, 117, 108, 47, 48, -137, -218, 125,
+	-137, -218, -137, 113, -45, -45, -45, -45, -45, 75,
+	125, 75, -110, -107, -116, -61, 125, 75, 75, -17,
+	-18, -19, 75, 4, 5, 7, 8, 113, 115, 114,
+	126, 38, 57, 27, 127, 134, 36, -17, -4, -5,
+	4, -4, -148, 38, 39, 38, 39, 38, 39, -4,
+	-148, -155, -147, 75, -4, -148, -192, -137, 154, -46,
+	52, -61, 9, -99, -103, -96, 75, -97, -98, -79,
+	-137, -166, -61, 44, -142, -163, -137, -160, 2, -161,
+	-159, -137, 106, 55, 126, 126, 69, -137, -133, 130,
+	125, -137, 127, -146, -137, 6, 40, -92, -79, 125,
+	-137, 75, 75, 125, -137, -132, 130, -132, 125, -61,
+	-61, -211, -137, 58, -37, 18, -3, -5, -6, -7,
+	-8, -9, -37, -37, -37, -137, 104, 104, 69, 80,
+	-67, 42, 94, 44, 23, 45, 43, 95, 96, 97,
+	98, 99, 100, 101, 102, 103, 106, 107, 69, 70,
+	71, 63, 64, 65, 66, 92, 93, -63, -64, -73,
+	-64, -3, -72, -73, 62, 162, 163, -73, 68, -203,
+	25, 68, 68, 104, 104, 68, -77, -54, -78, 109,
+	111, -137, -206, -205, -61, -209, -89, 68, -137, -208,
+	44, 10, 15, 9, 42, 125, 127, -48, -217, -137,
+	-137, -217, -217, -106, -61, -106, 125, 75, -118, 80,
+	133, 17, -116, -113, 75, 91, 80, -19, 91, 190,
+	190, -45, -83, 13, -83, -4, 80, -91, -83, -134,
+	16, -61, -122, -123, 160, -61, 80, 75, 80, 75,
+	-79, -100, 56, -137, 58, 55, 69, 161, -61, 190,
+	80, -165, -164, -137, 56, 2, -159, 80, -216, 56,
+	69, -216, -159, -137, -137, -22, 75, 58, -137, 75,
+	94, -133, -137, 127, -145, -3, 190, 80, 75, -144,
+	2, -161, 128, -132, 91, -105, -137, 41, 75, -61,
+	-211, 59, -140, 75, -141, -140, -137, -54, -73, -68,
+	141, 142, 38, -71, 68, 42, 44, 45, -73, 24,
+	-73, 46, 94, -73, -73, -73, -73, -73, -73, -73,
+	-73, -73, -137, -137, -63, -63, 190, 190, 80, 190,
+	58, 58, -73, 68, -137, 190, -50, -51, 101, -54,
+	75, -3, -139, -140, -141, 75, -139, -141, 190, -50,
+	39, 112, -78, -77, -54, -54, 80, 75, 40, 101,
+	-209, -61, 75, 58, -63, -73, -61, -61, -50, 74,
+	-49, 39, 82, 148, 149, 150, 151, 152, 153, 154,
+	-194, -195, -196, 133, -137, -119, 80, -106, -106, -107,
+	75, 75, -114, 6, 129, 58, 75, -20, -21, 75,
+	101, -18, -20, -48, -88, -89, 14, -88, -147, 40,
+	-88, 52, -91, 56, 56, 68, -122, -96, 75, 75,
+	75, 106, -100, -137, -95, -54, 55, -79, -95, 190,
+	-163, -180, -181, -137, -190, 143, -184, -182, 146, 144,
+	46, 94, 55, 91, 131, 106, -137, 147, 40, 146,
+	68, 68, -159, -162, -137, 58, 59, -216, -162, -191,
+	132, -191, 75, -160, -161, 41, 75, -137, 127, -199,
+	133, -79, -177, -179, 75, -104, 75, 75, 80, 68,
+	-72, -3, -71, -73, -73, 68, 92, 46, -137, -73,
+	-204, -201, -137, 160, 80, 190, -52, -137, 40, 104,
+	190, 104, 190, -50, 112, 110, -205, 75, 75, 190,
+	-209, -208, 80, 9, -83, -137, 80, -137, -137, 75,
+	-61, -119, 57, 52, 58, 128, 104, 9, -120, 17,
+	57, -84, -85, -73, -120, 68, -120, -61, -69, 51,
+	-3, -93, -94, -79, -93, -104, -62, 10, -101, -137,
+	58, 161, -157, 126, 54, -157, -137, 131, -182, 146,
+	68, 54, 46, -186, -80, -137, -200, 100, 99, 68,
+	7, 54, -137, 56, 54, 58, 59, -137, 68, 68,
+	59, -56, 58, -56, -162, 91, -137, 91, 58, -137,
+	146, -137, 2, 80, -175, -174, 120, 121, 122, 115,
+	116, -137, 2, 119, -215, 80, -137, -179, -137, -3,
+	190, 190, 92, -73, -73, 58, 190, -202, 13, -201,
+	14, -51, -139, 101, -139, 190, -54, 75, -207, 75,
+	-137, 75, -73, -57, -58, -60, 68, -140, 75, -141,
+	-88, 17, -196, -137, 125, -23, -22, -21, -23, 7,
+	155, 42, 80, -86, 49, 50, -3, -122, 91, -70,
+	-71, 91, 80, 69, -62, 190, -83, -63, -95, -197,
+	-137, -197, 54, -137, -185, -157, 68, -54, -197, 59,
+	59, -54, -186, -137, 40, -54, -54, 190, 80, 190,
+	80, 190, 75, 75, -137, 146, -179, -163, 123, -180,
+	-184, -214, 123, -214, -137, 123, -157, -136, 128, 69,
+	128, 75, -176, 190, -73, 190, -149, -154, 138, 139,
+	14, -202, -72, 75, -209, -62, 80, -59, 81, 82,
+	83, 84, 85, 87, 88, -53, -123, 75, 40, -58,
+	-3, 104, 104, -170, -171, 52, 75, -61, 2, 80,
+	-121, 157, 158, -121, 155, -85, -87, -137, 190, -91,
+	56, 53, 80, 53, -94, -54, -83, -88, 68, 68,
+	68, 54, -197, -54, 190, 68, 190, 68, 190, 190,
+	59, 58, -213, -213, -137, -175, -163, -137, -163, 54,
+	-137, -137, 75, -162, -137, 2, -173, 80, -137, 57,
+	-153, 45, -73, -84, -149, -81, 11, -58, -58, 81,
+	86, 81, 86, 81, 81, 81, -124, -53, 75, 40,
+	-123, 75, -140, 190, 190, -140, -140, 9, -172, -102,
+	-137, -117, 75, -111, -112, -108, -109, 75, -22, 159,
+	156, -137, -69, 51, -93, -71, -88, -189, -188, -137,
+	-189, -189, 68, 68, 190, -92, -54, 190, 68, 2,
+	68, -163, 56, -137, -173, -216, -216, -153, -137, -82,
+	12, 14, 91, 81, 81, -125, -126, 89, 129, 90,
+	-124, -124, -123, -57, -111, 80, 58, -115, 129, -108,
+	14, 75, -90, 91, -70, -168, -169, 40, 190, 80,
+	-158, 68, 49, 50, 190, 190, -189, -189, 190, 190,
+	-189, -189, -137, -198, 106, -137, 55, -137, 55, 92,
+	-151, 140, -63, -72, -63, -157, -124, -62, -117, 75,
+	-91, 59, 58, 53, -169, -90, -137, -156, -188, 59,
+	-156, -156, 190, 190, 145, 190, 190, -198, -137, -153,
+	-83, -152, -150, -137, -127, 17, -81, 75, 138, 54,
+	-90, -91, 132, -137, 190, -156, -156, -61, -178, -178,
+	-88, 80, 40, 68, 81, 13, 11, -82, 7, -137,
+	58, -158, 68, 132, -137, -173, -167, 22, -150, 68,
+	-129, -128, -137, 14, 14, -151, -93, -92, -137, 58,
+	-170, -171, -137, -204, 190, 80, -83, 190, -120, 68,
+	190, -137, -88, -183, 190, -50, -167, 91, 190, -120,
+	8, 7, -187, -137, 56, -187, -137, 46, 55,
 }
-var yyDef = []int{
 
+var yyDef = [...]int16{
 	0, -2, 1, 2, 3, 4, 5, 6, 7, 8,
-	9, 10, 27, 27, 27, 27, 27, 183, 174, 0,
-	0, 0, 31, 33, 34, 35, 36, 29, 0, 0,
-	0, 0, 172, 0, 0, 184, 0, 0, 175, 0,
-	170, 0, 170, 12, 32, 0, 37, 28, 0, 0,
-	69, 0, 16, 167, 0, 135, 187, 0, 0, 0,
-	188, 187, 0, 188, 0, 0, 0, 0, 0, 0,
-	38, 40, 45, 187, 43, 44, 76, 0, 0, 106,
-	107, 0, 135, 0, 123, 0, 137, 138, 139, 140,
-	126, 127, 128, 124, 125, 0, 30, 159, 0, 0,
-	74, 0, 0, 0, 188, 0, 185, 19, 0, 22,
-	0, 24, 171, 0, 188, 0, 0, 41, 46, 0,
+	9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
+	19, 20, 21, 22, 23, 24, 25, 26, 27, 28,
+	29, 30, 631, 0, 361, 361, 361, 361, 361, 674,
+	-2, -2, 635, 0, 633, 361, 361, 311, 0, 0,
+	0, 0, 0, 361, 361, 361, 361, 361, 0, 0,
+	0, 0, 0, 0, 0, 189, 190, 202, 223, 224,
+	225, 0, 365, 368, 369, 372, 0, 0, 632, 0,
+	50, 363, 0, 0, 0, 0, 61, 674, 0, 0,
+	-2, 637, 638, 639, 640, 0, 0, 627, 0, 0,
+	0, 0, 139, 140, 645, 629, 630, 634, 80, 71,
+	72, 0, 0, 0, 0, 0, 636, 0, 0, 0,
+	625, 625, 0, 0, 191, 0, 0, 0, 0, 0,
+	426, -2, 649, 312, 182, 650, 651, 652, 653, 654,
+	655, 656, 657, 658, 659, 660, 661, 662, 663, 664,
+	665, 666, 667, 668, 669, 670, 671, 672, 673, 214,
+	393, -2, -2, 445, 0, 0, 0, 482, 483, 484,
+	0, 498, 0, 502, 0, 549, 550, 551, 552, 553,
+	545, 645, 647, 536, 537, 538, 646, 529, 530, 531,
+	532, 533, 534, 535, 0, 462, 463, 215, 0, 301,
+	302, 287, 298, 0, 375, 205, 0, 205, 205, 213,
+	0, 0, 235, 229, 231, 233, 234, 648, 0, 0,
+	257, 259, 261, 262, 263, 264, 265, 266, 267, 268,
+	269, 270, 271, 272, 273, 274, 275, 0, 0, 0,
+	361, 39, 558, 366, 367, 370, 371, 373, 374, 35,
+	558, 0, 43, 590, 37, 558, 635, 51, 52, 362,
+	0, 423, 0, 59, 60, 604, 645, 0, 608, 612,
+	646, 62, 63, 0, 0, 313, 0, 65, 66, -2,
+	319, 329, 329, 0, 0, 0, 0, 0, 0, 0,
+	627, 76, 0, 81, 0, 0, 0, 0, 592, 0,
+	-2, 0, 0, 625, 0, 0, 0, 0, 0, 178,
+	191, 180, 192, 193, 194, 198, 183, 184, 185, 186,
+	187, 188, 195, 196, 197, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 91, 92, 93,
-	94, 95, 96, 97, 79, 0, 0, 0, 0, 104,
-	118, 0, 0, 0, 0, 0, 0, 131, 0, 0,
-	0, 0, 74, 70, 145, 0, 168, 169, 136, 17,
-	173, 0, 0, 188, 181, 176, 177, 178, 179, 180,
-	23, 25, 26, 74, 48, 54, 0, 66, 39, 47,
-	42, 77, 78, 81, 82, 0, 0, 0, 0, 84,
-	0, 88, 0, 110, 111, 112, 113, 114, 115, 116,
-	117, 80, 108, 0, 109, 104, 119, 0, 0, 0,
-	0, 129, 132, 0, 0, 134, 165, 0, 99, 0,
-	161, 145, 153, 0, 75, 0, 186, 20, 0, 182,
-	141, 0, 0, 57, 58, 0, 0, 0, 0, 0,
-	71, 55, 0, 0, 0, 0, 0, 0, 83, 85,
-	0, 0, 89, 105, 120, 0, 122, 90, 130, 0,
-	13, 0, 98, 100, 160, 0, 153, 15, 0, 0,
-	188, 21, 143, 0, 49, 52, 59, 0, 61, 0,
-	63, 64, 65, 50, 0, 0, 56, 51, 68, 67,
-	102, 103, 0, 86, 121, 133, 0, 0, 162, 14,
-	154, 146, 147, 150, 18, 145, 0, 0, 0, 60,
-	62, 0, 0, 87, 0, 101, 0, 0, 149, 151,
-	152, 153, 144, 142, 53, 0, 0, 0, 155, 148,
-	156, 0, 163, 0, 166, 11, 0, 0, 72, 0,
-	73, 157, 0, 164, 0, 158,
+	0, 0, 0, 0, 0, 0, 0, 0, 464, 465,
+	466, 467, 468, 469, 470, 0, 0, 448, 443, 444,
+	443, 0, 0, -2, 485, 0, 0, 497, 0, 499,
+	0, 0, 0, 0, 0, 0, 0, 0, 541, 0,
+	0, 216, 286, 303, 0, 288, 289, 0, 298, 0,
+	0, 0, 0, 296, 297, 0, 0, 0, 200, 206,
+	207, 203, 204, 221, 226, 218, 0, 648, 228, 0,
+	0, 0, 232, 241, 0, 0, 0, 260, 0, 41,
+	42, 375, 568, 0, 568, 31, 0, 0, 568, 0,
+	364, 590, 0, 424, 0, 423, 0, 610, 0, 645,
+	613, 614, 0, -2, 618, 619, 0, 0, 0, 75,
+	138, 331, 339, 332, 0, 67, 320, 0, 0, 329,
+	330, 0, 325, 643, 643, 82, 282, 283, 83, 317,
+	0, 0, 77, 0, 0, 85, 591, 0, 95, 90,
+	91, 92, 0, 0, 0, 162, 175, 626, 163, 177,
+	179, 199, 427, 648, 649, 428, 181, 394, 450, 0,
+	-2, -2, 473, 452, 0, 0, 0, 0, 454, 0,
+	0, 459, 0, 488, 489, 490, 491, 492, 493, 494,
+	495, 496, 503, 0, 446, 447, 449, 486, 0, 487,
+	505, 506, 480, 519, 511, 500, 0, 386, 388, 395,
+	645, 0, 546, 0, -2, -2, 547, 647, 507, 0,
+	0, 539, 542, 0, 0, 544, 0, 305, 0, 0,
+	291, 298, 648, 299, 300, 570, 294, 295, 558, 653,
+	376, 377, 378, 379, 380, 381, 382, 383, 384, 385,
+	201, 208, 209, 0, 0, 217, 0, 221, 220, 230,
+	0, 237, 0, 242, 243, 239, 0, 0, 276, 278,
+	279, 258, 0, 0, 583, 569, 0, 583, 44, 0,
+	583, 0, 0, 0, 0, 0, 441, 605, 645, 611,
+	609, 0, 616, 617, 606, 622, 623, 483, 607, 64,
+	314, 315, 316, 0, 0, 116, 0, 118, 0, 0,
+	340, 0, 0, 0, 344, 0, 352, 0, 0, 0,
+	0, 0, 321, 322, 326, 327, 328, 0, 324, 0,
+	0, 0, 284, 73, 318, 628, 74, 78, 0, 84,
+	0, 593, -2, 0, 158, 171, 173, 95, 0, 0,
+	0, 0, 453, 455, 0, 0, 0, 460, 0, 481,
+	0, 527, 519, 0, 0, 501, 389, 396, 0, 0,
+	461, 0, 508, 0, 540, 0, 304, 306, 0, 0,
+	292, 0, 0, 0, 568, 0, 0, 0, 212, 222,
+	227, 219, 236, 0, 240, 0, 0, 0, 40, 0,
+	0, 559, 560, 563, 36, 0, 38, 423, 53, 0,
+	475, 54, 601, 0, 441, 0, 558, 0, 615, 620,
+	621, 0, 145, 141, 142, 145, 117, 143, 119, 0,
+	0, 145, 341, 342, 355, 356, 357, 0, 0, 0,
+	0, 345, 346, 0, 351, 353, 354, 0, 0, 0,
+	0, 0, 337, 0, 323, 0, 644, 0, 285, 79,
+	0, 0, 89, 95, 93, 96, 138, 109, 109, 0,
+	641, 0, 108, 0, 159, 0, 172, 164, 176, 0,
+	478, 479, 0, 0, 457, 504, 510, 521, 0, 527,
+	0, 387, 397, 390, 548, 509, 543, 307, 308, 309,
+	290, 298, 571, 441, 398, 407, 0, 419, 648, 649,
+	575, 0, 210, 211, 0, -2, 280, 277, 256, 587,
+	587, 0, 0, 566, 564, 565, 0, 590, 0, 474,
+	476, 0, 0, 0, 558, 425, 568, 442, 624, 0,
+	146, 0, 0, 0, 145, 144, 0, 0, 0, 358,
+	359, 0, 343, 347, 0, 0, 0, 333, 0, 335,
+	0, 336, 137, 137, 86, 0, 0, 97, 0, 99,
+	0, 0, 110, 0, 102, 0, 0, 0, 642, 0,
+	0, 174, -2, 451, 458, 456, 512, 0, 524, 525,
+	0, 521, 520, 310, 293, 554, 0, 0, 410, 411,
+	0, 0, 0, 0, 0, 429, 407, 408, 0, 0,
+	0, 0, 0, 33, 576, 0, 46, 244, 255, 0,
+	584, 588, 0, 585, 0, 561, 562, 0, 45, 0,
+	0, 55, 0, 56, 602, 603, 568, 58, 0, 0,
+	0, 0, 0, 0, 120, 0, 360, 0, 349, 350,
+	0, 338, 0, 0, 87, 94, 98, 0, 101, 105,
+	103, 104, 106, 107, 0, 161, 165, 0, 329, 329,
+	522, 0, 0, 528, 513, 556, 0, 399, 405, 412,
+	0, 414, 0, 416, 417, 418, 400, 429, 408, 0,
+	429, 648, 409, 404, 422, 420, 421, 0, 244, 578,
+	0, 580, -2, 251, 245, 246, 0, 249, 281, 589,
+	586, 567, 599, 0, 596, 477, 57, 0, 147, 151,
+	0, 0, 0, 0, 121, 0, 0, 334, 0, 70,
+	0, 100, 0, 156, 166, 0, 0, 0, 526, 514,
+	0, 0, 0, 413, 415, 430, 0, 432, 433, 434,
+	401, 402, 429, 441, 577, 0, 579, 590, 0, 247,
+	0, 250, 47, 0, 474, 599, 597, 0, 130, 0,
+	149, 0, 152, 153, 130, 130, 0, 0, 0, 348,
+	0, 0, 156, 155, 0, 167, 168, 169, 170, 0,
+	558, 0, 557, 555, 406, 435, 403, 554, 581, 582,
+	238, 0, 248, 0, 599, 49, 590, 112, 148, 0,
+	111, 113, 130, 130, 0, 133, 133, 154, 157, 523,
+	568, 515, 516, 0, 0, 0, 556, 252, 253, 0,
+	48, 598, 0, 0, 151, 114, 115, 0, 68, 69,
+	572, 0, 0, 439, 436, 0, 0, 514, 0, 131,
+	132, 150, 0, 0, 329, 136, 575, 0, 517, 519,
+	0, 440, 594, 437, 438, 558, 600, 0, 134, 135,
+	583, 576, 0, 0, 431, 0, 568, 123, 32, 0,
+	518, 595, 572, 122, 573, 0, 583, 0, 574, 34,
+	0, 0, 124, 126, 0, 125, 127, 128, 129,
 }
-var yyTok1 = []int{
 
+var yyTok1 = [...]uint8{
 	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 75, 68, 3,
-	44, 112, 73, 71, 53, 72, 76, 74, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 103, 95, 3,
+	68, 190, 101, 99, 80, 100, 104, 102, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	46, 45, 47, 3, 3, 3, 3, 3, 3, 3,
+	70, 69, 71, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 70, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 97, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 69, 3, 48,
+	3, 3, 3, 3, 96, 3, 72,
 }
-var yyTok2 = []int{
 
+var yyTok2 = [...]uint8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
 	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
-	42, 43, 49, 50, 51, 52, 54, 55, 56, 57,
-	58, 59, 60, 61, 62, 63, 64, 65, 66, 67,
-	77, 78, 79, 80, 81, 82, 83, 84, 85, 86,
-	87, 88, 89, 90, 91, 92, 93, 94, 95, 96,
-	97, 98, 99, 100, 101, 102, 103, 104, 105, 106,
-	107, 108, 109, 110, 111,
+	42, 43, 44, 45, 46, 47, 48, 49, 50, 51,
+	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
+	62, 63, 64, 65, 66, 67, 73, 74, 75, 76,
+	77, 78, 79, 81, 82, 83, 84, 85, 86, 87,
+	88, 89, 90, 91, 92, 93, 94, 98, 105, 106,
+	107, 108, 109, 110, 111, 112, 113, 114, 115, 116,
+	117, 118, 119, 120, 121, 122, 123, 124, 125, 126,
+	127, 128, 129, 130, 131, 132, 133, 134, 135, 136,
+	137, 138, 139, 140, 141, 142, 143, 144, 145, 146,
+	147, 148, 149, 150, 151, 152, 153, 154, 155, 156,
+	157, 158, 159, 160, 161, 162, 163, 164, 165, 166,
+	167, 168, 169, 170, 171, 172, 173, 174, 175, 176,
+	177, 178, 179, 180, 181, 182, 183, 184, 185, 186,
+	187, 188, 189,
 }
-var yyTok3 = []int{
+
+var yyTok3 = [...]int8{
 	0,
 }
 
+var yyErrorMessages = [...]struct {
+	state int
+	token int
+	msg   string
+}{}
+
 //line yaccpar:1
 
 /*	parser for yacc output	*/
 
-var yyDebug = 0
+var (
+	yyDebug        = 0
+	yyErrorVerbose = false
+)
 
 type yyLexer interface {
 	Lex(lval *yySymType) int
 	Error(s string)
 }
 
+type yyParser interface {
+	Parse(yyLexer) int
+	Lookahead() int
+}
+
+type yyParserImpl struct {
+	lval  yySymType
+	stack [yyInitialStackSize]yySymType
+	char  int
+}
+
+func (p *yyParserImpl) Lookahead() int {
+	return p.char
+}
+
+func yyNewParser() yyParser {
+	return &yyParserImpl{}
+}
+
 const yyFlag = -1000
 
 func yyTokname(c int) string {
-	// 4 is TOKSTART above
-	if c >= 4 && c-4 < len(yyToknames) {
-		if yyToknames[c-4] != "" {
-			return yyToknames[c-4]
+	if c >= 1 && c-1 < len(yyToknames) {
+		if yyToknames[c-1] != "" {
+			return yyToknames[c-1]
 		}
 	}
 	return __yyfmt__.Sprintf("tok-%v", c)
@@ -571,51 +2263,127 @@ func yyStatname(s int) string {
 	return __yyfmt__.Sprintf("state-%v", s)
 }
 
-func yylex1(lex yyLexer, lval *yySymType) int {
-	c := 0
-	char := lex.Lex(lval)
+func yyErrorMessage(state, lookAhead int) string {
+	const TOKSTART = 4
+
+	if !yyErrorVerbose {
+		return "syntax error"
+	}
+
+	for _, e := range yyErrorMessages {
+		if e.state == state && e.token == lookAhead {
+			return "syntax error: " + e.msg
+		}
+	}
+
+	res := "syntax error: unexpected " + yyTokname(lookAhead)
+
+	// To match Bison, suggest at most four expected tokens.
+	expected := make([]int, 0, 4)
+
+	// Look for shiftable tokens.
+	base := int(yyPact[state])
+	for tok := TOKSTART; tok-1 < len(yyToknames); tok++ {
+		if n := base + tok; n >= 0 && n < yyLast && int(yyChk[int(yyAct[n])]) == tok {
+			if len(expected) == cap(expected) {
+				return res
+			}
+			expected = append(expected, tok)
+		}
+	}
+
+	if yyDef[state] == -2 {
+		i := 0
+		for yyExca[i] != -1 || int(yyExca[i+1]) != state {
+			i += 2
+		}
+
+		// Look for tokens that we accept or reduce.
+		for i += 2; yyExca[i] >= 0; i += 2 {
+			tok := int(yyExca[i])
+			if tok < TOKSTART || yyExca[i+1] == 0 {
+				continue
+			}
+			if len(expected) == cap(expected) {
+				return res
+			}
+			expected = append(expected, tok)
+		}
+
+		// If the default action is to accept or reduce, give up.
+		if yyExca[i+1] != 0 {
+			return res
+		}
+	}
+
+	for i, tok := range expected {
+		if i == 0 {
+			res += ", expecting "
+		} else {
+			res += " or "
+		}
+		res += yyTokname(tok)
+	}
+	return res
+}
+
+func yylex1(lex yyLexer, lval *yySymType) (char, token int) {
+	token = 0
+	char = lex.Lex(lval)
 	if char <= 0 {
-		c = yyTok1[0]
+		token = int(yyTok1[0])
 		goto out
 	}
 	if char < len(yyTok1) {
-		c = yyTok1[char]
+		token = int(yyTok1[char])
 		goto out
 	}
 	if char >= yyPrivate {
 		if char < yyPrivate+len(yyTok2) {
-			c = yyTok2[char-yyPrivate]
+			token = int(yyTok2[char-yyPrivate])
 			goto out
 		}
 	}
 	for i := 0; i < len(yyTok3); i += 2 {
-		c = yyTok3[i+0]
-		if c == char {
-			c = yyTok3[i+1]
+		token = int(yyTok3[i+0])
+		if token == char {
+			token = int(yyTok3[i+1])
 			goto out
 		}
 	}
 
 out:
-	if c == 0 {
-		c = yyTok2[1] /* unknown char */
+	if token == 0 {
+		token = int(yyTok2[1]) /* unknown char */
 	}
 	if yyDebug >= 3 {
-		__yyfmt__.Printf("lex %s(%d)\n", yyTokname(c), uint(char))
+		__yyfmt__.Printf("lex %s(%d)\n", yyTokname(token), uint(char))
 	}
-	return c
+	return char, token
 }
 
 func yyParse(yylex yyLexer) int {
+	return yyNewParser().Parse(yylex)
+}
+
+func (yyrcvr *yyParserImpl) Parse(yylex yyLexer) int {
 	var yyn int
-	var yylval yySymType
 	var yyVAL yySymType
-	yyS := make([]yySymType, yyMaxDepth)
+	var yyDollar []yySymType
+	_ = yyDollar // silence set and not used
+	yyS := yyrcvr.stack[:]
 
 	Nerrs := 0   /* number of errors */
 	Errflag := 0 /* error recovery flag */
 	yystate := 0
-	yychar := -1
+	yyrcvr.char = -1
+	yytoken := -1 // yyrcvr.char translated into internal numbering
+	defer func() {
+		// Make sure we report no lookahead when not parsing.
+		yystate = -1
+		yyrcvr.char = -1
+		yytoken = -1
+	}()
 	yyp := -1
 	goto yystack
 
@@ -628,7 +2396,7 @@ ret1:
 yystack:
 	/* put a state and value onto the stack */
 	if yyDebug >= 4 {
-		__yyfmt__.Printf("char %v in %v\n", yyTokname(yychar), yyStatname(yystate))
+		__yyfmt__.Printf("char %v in %v\n", yyTokname(yytoken), yyStatname(yystate))
 	}
 
 	yyp++
@@ -641,21 +2409,22 @@ yystack:
 	yyS[yyp].yys = yystate
 
 yynewstate:
-	yyn = yyPact[yystate]
+	yyn = int(yyPact[yystate])
 	if yyn <= yyFlag {
 		goto yydefault /* simple state */
 	}
-	if yychar < 0 {
-		yychar = yylex1(yylex, &yylval)
+	if yyrcvr.char < 0 {
+		yyrcvr.char, yytoken = yylex1(yylex, &yyrcvr.lval)
 	}
-	yyn += yychar
+	yyn += yytoken
 	if yyn < 0 || yyn >= yyLast {
 		goto yydefault
 	}
-	yyn = yyAct[yyn]
-	if yyChk[yyn] == yychar { /* valid shift */
-		yychar = -1
-		yyVAL = yylval
+	yyn = int(yyAct[yyn])
+	if int(yyChk[yyn]) == yytoken { /* valid shift */
+		yyrcvr.char = -1
+		yytoken = -1
+		yyVAL = yyrcvr.lval
 		yystate = yyn
 		if Errflag > 0 {
 			Errflag--
@@ -665,27 +2434,27 @@ yynewstate:
 
 yydefault:
 	/* default state action */
-	yyn = yyDef[yystate]
+	yyn = int(yyDef[yystate])
 	if yyn == -2 {
-		if yychar < 0 {
-			yychar = yylex1(yylex, &yylval)
+		if yyrcvr.char < 0 {
+			yyrcvr.char, yytoken = yylex1(yylex, &yyrcvr.lval)
 		}
 
 		/* look through exception table */
 		xi := 0
 		for {
-			if yyExca[xi+0] == -1 && yyExca[xi+1] == yystate {
+			if yyExca[xi+0] == -1 && int(yyExca[xi+1]) == yystate {
 				break
 			}
 			xi += 2
 		}
 		for xi += 2; ; xi += 2 {
-			yyn = yyExca[xi+0]
-			if yyn < 0 || yyn == yychar {
+			yyn = int(yyExca[xi+0])
+			if yyn < 0 || yyn == yytoken {
 				break
 			}
 		}
-		yyn = yyExca[xi+1]
+		yyn = int(yyExca[xi+1])
 		if yyn < 0 {
 			goto ret0
 		}
@@ -694,11 +2463,11 @@ yydefault:
 		/* error ... attempt to resume parsing */
 		switch Errflag {
 		case 0: /* brand new error */
-			yylex.Error("syntax error")
+			yylex.Error(yyErrorMessage(yystate, yytoken))
 			Nerrs++
 			if yyDebug >= 1 {
 				__yyfmt__.Printf("%s", yyStatname(yystate))
-				__yyfmt__.Printf(" saw %s\n", yyTokname(yychar))
+				__yyfmt__.Printf(" saw %s\n", yyTokname(yytoken))
 			}
 			fallthrough
 
@@ -707,10 +2476,10 @@ yydefault:
 
 			/* find a state where "error" is a legal shift action */
 			for yyp >= 0 {
-				yyn = yyPact[yyS[yyp].yys] + yyErrCode
+				yyn = int(yyPact[yyS[yyp].yys]) + yyErrCode
 				if yyn >= 0 && yyn < yyLast {
-					yystate = yyAct[yyn] /* simulate a shift of "error" */
-					if yyChk[yystate] == yyErrCode {
+					yystate = int(yyAct[yyn]) /* simulate a shift of "error" */
+					if int(yyChk[yystate]) == yyErrCode {
 						goto yystack
 					}
 				}
@@ -726,12 +2495,13 @@ yydefault:
 
 		case 3: /* no shift yet; clobber input char */
 			if yyDebug >= 2 {
-				__yyfmt__.Printf("error recovery discards %s\n", yyTokname(yychar))
+				__yyfmt__.Printf("error recovery discards %s\n", yyTokname(yytoken))
 			}
-			if yychar == yyEofCode {
+			if yytoken == yyEofCode {
 				goto ret1
 			}
-			yychar = -1
+			yyrcvr.char = -1
+			yytoken = -1
 			goto yynewstate /* try again in the same state */
 		}
 	}
@@ -745,886 +2515,3684 @@ yydefault:
 	yypt := yyp
 	_ = yypt // guard against "declared and not used"
 
-	yyp -= yyR2[yyn]
+	yyp -= int(yyR2[yyn])
+	// yyp is now the index of $0. Perform the default action. Iff the
+	// reduced production is ε, $1 is possibly out of range.
+	if yyp+1 >= len(yyS) {
+		nyys := make([]yySymType, len(yyS)*2)
+		copy(nyys, yyS)
+		yyS = nyys
+	}
 	yyVAL = yyS[yyp+1]
 
 	/* consult goto table to find next state */
-	yyn = yyR1[yyn]
-	yyg := yyPgo[yyn]
+	yyn = int(yyR1[yyn])
+	yyg := int(yyPgo[yyn])
 	yyj := yyg + yyS[yyp].yys + 1
 
 	if yyj >= yyLast {
-		yystate = yyAct[yyg]
+		yystate = int(yyAct[yyg])
 	} else {
-		yystate = yyAct[yyj]
-		if yyChk[yystate] != -yyn {
-			yystate = yyAct[yyg]
+		yystate = int(yyAct[yyj])
+		if int(yyChk[yystate]) != -yyn {
+			yystate = int(yyAct[yyg])
 		}
 	}
 	// dummy call; replaced with literal code
 	switch yynt {
 
 	case 1:
-		//line sql.y:110
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:853
 		{
-			SetParseTree(yylex, yyS[yypt-0].statement)
+			SetParseTree(yylex, yyDollar[1].statement)
 		}
-	case 2:
-		yyVAL.statement = yyS[yypt-0].statement
-	case 3:
-		yyVAL.statement = yyS[yypt-0].statement
-	case 4:
-		yyVAL.statement = yyS[yypt-0].statement
-	case 5:
-		yyVAL.statement = yyS[yypt-0].statement
-	case 6:
-		yyVAL.statement = yyS[yypt-0].statement
-	case 7:
-		yyVAL.statement = yyS[yypt-0].statement
-	case 8:
-		yyVAL.statement = yyS[yypt-0].statement
-	case 9:
-		yyVAL.statement = yyS[yypt-0].statement
-	case 10:
-		yyVAL.statement = yyS[yypt-0].statement
-	case 11:
-		//line sql.y:127
-		{
-			yyVAL.statement = &Select{Comments: yyS[yypt-10].comments, Distinct: yyS[yypt-9].distinct, SelectExprs: yyS[yypt-8].selectExprs, From: yyS[yypt-6].tableExprs, Where: yyS[yypt-5].node, GroupBy: yyS[yypt-4].node, Having: yyS[yypt-3].node, OrderBy: yyS[yypt-2].node, Limit: yyS[yypt-1].node, Lock: yyS[yypt-0].node}
+	case 31:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:890
+		{
+			with := &With{Recursive: yyDollar[2].boolean, CTEs: yyDollar[3].ctes}
+			switch sel := yyDollar[4].statement.(type) {
+			case *Select:
+				sel.With = with
+			case *Union:
+				sel.With = with
+			}
+			yyVAL.statement = yyDollar[4].statement
 		}
-	case 12:
-		//line sql.y:131
+	case 32:
+		yyDollar = yyS[yypt-15 : yypt+1]
+//line sql.y:903
 		{
-			yyVAL.statement = &Union{Type: yyS[yypt-1].str, Select1: yyS[yypt-2].statement.(SelectStatement), Select2: yyS[yypt-0].statement.(SelectStatement)}
+			distinct, options := selectOptions(yyDollar[3].nodes)
+			yyVAL.statement = &Select{Comments: yyDollar[2].comments, Distinct: distinct, Options: options, SelectExprs: yyDollar[4].selectExprs, From: yyDollar[6].tableExprs, Where: yyDollar[7].node, GroupBy: yyDollar[8].node, Having: yyDollar[9].node, Windows: yyDollar[10].namedWindows, OrderBy: yyDollar[11].node, Limit: yyDollar[12].node, Procedure: yyDollar[13].node, Into: yyDollar[14].selectInto, Lock: yyDollar[15].lock}
 		}
-	case 13:
-		//line sql.y:137
-		{
-			yyVAL.statement = &Insert{Comments: yyS[yypt-5].comments, Table: yyS[yypt-3].node, Columns: yyS[yypt-2].columns, Values: yyS[yypt-1].sqlNode, OnDup: yyS[yypt-0].node}
+	case 33:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:908
+		{
+			// Like MySQL, a select without FROM doesn't accept
+			// WHERE, GROUP BY, HAVING or a lock clause.
+			distinct, options := selectOptions(yyDollar[3].nodes)
+			yyVAL.statement = &Select{
+				Comments:    yyDollar[2].comments,
+				Distinct:    distinct,
+				Options:     options,
+				SelectExprs: yyDollar[4].selectExprs,
+				Where:       NewSimpleParseNode(WHERE, "where"),
+				GroupBy:     NewSimpleParseNode(GROUP, "group"),
+				Having:      NewSimpleParseNode(HAVING, "having"),
+				OrderBy:     yyDollar[5].node,
+				Limit:       yyDollar[6].node,
+				Into:        yyDollar[7].selectInto,
+				Lock:        &Lock{Type: NO_LOCK},
+			}
 		}
-	case 14:
-		//line sql.y:143
-		{
-			yyVAL.statement = &Update{Comments: yyS[yypt-6].comments, Table: yyS[yypt-5].node, List: yyS[yypt-3].node, Where: yyS[yypt-2].node, OrderBy: yyS[yypt-1].node, Limit: yyS[yypt-0].node}
+	case 34:
+		yyDollar = yyS[yypt-17 : yypt+1]
+//line sql.y:927
+		{
+			// The INTO clause can also precede FROM. The empty ORDER
+			// BY and LIMIT let it share its start with a select
+			// without FROM.
+			if yyDollar[5].node.Len() != 0 || yyDollar[6].node.Len() != 0 {
+				yylex.Error("order by and limit must follow from")
+				return 1
+			}
+			distinct, options := selectOptions(yyDollar[3].nodes)
+			yyVAL.statement = &Select{Comments: yyDollar[2].comments, Distinct: distinct, Options: options, SelectExprs: yyDollar[4].selectExprs, From: yyDollar[9].tableExprs, Where: yyDollar[10].node, GroupBy: yyDollar[11].node, Having: yyDollar[12].node, Windows: yyDollar[13].namedWindows, OrderBy: yyDollar[14].node, Limit: yyDollar[15].node, Procedure: yyDollar[16].node, Into: yyDollar[7].selectInto, Lock: yyDollar[17].lock}
 		}
-	case 15:
-		//line sql.y:149
+	case 35:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:939
 		{
-			yyVAL.statement = &Delete{Comments: yyS[yypt-5].comments, Table: yyS[yypt-3].node, Where: yyS[yypt-2].node, OrderBy: yyS[yypt-1].node, Limit: yyS[yypt-0].node}
+			yyVAL.statement = newUnion(yyDollar[1].statement.(SelectStatement), yyDollar[2].setOp, yyDollar[3].statement.(SelectStatement))
 		}
-	case 16:
-		//line sql.y:155
+	case 36:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:943
 		{
-			yyVAL.statement = &Set{Comments: yyS[yypt-1].comments, Updates: yyS[yypt-0].node}
+			union := newUnion(yyDollar[1].statement.(SelectStatement), yyDollar[2].setOp, yyDollar[3].parenSelect)
+			setUnionTail(union, yyDollar[4].node, yyDollar[5].node, yyDollar[6].lock)
+			yyVAL.statement = union
 		}
-	case 17:
-		//line sql.y:161
+	case 37:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:954
 		{
-			yyVAL.statement = &DDLSimple{Action: CREATE, Table: yyS[yypt-1].node}
+			yyVAL.statement = newUnion(yyDollar[1].parenSelect, yyDollar[2].setOp, yyDollar[3].statement.(SelectStatement))
 		}
-	case 18:
-		//line sql.y:165
+	case 38:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:958
 		{
-			// Change this to an alter statement
-			yyVAL.statement = &DDLSimple{Action: ALTER, Table: yyS[yypt-1].node}
+			union := newUnion(yyDollar[1].parenSelect, yyDollar[2].setOp, yyDollar[3].parenSelect)
+			setUnionTail(union, yyDollar[4].node, yyDollar[5].node, yyDollar[6].lock)
+			yyVAL.statement = union
 		}
-	case 19:
-		//line sql.y:170
+	case 39:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:964
 		{
-			yyVAL.statement = &DDLSimple{Action: CREATE, Table: yyS[yypt-1].node}
+			yyVAL.statement = newUnion(yyDollar[1].statement.(SelectStatement), yyDollar[2].setOp, yyDollar[3].statement.(SelectStatement))
 		}
-	case 20:
-		//line sql.y:176
+	case 40:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:968
 		{
-			yyVAL.statement = &DDLSimple{Action: ALTER, Table: yyS[yypt-2].node}
+			union := newUnion(yyDollar[1].statement.(SelectStatement), yyDollar[2].setOp, yyDollar[3].parenSelect)
+			setUnionTail(union, yyDollar[4].node, yyDollar[5].node, yyDollar[6].lock)
+			yyVAL.statement = union
 		}
-	case 21:
-		//line sql.y:180
+	case 41:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:976
 		{
-			// Change this to a rename statement
-			yyVAL.statement = &Rename{OldName: yyS[yypt-3].node, NewName: yyS[yypt-0].node}
+			yyVAL.parenSelect = &ParenSelect{Select: yyDollar[2].statement.(SelectStatement)}
 		}
-	case 22:
-		//line sql.y:185
+	case 42:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:980
 		{
-			yyVAL.statement = &DDLSimple{Action: ALTER, Table: yyS[yypt-1].node}
+			yyVAL.parenSelect = &ParenSelect{Select: yyDollar[2].statement.(SelectStatement)}
 		}
-	case 23:
-		//line sql.y:191
+	case 43:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:986
 		{
-			yyVAL.statement = &Rename{OldName: yyS[yypt-2].node, NewName: yyS[yypt-0].node}
+			yyVAL.ctes = []*CommonTableExpr{yyDollar[1].cte}
 		}
-	case 24:
-		//line sql.y:197
+	case 44:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:990
 		{
-			yyVAL.statement = &DDLSimple{Action: DROP, Table: yyS[yypt-0].node}
+			yyVAL.ctes = append(yyDollar[1].ctes, yyDollar[3].cte)
 		}
-	case 25:
-		//line sql.y:201
+	case 45:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:996
 		{
-			// Change this to an alter statement
-			yyVAL.statement = &DDLSimple{Action: ALTER, Table: yyS[yypt-0].node}
+			yyVAL.cte = &CommonTableExpr{Name: yyDollar[1].node, Columns: yyDollar[2].columns, Subquery: yyDollar[5].statement.(SelectStatement)}
 		}
-	case 26:
-		//line sql.y:206
+	case 46:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:1002
 		{
-			yyVAL.statement = &DDLSimple{Action: DROP, Table: yyS[yypt-1].node}
+			if yyDollar[3].nodes != nil || !bytes.Equal(yyDollar[5].node.Value, VALUE) {
+				yylex.Error("expecting value")
+				return 1
+			}
+			yyVAL.statement = &NextValueFor{Comments: yyDollar[2].comments, SequenceName: yyDollar[7].node.Value}
 		}
-	case 27:
-		//line sql.y:211
+	case 47:
+		yyDollar = yyS[yypt-10 : yypt+1]
+//line sql.y:1012
 		{
-			SetAllowComments(yylex, true)
+			yyVAL.statement = &Insert{Comments: yyDollar[2].comments, Priority: yyDollar[3].bytes, Ignore: yyDollar[4].node != nil, Table: yyDollar[6].node, Partitions: yyDollar[7].partitions, Columns: yyDollar[8].columns, Values: yyDollar[9].sqlNode, OnDup: yyDollar[10].node}
 		}
-	case 28:
-		//line sql.y:215
+	case 48:
+		yyDollar = yyS[yypt-12 : yypt+1]
+//line sql.y:1016
 		{
-			yyVAL.comments = yyS[yypt-0].comments
-			SetAllowComments(yylex, false)
+			yyVAL.statement = &Insert{Comments: yyDollar[2].comments, Priority: yyDollar[3].bytes, Ignore: yyDollar[4].node != nil, Table: yyDollar[6].node, Partitions: yyDollar[7].partitions, Columns: yyDollar[8].columns, Values: yyDollar[9].node.Push(yyDollar[10].node), RowAlias: yyDollar[11].rowAlias, OnDup: yyDollar[12].node}
 		}
-	case 29:
-		//line sql.y:221
+	case 49:
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line sql.y:1020
 		{
-			yyVAL.comments = nil
+			columns, values := updateListToValues(yyDollar[9].node)
+			yyVAL.statement = &Insert{Comments: yyDollar[2].comments, Priority: yyDollar[3].bytes, Ignore: yyDollar[4].node != nil, Table: yyDollar[6].node, Partitions: yyDollar[7].partitions, Columns: columns, Values: values, RowAlias: yyDollar[10].rowAlias, OnDup: yyDollar[11].node}
 		}
-	case 30:
-		//line sql.y:225
+	case 50:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1026
 		{
-			yyVAL.comments = append(yyVAL.comments, Comment(yyS[yypt-0].node.Value))
+			yyVAL.bytes = nil
 		}
-	case 31:
-		//line sql.y:231
-		{
-			yyVAL.str = yyS[yypt-0].node.Value
+	case 51:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1030
+		{
+			switch {
+			case bytes.Equal(yyDollar[1].node.Value, LOW_PRIORITY):
+				yyVAL.bytes = LOW_PRIORITY
+			case bytes.Equal(yyDollar[1].node.Value, DELAYED):
+				yyVAL.bytes = DELAYED
+			default:
+				yylex.Error("expecting insert modifier")
+				return 1
+			}
 		}
-	case 32:
-		//line sql.y:235
+	case 52:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1042
 		{
-			yyVAL.str = []byte("union all")
+			yyVAL.bytes = yyDollar[1].node.Value
 		}
-	case 33:
-		//line sql.y:239
+	case 53:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:1048
 		{
-			yyVAL.str = yyS[yypt-0].node.Value
+			yyVAL.statement = &Replace{Comments: yyDollar[2].comments, Table: yyDollar[4].node, Columns: yyDollar[5].columns, Values: yyDollar[6].sqlNode}
 		}
-	case 34:
-		//line sql.y:243
+	case 54:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:1052
 		{
-			yyVAL.str = yyS[yypt-0].node.Value
+			columns, values := updateListToValues(yyDollar[6].node)
+			yyVAL.statement = &Replace{Comments: yyDollar[2].comments, Table: yyDollar[4].node, Columns: columns, Values: values}
 		}
-	case 35:
-		//line sql.y:247
+	case 55:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line sql.y:1057
 		{
-			yyVAL.str = yyS[yypt-0].node.Value
+			yylex.Error("on duplicate key update is not allowed with replace")
+			return 1
 		}
-	case 36:
-		//line sql.y:252
+	case 56:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line sql.y:1062
 		{
-			yyVAL.distinct = Distinct(false)
-		}
-	case 37:
-		//line sql.y:256
-		{
-			yyVAL.distinct = Distinct(true)
-		}
-	case 38:
-		//line sql.y:262
-		{
-			yyVAL.selectExprs = SelectExprs{yyS[yypt-0].selectExpr}
-		}
-	case 39:
-		//line sql.y:266
-		{
-			yyVAL.selectExprs = append(yyVAL.selectExprs, yyS[yypt-0].selectExpr)
-		}
-	case 40:
-		//line sql.y:272
-		{
-			yyVAL.selectExpr = &StarExpr{}
-		}
-	case 41:
-		//line sql.y:276
-		{
-			yyVAL.selectExpr = &NonStarExpr{Expr: yyS[yypt-1].node, As: yyS[yypt-0].str}
-		}
-	case 42:
-		//line sql.y:280
-		{
-			yyVAL.selectExpr = &StarExpr{TableName: yyS[yypt-2].node.Value}
-		}
-	case 43:
-		yyVAL.node = yyS[yypt-0].node
-	case 44:
-		yyVAL.node = yyS[yypt-0].node
-	case 45:
-		//line sql.y:289
-		{
-			yyVAL.str = nil
-		}
-	case 46:
-		//line sql.y:293
-		{
-			yyVAL.str = yyS[yypt-0].node.Value
-		}
-	case 47:
-		//line sql.y:297
-		{
-			yyVAL.str = yyS[yypt-0].node.Value
-		}
-	case 48:
-		//line sql.y:303
-		{
-			yyVAL.tableExprs = TableExprs{yyS[yypt-0].tableExpr}
-		}
-	case 49:
-		//line sql.y:307
-		{
-			yyVAL.tableExprs = append(yyVAL.tableExprs, yyS[yypt-0].tableExpr)
-		}
-	case 50:
-		//line sql.y:313
-		{
-			yyVAL.tableExpr = &AliasedTableExpr{Expr: yyS[yypt-2].node, As: yyS[yypt-1].str, Hint: yyS[yypt-0].node}
-		}
-	case 51:
-		//line sql.y:317
-		{
-			yyVAL.tableExpr = &ParenTableExpr{Inner: yyS[yypt-1].tableExpr}
-		}
-	case 52:
-		//line sql.y:321
-		{
-			yyVAL.tableExpr = &JoinTableExpr{
-				LeftExpr:  yyS[yypt-2].tableExpr,
-				Join:      yyS[yypt-1].str,
-				RightExpr: yyS[yypt-0].tableExpr,
-			}
-		}
-	case 53:
-		//line sql.y:329
-		{
-			yyVAL.tableExpr = &JoinTableExpr{
-				LeftExpr:  yyS[yypt-4].tableExpr,
-				Join:      yyS[yypt-3].str,
-				RightExpr: yyS[yypt-2].tableExpr,
-				On:        yyS[yypt-0].node,
-			}
-		}
-	case 54:
-		//line sql.y:339
-		{
-			yyVAL.str = nil
-		}
-	case 55:
-		//line sql.y:343
-		{
-			yyVAL.str = yyS[yypt-0].node.Value
-		}
-	case 56:
-		//line sql.y:347
-		{
-			yyVAL.str = yyS[yypt-0].node.Value
+			yylex.Error("on duplicate key update is not allowed with replace")
+			return 1
 		}
 	case 57:
-		//line sql.y:353
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line sql.y:1069
 		{
-			yyVAL.str = yyS[yypt-0].node.Value
+			yyVAL.statement = &Update{Comments: yyDollar[2].comments, Table: yyDollar[3].node, Partitions: yyDollar[4].partitions, List: yyDollar[6].node, Where: yyDollar[7].node, OrderBy: yyDollar[8].node, Limit: yyDollar[9].node}
 		}
 	case 58:
-		//line sql.y:357
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line sql.y:1075
 		{
-			yyVAL.str = yyS[yypt-0].node.Value
+			yyVAL.statement = &Delete{Comments: yyDollar[2].comments, Table: yyDollar[4].node, Partitions: yyDollar[5].partitions, Where: yyDollar[6].node, OrderBy: yyDollar[7].node, Limit: yyDollar[8].node}
 		}
 	case 59:
-		//line sql.y:361
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1081
 		{
-			yyVAL.str = LJOIN
+			resolveSetScopes(yyDollar[3].setExprs)
+			yyVAL.statement = &Set{Comments: yyDollar[2].comments, Exprs: yyDollar[3].setExprs}
 		}
 	case 60:
-		//line sql.y:365
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1086
 		{
-			yyVAL.str = LJOIN
+			chars, err := newTransactionChars(yyDollar[3].nodes)
+			if chars == nil {
+				yylex.Error(err)
+				return 1
+			}
+			yyVAL.statement = &Set{Comments: yyDollar[2].comments, Transaction: chars}
 		}
 	case 61:
-		//line sql.y:369
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1097
 		{
-			yyVAL.str = RJOIN
+			yyVAL.statement = yyDollar[1].ddl
 		}
 	case 62:
-		//line sql.y:373
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1101
 		{
-			yyVAL.str = RJOIN
+			yyVAL.statement = yyDollar[1].ddl
 		}
 	case 63:
-		//line sql.y:377
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1105
 		{
-			yyVAL.str = yyS[yypt-0].node.Value
+			yyDollar[1].ddl.OptLike = &OptLike{LikeTable: yyDollar[3].node}
+			yyVAL.statement = yyDollar[1].ddl
 		}
 	case 64:
-		//line sql.y:381
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1110
 		{
-			yyVAL.str = CJOIN
+			yyDollar[1].ddl.OptLike = &OptLike{LikeTable: yyDollar[4].node, Parens: true}
+			yyVAL.statement = yyDollar[1].ddl
 		}
 	case 65:
-		//line sql.y:385
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1115
 		{
-			yyVAL.str = NJOIN
+			yyDollar[2].tableSpec.Options = yyDollar[3].tableOptions
+			yyDollar[1].ddl.TableSpec = yyDollar[2].tableSpec
+			yyVAL.statement = yyDollar[1].ddl
 		}
 	case 66:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1121
+		{
+			// The table options are kept as written if one
+			// of them is not parsed yet, like PARTITION BY.
+			yyDollar[2].tableSpec.RawOptions = rawText(yylex)
+			yyDollar[1].ddl.TableSpec = yyDollar[2].tableSpec
+			yyVAL.statement = yyDollar[1].ddl
+		}
 	case 67:
-		//line sql.y:392
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1129
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			yyDollar[2].tableSpec.RawOptions = rawText(yylex)
+			yyDollar[1].ddl.TableSpec = yyDollar[2].tableSpec
+			yyVAL.statement = yyDollar[1].ddl
 		}
 	case 68:
-		//line sql.y:396
+		yyDollar = yyS[yypt-12 : yypt+1]
+//line sql.y:1135
 		{
-			yyVAL.node = yyS[yypt-2].node.Push(yyS[yypt-1].statement)
+			// Change this to an alter statement
+			yyVAL.statement = createIndex(yylex, yyDollar[7].node, &IndexDefinition{Type: yyDollar[2].bytes, Name: yyDollar[4].node, Columns: yyDollar[10].indexColumns, Using: yyDollar[5].bytes}, yyDollar[12].alterOptions)
 		}
 	case 69:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-12 : yypt+1]
+//line sql.y:1140
+		{
+			if !bytes.Equal(yyDollar[2].node.Value, SPATIAL) {
+				yylex.Error("unexpected index type " + string(yyDollar[2].node.Value))
+				return 1
+			}
+			yyVAL.statement = createIndex(yylex, yyDollar[7].node, &IndexDefinition{Type: yyDollar[2].node.Value, Name: yyDollar[4].node, Columns: yyDollar[10].indexColumns, Using: yyDollar[5].bytes}, yyDollar[12].alterOptions)
+		}
 	case 70:
-		//line sql.y:403
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line sql.y:1148
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			// The columns and options are kept as written if they can't be parsed.
+			index := &IndexDefinition{Type: yyDollar[2].bytes, Name: yyDollar[4].node, Using: yyDollar[5].bytes, Raw: rawText(yylex)}
+			yyVAL.statement = &DDLSimple{Action: ALTER, Table: yyDollar[7].node, AlterOptions: []AlterOption{&AddIndex{Index: index}}}
 		}
 	case 71:
-		//line sql.y:408
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1154
 		{
-			yyVAL.node = nil
+			if yyDollar[1].ddl.ViewSpec != nil {
+				yyDollar[2].viewSpec.Algorithm, yyDollar[2].viewSpec.Definer, yyDollar[2].viewSpec.Security = yyDollar[1].ddl.ViewSpec.Algorithm, yyDollar[1].ddl.ViewSpec.Definer, yyDollar[1].ddl.ViewSpec.Security
+			}
+			yyDollar[1].ddl.ViewSpec = yyDollar[2].viewSpec
+			yyVAL.statement = yyDollar[1].ddl
 		}
 	case 72:
-		//line sql.y:412
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1162
 		{
-			yyVAL.node.Push(yyS[yypt-1].node)
+			// Fall back to the view name for the view
+			// definitions that are not parsed yet.
+			yyDollar[1].ddl.ViewSpec = nil
+			yyVAL.statement = yyDollar[1].ddl
 		}
 	case 73:
-		//line sql.y:416
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1170
 		{
-			yyVAL.node.Push(yyS[yypt-1].node)
+			ddl := &DBDDL{Action: CREATE, DBName: yyDollar[4].node, IfNotExists: yyDollar[3].node != nil}
+			if !setDBOptions(ddl, yyDollar[5].tableOptions) {
+				yylex.Error("unexpected database option")
+				return 1
+			}
+			yyVAL.statement = ddl
 		}
 	case 74:
-		//line sql.y:421
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1181
 		{
-			yyVAL.node = NewSimpleParseNode(WHERE, "where")
+			yyVAL.ddl = &DDLSimple{Action: CREATE, Table: yyDollar[5].node, Temporary: yyDollar[2].boolean, IfNotExists: yyDollar[4].node != nil}
+			yylex.(*Tokenizer).createTable = yyVAL.ddl
 		}
 	case 75:
-		//line sql.y:425
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1188
 		{
-			yyVAL.node = yyS[yypt-1].node.Push(yyS[yypt-0].node)
+			yyVAL.tableSpec = yyDollar[2].tableSpec
+			markRawText(yylex)
 		}
 	case 76:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1197
+		{
+			yyVAL.ddl = &DDLSimple{Action: CREATE, Table: yyDollar[3].node, View: true}
+		}
 	case 77:
-		//line sql.y:432
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1201
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.ddl = &DDLSimple{Action: CREATE, Table: yyDollar[4].node, View: true, ViewSpec: yyDollar[2].viewSpec}
 		}
 	case 78:
-		//line sql.y:436
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1205
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			// Change this to an alter statement
+			yyVAL.ddl = &DDLSimple{Action: ALTER, Table: yyDollar[5].node, View: true, Replace: true}
 		}
 	case 79:
-		//line sql.y:440
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:1210
 		{
-			yyVAL.node = yyS[yypt-1].node.Push(yyS[yypt-0].node)
+			yyVAL.ddl = &DDLSimple{Action: ALTER, Table: yyDollar[6].node, View: true, Replace: true, ViewSpec: yyDollar[4].viewSpec}
 		}
 	case 80:
-		//line sql.y:444
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1216
 		{
-			yyVAL.node = yyS[yypt-2].node.Push(yyS[yypt-1].node)
+			yyVAL.viewSpec = &ViewSpec{}
+			if !setViewOption(yyVAL.viewSpec, yyDollar[1].viewOption) {
+				yylex.Error("unexpected view option " + string(yyDollar[1].viewOption.Name))
+				return 1
+			}
 		}
 	case 81:
-		//line sql.y:450
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1224
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			if !setViewOption(yyVAL.viewSpec, yyDollar[2].viewOption) {
+				yylex.Error("unexpected view option " + string(yyDollar[2].viewOption.Name))
+				return 1
+			}
 		}
 	case 82:
-		//line sql.y:454
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1233
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.viewOption = &viewOption{Name: yyDollar[1].node.Value, User: yyDollar[3].userSpec}
 		}
 	case 83:
-		//line sql.y:458
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1237
 		{
-			yyVAL.node = NewSimpleParseNode(NOT_IN, "not in").PushTwo(yyS[yypt-3].node, yyS[yypt-0].node)
+			if !bytes.Equal(yyDollar[1].node.Value, SQL) || !bytes.Equal(yyDollar[2].node.Value, SECURITY) {
+				yylex.Error("expecting sql security")
+				return 1
+			}
+			yyVAL.viewOption = &viewOption{Name: SECURITY, Value: yyDollar[3].node.Value}
 		}
 	case 84:
-		//line sql.y:462
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1247
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.viewSpec = &ViewSpec{Columns: yyDollar[1].columns, Select: yyDollar[3].statement.(SelectStatement), CheckOption: yyDollar[4].bytes}
 		}
 	case 85:
-		//line sql.y:466
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1252
 		{
-			yyVAL.node = NewSimpleParseNode(NOT_LIKE, "not like").PushTwo(yyS[yypt-3].node, yyS[yypt-0].node)
+			yyVAL.bytes = nil
 		}
 	case 86:
-		//line sql.y:470
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1256
 		{
-			yyVAL.node = yyS[yypt-3].node
-			yyVAL.node.Push(yyS[yypt-4].node)
-			yyVAL.node.Push(yyS[yypt-2].node)
-			yyVAL.node.Push(yyS[yypt-0].node)
+			if string(yyDollar[3].node.Value) != "option" {
+				yylex.Error("expecting check option")
+				return 1
+			}
+			yyVAL.bytes = []byte("check option")
 		}
 	case 87:
-		//line sql.y:477
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1264
 		{
-			yyVAL.node = NewSimpleParseNode(NOT_BETWEEN, "not between")
-			yyVAL.node.Push(yyS[yypt-5].node)
-			yyVAL.node.Push(yyS[yypt-2].node)
-			yyVAL.node.Push(yyS[yypt-0].node)
+			if string(yyDollar[2].node.Value) != "cascaded" && string(yyDollar[2].node.Value) != "local" || string(yyDollar[4].node.Value) != "option" {
+				yylex.Error("expecting check option")
+				return 1
+			}
+			yyVAL.bytes = []byte(string(yyDollar[2].node.Value) + " check option")
 		}
 	case 88:
-		//line sql.y:484
-		{
-			yyVAL.node = NewSimpleParseNode(IS_NULL, "is null").Push(yyS[yypt-2].node)
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1274
+		{
+			options := alterRawText(yylex, yyDollar[5].alterOptions)
+			yyVAL.statement = &DDLSimple{Action: ALTER, Table: yyDollar[4].node, AlterOptions: options}
+			if len(options) == 1 {
+				if rename, ok := options[0].(*RenameTable); ok {
+					// Change this to a rename statement
+					yyVAL.statement = &Rename{OldName: yyDollar[4].node, NewName: rename.NewName}
+				}
+			}
 		}
 	case 89:
-		//line sql.y:488
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:1285
 		{
-			yyVAL.node = NewSimpleParseNode(IS_NOT_NULL, "is not null").Push(yyS[yypt-3].node)
+			// The end of the last operation is not parsed yet.
+			yyDollar[5].alterOptions[len(yyDollar[5].alterOptions)-1] = &AlterRaw{}
+			yyVAL.statement = &DDLSimple{Action: ALTER, Table: yyDollar[4].node, AlterOptions: alterRawText(yylex, yyDollar[5].alterOptions)}
 		}
 	case 90:
-		//line sql.y:492
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1291
 		{
-			yyVAL.node = yyS[yypt-3].node.Push(yyS[yypt-1].statement)
+			yyVAL.statement = &DDLSimple{Action: ALTER, Table: yyDollar[3].node, View: true, ViewSpec: yyDollar[4].viewSpec}
 		}
 	case 91:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1295
+		{
+			yyVAL.statement = &DDLSimple{Action: ALTER, Table: yyDollar[3].node, View: true}
+		}
 	case 92:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1299
+		{
+			ddl := &DBDDL{Action: ALTER, DBName: yyDollar[3].node}
+			if !setDBOptions(ddl, yyDollar[4].tableOptions) {
+				yylex.Error("unexpected database option")
+				return 1
+			}
+			yyVAL.statement = ddl
+		}
 	case 93:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1310
+		{
+			yyVAL.alterOptions = []AlterOption{yyDollar[2].alterOption}
+		}
 	case 94:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1314
+		{
+			yyVAL.alterOptions = append(yyDollar[1].alterOptions, yyDollar[4].alterOption)
+		}
 	case 95:
-		yyVAL.node = yyS[yypt-0].node
-	case 96:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1319
+		{
+			markAlterOption(yylex)
+		}
 	case 97:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1326
+		{
+			yyVAL.alterOption = &AddColumn{Column: yyDollar[2].columnDefinition}
+			if takeSkippedDDL(yylex) {
+				yyVAL.alterOption = &AlterRaw{}
+			}
+		}
 	case 98:
-		//line sql.y:507
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1333
 		{
-			yyVAL.sqlNode = yyS[yypt-1].node.Push(yyS[yypt-0].node)
+			yyVAL.alterOption = &AddColumn{Column: yyDollar[3].columnDefinition}
+			if takeSkippedDDL(yylex) {
+				yyVAL.alterOption = &AlterRaw{}
+			}
 		}
 	case 99:
-		//line sql.y:511
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1340
 		{
-			yyVAL.sqlNode = yyS[yypt-0].statement
+			yyVAL.alterOption = &AddIndex{Index: yyDollar[2].indexDefinition}
+			if takeSkippedDDL(yylex) {
+				yyVAL.alterOption = &AlterRaw{}
+			}
 		}
 	case 100:
-		//line sql.y:517
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1347
 		{
-			yyVAL.node = NewSimpleParseNode(NODE_LIST, "node_list")
-			yyVAL.node.Push(yyS[yypt-0].node)
+			yyVAL.alterOption = &ChangeColumn{OldName: yyDollar[3].node, Column: yyDollar[4].columnDefinition}
+			if takeSkippedDDL(yylex) {
+				yyVAL.alterOption = &AlterRaw{}
+			}
 		}
 	case 101:
-		//line sql.y:522
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1354
 		{
-			yyVAL.node.Push(yyS[yypt-0].node)
+			yyVAL.alterOption = &ModifyColumn{Column: yyDollar[3].columnDefinition}
+			if takeSkippedDDL(yylex) {
+				yyVAL.alterOption = &AlterRaw{}
+			}
 		}
 	case 102:
-		//line sql.y:528
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1361
 		{
-			yyVAL.node = yyS[yypt-2].node.Push(yyS[yypt-1].node)
+			yyVAL.alterOption = &DropColumn{Name: yyDollar[2].node}
 		}
 	case 103:
-		//line sql.y:532
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1365
 		{
-			yyVAL.node = yyS[yypt-2].node.Push(yyS[yypt-1].statement)
+			yyVAL.alterOption = &DropColumn{Name: yyDollar[3].node}
 		}
 	case 104:
-		//line sql.y:538
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1369
 		{
-			yyVAL.node = NewSimpleParseNode(NODE_LIST, "node_list")
-			yyVAL.node.Push(yyS[yypt-0].node)
+			yyVAL.alterOption = &DropIndex{Name: yyDollar[3].node}
 		}
 	case 105:
-		//line sql.y:543
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1373
 		{
-			yyVAL.node.Push(yyS[yypt-0].node)
+			if !bytes.Equal(yyDollar[2].node.Value, PRIMARY) {
+				yylex.Error("expecting primary key")
+				return 1
+			}
+			yyVAL.alterOption = &DropIndex{}
 		}
 	case 106:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1381
+		{
+			yyVAL.alterOption = &RenameTable{NewName: yyDollar[3].node}
+		}
 	case 107:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1385
+		{
+			yyVAL.alterOption = &TableOption{Name: yyDollar[1].node.Value, Value: yyDollar[3].node}
+		}
 	case 108:
-		//line sql.y:551
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1389
 		{
-			yyVAL.node = yyS[yypt-2].node.Push(yyS[yypt-1].statement)
+			// The text is set by alterRawText.
+			yyVAL.alterOption = &AlterRaw{}
 		}
 	case 109:
-		//line sql.y:555
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1395
 		{
-			if yyS[yypt-1].node.Len() == 1 {
-				yyS[yypt-1].node = yyS[yypt-1].node.NodeAt(0)
-			}
-			switch yyS[yypt-1].node.Type {
-			case NUMBER, STRING, ID, VALUE_ARG, '(', '.':
-				yyVAL.node = yyS[yypt-1].node
-			default:
-				yyVAL.node = yyS[yypt-2].node.Push(yyS[yypt-1].node)
-			}
 		}
 	case 110:
-		//line sql.y:567
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1397
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
 		}
 	case 111:
-		//line sql.y:571
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:1401
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.indexDefinition = yyDollar[7].indexDefinition
+			yyVAL.indexDefinition.Type, yyVAL.indexDefinition.Name, yyVAL.indexDefinition.Columns = yyDollar[1].bytes, yyDollar[3].node, yyDollar[5].indexColumns
 		}
 	case 112:
-		//line sql.y:575
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:1406
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			switch {
+			case bytes.Equal(yyDollar[1].node.Value, PRIMARY) && yyDollar[2].node.Type == KEY && yyDollar[3].node == nil:
+			case bytes.Equal(yyDollar[1].node.Value, SPATIAL):
+			default:
+				if !skipDDLClause(yylex, "unexpected index type "+string(yyDollar[1].node.Value)) {
+					return 1
+				}
+			}
+			yyVAL.indexDefinition = yyDollar[7].indexDefinition
+			yyVAL.indexDefinition.Type, yyVAL.indexDefinition.Name, yyVAL.indexDefinition.Columns = yyDollar[1].node.Value, yyDollar[3].node, yyDollar[5].indexColumns
 		}
 	case 113:
-		//line sql.y:579
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:1419
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			if !bytes.Equal(yyDollar[2].node.Value, PRIMARY) && !skipDDLClause(yylex, "expecting primary key") {
+				return 1
+			}
+			yyVAL.indexDefinition = yyDollar[7].indexDefinition
+			yyVAL.indexDefinition.Type, yyVAL.indexDefinition.Columns = PRIMARY, yyDollar[5].indexColumns
 		}
 	case 114:
-		//line sql.y:583
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line sql.y:1427
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			if !bytes.Equal(yyDollar[3].node.Value, PRIMARY) && !skipDDLClause(yylex, "expecting primary key") {
+				return 1
+			}
+			yyVAL.indexDefinition = yyDollar[8].indexDefinition
+			yyVAL.indexDefinition.Constraint, yyVAL.indexDefinition.Type, yyVAL.indexDefinition.Columns = yyDollar[2].node, PRIMARY, yyDollar[6].indexColumns
 		}
 	case 115:
-		//line sql.y:587
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line sql.y:1435
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.indexDefinition = yyDollar[8].indexDefinition
+			yyVAL.indexDefinition.Constraint, yyVAL.indexDefinition.Type, yyVAL.indexDefinition.Name, yyVAL.indexDefinition.Columns = yyDollar[1].node, []byte("unique"), yyDollar[4].node, yyDollar[6].indexColumns
 		}
 	case 116:
-		//line sql.y:591
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1445
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.node = nil
 		}
 	case 117:
-		//line sql.y:595
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1449
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.node = yyDollar[2].node
 		}
 	case 118:
-		//line sql.y:599
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1455
 		{
-			if yyS[yypt-0].node.Type == NUMBER { // Simplify trivial unary expressions
-				switch yyS[yypt-1].node.Type {
-				case UMINUS:
-					yyS[yypt-0].node.Value = append(yyS[yypt-1].node.Value, yyS[yypt-0].node.Value...)
-					yyVAL.node = yyS[yypt-0].node
-				case UPLUS:
-					yyVAL.node = yyS[yypt-0].node
-				default:
-					yyVAL.node = yyS[yypt-1].node.Push(yyS[yypt-0].node)
-				}
-			} else {
-				yyVAL.node = yyS[yypt-1].node.Push(yyS[yypt-0].node)
-			}
+			yyVAL.constraintDefinition = &ConstraintDefinition{ForeignKey: yyDollar[1].foreignKey}
 		}
 	case 119:
-		//line sql.y:615
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1459
 		{
-			yyS[yypt-2].node.Type = FUNCTION
-			yyVAL.node = yyS[yypt-2].node.Push(NewSimpleParseNode(NODE_LIST, "node_list"))
+			yyVAL.constraintDefinition = &ConstraintDefinition{Name: yyDollar[1].node, ForeignKey: yyDollar[2].foreignKey}
 		}
 	case 120:
-		//line sql.y:620
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1463
 		{
-			yyS[yypt-3].node.Type = FUNCTION
-			yyVAL.node = yyS[yypt-3].node.Push(yyS[yypt-1].selectExprs)
+			yyVAL.constraintDefinition = &ConstraintDefinition{Check: yyDollar[3].node}
 		}
 	case 121:
-		//line sql.y:625
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1467
 		{
-			yyS[yypt-4].node.Type = FUNCTION
-			yyVAL.node = yyS[yypt-4].node.Push(yyS[yypt-2].node)
-			yyVAL.node = yyS[yypt-4].node.Push(yyS[yypt-1].selectExprs)
+			yyVAL.constraintDefinition = &ConstraintDefinition{Name: yyDollar[1].node, Check: yyDollar[4].node}
 		}
 	case 122:
-		//line sql.y:631
+		yyDollar = yyS[yypt-12 : yypt+1]
+//line sql.y:1473
 		{
-			yyS[yypt-3].node.Type = FUNCTION
-			yyVAL.node = yyS[yypt-3].node.Push(yyS[yypt-1].selectExprs)
+			yyVAL.foreignKey = yyDollar[12].foreignKey
+			yyVAL.foreignKey.Name, yyVAL.foreignKey.Columns, yyVAL.foreignKey.ReferencedTable, yyVAL.foreignKey.ReferencedColumns = yyDollar[3].node, yyDollar[5].columns, yyDollar[8].node, yyDollar[10].columns
 		}
 	case 123:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1479
+		{
+			yyVAL.foreignKey = &ForeignKeyDefinition{}
+		}
 	case 124:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1483
+		{
+			yyVAL.foreignKey.OnDelete = yyDollar[4].bytes
+		}
 	case 125:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1487
+		{
+			yyVAL.foreignKey.OnUpdate = yyDollar[4].bytes
+		}
 	case 126:
-		//line sql.y:643
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1493
 		{
-			yyVAL.node = NewSimpleParseNode(UPLUS, "+")
+			if !bytes.Equal(yyDollar[1].node.Value, RESTRICT) && !bytes.Equal(yyDollar[1].node.Value, CASCADE) {
+				yylex.Error("unexpected reference option " + string(yyDollar[1].node.Value))
+				return 1
+			}
+			yyVAL.bytes = yyDollar[1].node.Value
 		}
 	case 127:
-		//line sql.y:647
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1501
 		{
-			yyVAL.node = NewSimpleParseNode(UMINUS, "-")
+			if string(yyDollar[1].node.Value) != "no" || string(yyDollar[2].node.Value) != "action" {
+				yylex.Error("expecting no action")
+				return 1
+			}
+			yyVAL.bytes = []byte("no action")
 		}
 	case 128:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1509
+		{
+			yyVAL.bytes = []byte("set null")
+		}
 	case 129:
-		//line sql.y:654
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1513
 		{
-			yyVAL.node = NewSimpleParseNode(CASE_WHEN, "case")
-			yyVAL.node.Push(yyS[yypt-1].node)
+			yyVAL.bytes = []byte("set default")
 		}
 	case 130:
-		//line sql.y:659
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1518
 		{
-			yyVAL.node.PushTwo(yyS[yypt-2].node, yyS[yypt-1].node)
+			yyVAL.indexDefinition = &IndexDefinition{}
 		}
 	case 131:
-		//line sql.y:665
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1522
 		{
-			yyVAL.node = NewSimpleParseNode(WHEN_LIST, "when_list")
-			yyVAL.node.Push(yyS[yypt-0].node)
+			yyVAL.indexDefinition.Using = yyDollar[3].node.Value
 		}
 	case 132:
-		//line sql.y:670
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1526
 		{
-			yyVAL.node.Push(yyS[yypt-0].node)
+			if !bytes.Equal(yyDollar[2].node.Value, COMMENT_OPTION) && !skipDDLClause(yylex, "unexpected index option "+string(yyDollar[2].node.Value)) {
+				return 1
+			}
+			yyVAL.indexDefinition.Comment = yyDollar[3].node
 		}
 	case 133:
-		//line sql.y:676
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1536
 		{
-			yyVAL.node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.alterOptions = nil
 		}
 	case 134:
-		//line sql.y:680
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1540
 		{
-			yyVAL.node.Push(yyS[yypt-0].node)
+			yyVAL.alterOptions = append(yyDollar[1].alterOptions, &TableOption{Name: USING_OPTION, Value: yyDollar[3].node})
 		}
 	case 135:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1544
+		{
+			yyVAL.alterOptions = append(yyDollar[1].alterOptions, &TableOption{Name: yyDollar[2].node.Value, Value: yyDollar[3].node})
+		}
 	case 136:
-		//line sql.y:687
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1548
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.alterOptions = append(yyDollar[1].alterOptions, yyDollar[2].alterOption)
 		}
 	case 137:
-		yyVAL.node = yyS[yypt-0].node
-	case 138:
-		yyVAL.node = yyS[yypt-0].node
-	case 139:
-		yyVAL.node = yyS[yypt-0].node
-	case 140:
-		yyVAL.node = yyS[yypt-0].node
-	case 141:
-		//line sql.y:698
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1553
 		{
-			yyVAL.node = NewSimpleParseNode(GROUP, "group")
+			markRawText(yylex)
 		}
-	case 142:
-		//line sql.y:702
+	case 138:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1558
 		{
-			yyVAL.node = yyS[yypt-2].node.Push(yyS[yypt-0].node)
+			yyVAL.bytes = nil
 		}
-	case 143:
-		//line sql.y:707
+	case 139:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1562
 		{
-			yyVAL.node = NewSimpleParseNode(HAVING, "having")
+			yyVAL.bytes = []byte("unique")
 		}
-	case 144:
-		//line sql.y:711
+	case 140:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1566
 		{
-			yyVAL.node = yyS[yypt-1].node.Push(yyS[yypt-0].node)
+			yyVAL.bytes = []byte("fulltext")
 		}
-	case 145:
-		//line sql.y:716
+	case 143:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1575
 		{
-			yyVAL.node = NewSimpleParseNode(ORDER, "order")
+			yyVAL.node = nil
 		}
-	case 146:
-		//line sql.y:720
+	case 145:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1581
 		{
-			yyVAL.node = yyS[yypt-2].node.Push(yyS[yypt-0].node)
+			yyVAL.node = nil
 		}
 	case 147:
-		//line sql.y:726
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1588
 		{
-			yyVAL.node = NewSimpleParseNode(NODE_LIST, "node_list")
-			yyVAL.node.Push(yyS[yypt-0].node)
+			yyVAL.indexColumns = []*IndexColumn{yyDollar[1].indexColumn}
 		}
 	case 148:
-		//line sql.y:731
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1592
 		{
-			yyVAL.node = yyS[yypt-2].node.Push(yyS[yypt-0].node)
+			yyVAL.indexColumns = append(yyDollar[1].indexColumns, yyDollar[3].indexColumn)
 		}
 	case 149:
-		//line sql.y:737
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1598
 		{
-			yyVAL.node = yyS[yypt-0].node.Push(yyS[yypt-1].node)
+			yyVAL.indexColumn = &IndexColumn{Column: yyDollar[1].node, Direction: yyDollar[2].bytes}
 		}
 	case 150:
-		//line sql.y:742
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1602
 		{
-			yyVAL.node = NewSimpleParseNode(ASC, "asc")
+			yyVAL.indexColumn = &IndexColumn{Column: yyDollar[1].node, Length: yyDollar[3].node.Value, Direction: yyDollar[5].bytes}
 		}
 	case 151:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1607
+		{
+			yyVAL.bytes = nil
+		}
 	case 152:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1611
+		{
+			yyVAL.bytes = []byte("asc")
+		}
 	case 153:
-		//line sql.y:749
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1615
 		{
-			yyVAL.node = NewSimpleParseNode(LIMIT, "limit")
+			yyVAL.bytes = []byte("desc")
 		}
 	case 154:
-		//line sql.y:753
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:1621
 		{
-			yyVAL.node = yyS[yypt-1].node.Push(yyS[yypt-0].node)
+			if !bytes.Equal(yyDollar[3].node.Value, CHARACTER) {
+				yylex.Error("expecting character set")
+				return 1
+			}
+			yyVAL.alterOption = &AlterCharset{CharacterSet: yyDollar[5].node.Value, Collate: yyDollar[6].bytes}
 		}
 	case 155:
-		//line sql.y:757
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1629
 		{
-			yyVAL.node = yyS[yypt-3].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			if !bytes.Equal(yyDollar[3].node.Value, CHARSET) {
+				yylex.Error("expecting character set")
+				return 1
+			}
+			yyVAL.alterOption = &AlterCharset{CharacterSet: yyDollar[4].node.Value, Collate: yyDollar[5].bytes}
 		}
 	case 156:
-		//line sql.y:762
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1638
 		{
-			yyVAL.node = NewSimpleParseNode(NO_LOCK, "")
+			yyVAL.bytes = nil
 		}
 	case 157:
-		//line sql.y:766
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1642
 		{
-			yyVAL.node = NewSimpleParseNode(FOR_UPDATE, " for update")
+			yyVAL.bytes = yyDollar[2].node.Value
 		}
 	case 158:
-		//line sql.y:770
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1648
 		{
-			if !bytes.Equal(yyS[yypt-1].node.Value, SHARE) {
-				yylex.Error("expecting share")
-				return 1
-			}
-			if !bytes.Equal(yyS[yypt-0].node.Value, MODE) {
-				yylex.Error("expecting mode")
-				return 1
-			}
-			yyVAL.node = NewSimpleParseNode(LOCK_IN_SHARE_MODE, " lock in share mode")
+			yyVAL.statement = &Rename{OldName: yyDollar[3].node, NewName: yyDollar[5].node}
 		}
 	case 159:
-		//line sql.y:783
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:1654
 		{
-			yyVAL.columns = nil
+			yyVAL.statement = &DDLSimple{Action: DROP, Table: yyDollar[5].nodes[0], Tables: yyDollar[5].nodes, Temporary: yyDollar[2].boolean, IfExists: yyDollar[4].node != nil}
 		}
 	case 160:
-		//line sql.y:787
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:1658
 		{
-			yyVAL.columns = yyS[yypt-1].columns
+			// Change this to an alter statement
+			options := yyDollar[7].alterOptions
+			for _, option := range options {
+				if !isLockOption(option) {
+					// Only ALGORITHM and LOCK are parsed, the
+					// options are kept as written otherwise.
+					options = alterRawText(yylex, []AlterOption{&AlterRaw{}})
+					break
+				}
+			}
+			options = append([]AlterOption{&DropIndex{Name: yyDollar[3].node}}, options...)
+			yyVAL.statement = &DDLSimple{Action: ALTER, Table: yyDollar[5].node, AlterOptions: options}
 		}
 	case 161:
-		//line sql.y:793
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line sql.y:1673
 		{
-			yyVAL.columns = Columns{&NonStarExpr{Expr: yyS[yypt-0].node}}
+			// The options are kept as written if one of them can't be parsed.
+			options := append([]AlterOption{&DropIndex{Name: yyDollar[3].node}}, alterRawText(yylex, []AlterOption{&AlterRaw{}})...)
+			yyVAL.statement = &DDLSimple{Action: ALTER, Table: yyDollar[5].node, AlterOptions: options}
 		}
 	case 162:
-		//line sql.y:797
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1679
 		{
-			yyVAL.columns = append(yyVAL.columns, &NonStarExpr{Expr: yyS[yypt-0].node})
+			yyVAL.statement = &DDLSimple{Action: DROP, Table: yyDollar[4].nodes[0], Tables: yyDollar[4].nodes, View: true, IfExists: yyDollar[3].node != nil}
 		}
 	case 163:
-		//line sql.y:803
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1683
 		{
-			yyVAL.node = NewSimpleParseNode(INDEX_LIST, "")
-			yyVAL.node.Push(yyS[yypt-0].node)
+			yyVAL.statement = &DBDDL{Action: DROP, DBName: yyDollar[4].node, IfExists: yyDollar[3].node != nil}
 		}
 	case 164:
-		//line sql.y:808
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1688
 		{
-			yyVAL.node = yyS[yypt-2].node.Push(yyS[yypt-0].node)
+			yyVAL.alterOptions = nil
 		}
 	case 165:
-		//line sql.y:813
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1692
 		{
-			yyVAL.node = NewSimpleParseNode(DUPLICATE, "duplicate")
+			yyVAL.alterOptions = append(yyDollar[1].alterOptions, yyDollar[2].alterOption)
 		}
 	case 166:
-		//line sql.y:817
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1696
 		{
-			yyVAL.node = yyS[yypt-3].node.Push(yyS[yypt-0].node)
+			yyVAL.alterOptions = append(yyDollar[1].alterOptions, yyDollar[3].alterOption)
 		}
 	case 167:
-		//line sql.y:823
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1702
 		{
-			yyVAL.node = NewSimpleParseNode(NODE_LIST, "node_list")
-			yyVAL.node.Push(yyS[yypt-0].node)
+			yyVAL.alterOption = &TableOption{Name: yyDollar[1].node.Value, Value: yyDollar[3].node}
 		}
 	case 168:
-		//line sql.y:828
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1706
 		{
-			yyVAL.node = yyS[yypt-2].node.Push(yyS[yypt-0].node)
+			yyVAL.alterOption = &TableOption{Name: yyDollar[1].node.Value, Value: yyDollar[3].node}
 		}
 	case 169:
-		//line sql.y:834
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1710
 		{
-			yyVAL.node = yyS[yypt-1].node.PushTwo(yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.alterOption = &TableOption{Name: LOCK_OPTION, Value: yyDollar[3].node}
 		}
 	case 170:
-		//line sql.y:839
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1714
 		{
-			yyVAL.node = nil
+			yyVAL.alterOption = &TableOption{Name: LOCK_OPTION, Value: yyDollar[3].node}
 		}
 	case 171:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1721
+		{
+		}
 	case 172:
-		//line sql.y:843
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1723
 		{
-			yyVAL.node = nil
+			if !bytes.Equal(yyDollar[1].node.Value, RESTRICT) && !bytes.Equal(yyDollar[1].node.Value, CASCADE) {
+				yylex.Error("expecting restrict or cascade")
+				return 1
+			}
 		}
 	case 173:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1732
+		{
+			yyVAL.nodes = []*Node{yyDollar[1].node}
+		}
 	case 174:
-		//line sql.y:847
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1736
 		{
-			yyVAL.node = nil
+			yyVAL.nodes = append(yyDollar[1].nodes, yyDollar[3].node)
 		}
 	case 175:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1742
+		{
+			yyVAL.nodes = []*Node{yyDollar[1].node}
+		}
 	case 176:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1746
+		{
+			yyVAL.nodes = append(yyDollar[1].nodes, yyDollar[3].node)
+		}
 	case 177:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1752
+		{
+			yyVAL.statement = &Truncate{Comments: yyDollar[2].comments, Table: yyDollar[4].node}
+		}
 	case 178:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1756
+		{
+			yyVAL.statement = &Truncate{Comments: yyDollar[2].comments, Table: yyDollar[3].node}
+		}
 	case 179:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1762
+		{
+			yyVAL.statement = &Describe{Comments: yyDollar[2].comments, Table: yyDollar[3].node, Column: yyDollar[4].node}
+		}
 	case 180:
-		yyVAL.node = yyS[yypt-0].node
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1766
+		{
+			yyVAL.statement = &Describe{Table: yyDollar[2].node, Column: yyDollar[3].node}
+		}
 	case 181:
-		//line sql.y:858
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1772
 		{
-			yyVAL.node = nil
+			if !bytes.Equal(yyDollar[1].node.Value, FORMAT) {
+				yylex.Error("expecting format")
+				return 1
+			}
+			switch string(yyDollar[3].node.Value) {
+			case "traditional", "json", "tree":
+			default:
+				yylex.Error("unexpected format " + string(yyDollar[3].node.Value))
+				return 1
+			}
+			yyVAL.bytes = yyDollar[3].node.Value
 		}
 	case 182:
-		yyVAL.node = yyS[yypt-0].node
-	case 183:
-		//line sql.y:862
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1788
 		{
-			yyVAL.node = nil
+			if !bytes.Equal(yyDollar[1].node.Value, EXTENDED) {
+				yylex.Error("unexpected explain " + string(yyDollar[1].node.Value))
+				return 1
+			}
 		}
-	case 184:
-		yyVAL.node = yyS[yypt-0].node
-	case 185:
-		//line sql.y:866
+	case 191:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1808
 		{
 			yyVAL.node = nil
 		}
-	case 186:
-		yyVAL.node = yyS[yypt-0].node
-	case 187:
-		//line sql.y:871
+	case 194:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1816
 		{
-			yyVAL.node.LowerCase()
+			yyVAL.statement = &Explain{Partitions: yyDollar[2].boolean, Statement: yyDollar[3].statement}
+		}
+	case 195:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1820
+		{
+			yyVAL.statement = &Explain{OutputFormat: yyDollar[2].bytes, Statement: yyDollar[3].statement}
+		}
+	case 196:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1824
+		{
+			yyVAL.statement = &Explain{Extended: true, Statement: yyDollar[3].statement}
+		}
+	case 197:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1828
+		{
+			yyVAL.statement = &Explain{Analyze: true, Statement: yyDollar[3].statement}
+		}
+	case 198:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1832
+		{
+			yylex.Error("cannot explain an explain statement")
+			return 1
+		}
+	case 199:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1837
+		{
+			if !bytes.Equal(yyDollar[3].node.Value, CONNECTION) {
+				yylex.Error("expecting connection")
+				return 1
+			}
+			yyVAL.statement = &ExplainForConnection{ConnectionID: yyDollar[4].node}
+		}
+	case 200:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1847
+		{
+			yyVAL.statement = &Begin{Comments: yyDollar[2].comments}
+		}
+	case 201:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1851
+		{
+			if !bytes.Equal(yyDollar[3].node.Value, TRANSACTION) {
+				yylex.Error("expecting transaction")
+				return 1
+			}
+			yyVAL.statement = &Begin{Comments: yyDollar[2].comments, Start: true, Modifiers: yyDollar[4].bytes}
+		}
+	case 202:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1863
+		{
+			if !bytes.EqualFold(yyDollar[1].node.Value, START) {
+				yylex.Error("syntax error")
+				return 1
+			}
+		}
+	case 203:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1872
+		{
+			yyVAL.statement = &Commit{Comments: yyDollar[2].comments}
+		}
+	case 204:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1878
+		{
+			yyVAL.statement = &Rollback{Comments: yyDollar[2].comments}
+		}
+	case 205:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1883
+		{
+		}
+	case 206:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1886
+		{
+			if !bytes.Equal(yyDollar[1].node.Value, WORK) {
+				yylex.Error("expecting work")
+				return 1
+			}
+		}
+	case 207:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1894
+		{
+			yyVAL.bytes = nil
+		}
+	case 210:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1902
+		{
+			yyVAL.bytes = append(append(yyDollar[1].bytes, ", "...), yyDollar[3].bytes...)
+		}
+	case 211:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1908
+		{
+			if string(yyDollar[2].node.Value) != "consistent" || string(yyDollar[3].node.Value) != "snapshot" {
+				yylex.Error("unexpected transaction modifier")
+				return 1
+			}
+			yyVAL.bytes = []byte("with consistent snapshot")
+		}
+	case 212:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1916
+		{
+			if string(yyDollar[1].node.Value) != "read" || string(yyDollar[2].node.Value) != "only" && string(yyDollar[2].node.Value) != "write" {
+				yylex.Error("unexpected transaction modifier")
+				return 1
+			}
+			yyVAL.bytes = []byte("read " + string(yyDollar[2].node.Value))
+		}
+	case 213:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1926
+		{
+			yyVAL.statement = &Use{DBName: yyDollar[2].node}
+		}
+	case 214:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1932
+		{
+			yyVAL.statement = &Do{Exprs: yyDollar[2].nodes}
+		}
+	case 215:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1938
+		{
+			switch string(yyDollar[2].node.Value) {
+			case "master":
+				yyVAL.statement = &Reset{Target: RESET_MASTER}
+			case "slave":
+				yyVAL.statement = &Reset{Target: RESET_SLAVE}
+			case "replica":
+				yyVAL.statement = &Reset{Target: RESET_REPLICA}
+			default:
+				yylex.Error("unexpected reset target " + string(yyDollar[2].node.Value))
+				return 1
+			}
+		}
+	case 216:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1952
+		{
+			if !bytes.Equal(yyDollar[2].node.Value, QUERY) || !bytes.Equal(yyDollar[3].node.Value, CACHE) {
+				yylex.Error("expecting query cache")
+				return 1
+			}
+			yyVAL.statement = &Reset{Target: RESET_QUERY_CACHE}
+		}
+	case 217:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1962
+		{
+			if yyDollar[4].bytes != nil && yyDollar[1].verb != ADMIN_REPAIR {
+				yylex.Error("unexpected option " + string(yyDollar[4].bytes))
+				return 1
+			}
+			yyVAL.statement = &OtherAdmin{Verb: yyDollar[1].verb, Tables: yyDollar[3].nodes, Options: yyDollar[4].bytes}
+		}
+	case 218:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1970
+		{
+			if !bytes.EqualFold(yyDollar[2].node.Value, TABLES) {
+				yylex.Error("expecting tables")
+				return 1
+			}
+			yyVAL.statement = &OtherAdmin{Verb: yyDollar[1].verb, Tables: yyDollar[3].nodes}
+		}
+	case 219:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1978
+		{
+			modifier, ok := adminModifier(yyDollar[2].node)
+			if !ok {
+				yylex.Error("expecting local or no_write_to_binlog")
+				return 1
+			}
+			if yyDollar[5].bytes != nil && yyDollar[1].verb != ADMIN_REPAIR {
+				yylex.Error("unexpected option " + string(yyDollar[5].bytes))
+				return 1
+			}
+			yyVAL.statement = &OtherAdmin{Verb: yyDollar[1].verb, Modifier: modifier, Tables: yyDollar[4].nodes, Options: yyDollar[5].bytes}
+		}
+	case 220:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1991
+		{
+			modifier, ok := adminModifier(yyDollar[2].node)
+			if !ok {
+				yylex.Error("expecting local or no_write_to_binlog")
+				return 1
+			}
+			if !bytes.EqualFold(yyDollar[3].node.Value, TABLES) {
+				yylex.Error("expecting tables")
+				return 1
+			}
+			yyVAL.statement = &OtherAdmin{Verb: yyDollar[1].verb, Modifier: modifier, Tables: yyDollar[4].nodes}
+		}
+	case 221:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2009
+		{
+			yyVAL.bytes = nil
+		}
+	case 222:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2013
+		{
+			option := bytes.ToLower(yyDollar[2].node.Value)
+			switch string(option) {
+			case "quick", "extended", "use_frm":
+			default:
+				yylex.Error("unexpected option " + string(option))
+				return 1
+			}
+			if yyDollar[1].bytes != nil {
+				option = append(append(yyDollar[1].bytes, ' '), option...)
+			}
+			yyVAL.bytes = option
+		}
+	case 223:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2029
+		{
+			yyVAL.verb = ADMIN_ANALYZE
+		}
+	case 224:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2033
+		{
+			yyVAL.verb = ADMIN_OPTIMIZE
+		}
+	case 225:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2037
+		{
+			yyVAL.verb = ADMIN_REPAIR
+		}
+	case 226:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2043
+		{
+			yyVAL.nodes = []*Node{yyDollar[1].node}
+		}
+	case 227:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2047
+		{
+			yyVAL.nodes = append(yyDollar[1].nodes, yyDollar[3].node)
+		}
+	case 228:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2053
+		{
+			flush, msg := newFlush(yyDollar[2].nodeLists)
+			if flush == nil {
+				yylex.Error(msg)
+				return 1
+			}
+			switch string(yyDollar[3].bytes) {
+			case "with read lock":
+				flush.WithLock = true
+			case "for export":
+				flush.ForExport = true
+			}
+			if (flush.WithLock || flush.ForExport) && !bytes.Equal(flush.Type, TABLES) {
+				yylex.Error("unexpected " + string(yyDollar[3].bytes))
+				return 1
+			}
+			yyVAL.statement = flush
+		}
+	case 229:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2074
+		{
+			yyVAL.nodeLists = [][]*Node{yyDollar[1].nodes}
+		}
+	case 230:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2078
+		{
+			yyVAL.nodeLists = append(yyDollar[1].nodeLists, yyDollar[3].nodes)
+		}
+	case 231:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2084
+		{
+			yyVAL.nodes = []*Node{yyDollar[1].node}
+		}
+	case 232:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2088
+		{
+			yyVAL.nodes = append(yyDollar[1].nodes, yyDollar[2].node)
+		}
+	case 235:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2097
+		{
+			yyVAL.bytes = nil
+		}
+	case 236:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2101
+		{
+			if !bytes.EqualFold(yyDollar[2].node.Value, READ) {
+				yylex.Error("expecting read")
+				return 1
+			}
+			yyVAL.bytes = []byte("with read lock")
+		}
+	case 237:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2109
+		{
+			if !bytes.EqualFold(yyDollar[2].node.Value, EXPORT) {
+				yylex.Error("expecting export")
+				return 1
+			}
+			yyVAL.bytes = []byte("for export")
+		}
+	case 238:
+		yyDollar = yyS[yypt-10 : yypt+1]
+//line sql.y:2119
+		{
+			if !bytes.EqualFold(yyDollar[2].node.Value, DATA) {
+				yylex.Error("expecting data")
+				return 1
+			}
+			load := yyDollar[3].load
+			fields, lines, msg := newLoadOptions(yyDollar[8].nodeLists)
+			if msg != "" {
+				yylex.Error(msg)
+				return 1
+			}
+			load.Conflict, load.Table, load.Fields, load.Lines, load.IgnoreLines, load.Columns = yyDollar[4].bytes, yyDollar[7].node, fields, lines, yyDollar[9].node, yyDollar[10].columns
+			yyVAL.statement = load
+		}
+	case 239:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2136
+		{
+			if !bytes.EqualFold(yyDollar[1].node.Value, INFILE) {
+				yylex.Error("expecting infile")
+				return 1
+			}
+			yyVAL.load = &Load{FileName: yyDollar[2].node}
+		}
+	case 240:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2144
+		{
+			if !bytes.EqualFold(yyDollar[1].node.Value, LOCAL) || !bytes.EqualFold(yyDollar[2].node.Value, INFILE) {
+				yylex.Error("expecting local infile")
+				return 1
+			}
+			yyVAL.load = &Load{Local: true, FileName: yyDollar[3].node}
+		}
+	case 241:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2153
+		{
+			yyVAL.bytes = nil
+		}
+	case 242:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2157
+		{
+			yyVAL.bytes = []byte("replace")
+		}
+	case 243:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2161
+		{
+			yyVAL.bytes = []byte("ignore")
+		}
+	case 244:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2166
+		{
+			yyVAL.nodeLists = nil
+		}
+	case 246:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2173
+		{
+			yyVAL.nodeLists = [][]*Node{yyDollar[1].nodes}
+		}
+	case 247:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2177
+		{
+			yyVAL.nodeLists = append(yyDollar[1].nodeLists, yyDollar[2].nodes)
+		}
+	case 248:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2183
+		{
+			yyVAL.nodes = append(yyDollar[1].nodes, yyDollar[3].node)
+		}
+	case 249:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2189
+		{
+			yyVAL.nodes = []*Node{yyDollar[1].node}
+		}
+	case 250:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2193
+		{
+			yyVAL.nodes = append(yyDollar[1].nodes, yyDollar[2].node)
+		}
+	case 251:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2198
+		{
+			yyVAL.node = nil
+		}
+	case 252:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2202
+		{
+			if !bytes.EqualFold(yyDollar[3].node.Value, LINES) {
+				yylex.Error("expecting lines or rows")
+				return 1
+			}
+			yyVAL.node = yyDollar[2].node
+		}
+	case 253:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2210
+		{
+			yyVAL.node = yyDollar[2].node
+		}
+	case 254:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:2216
+		{
+			yyVAL.statement = &Grant{Action: GRANT, Privileges: yyDollar[2].strs, On: yyDollar[4].node, Users: yyDollar[6].userSpecs}
+		}
+	case 255:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:2220
+		{
+			// The trailing clauses are kept as text.
+			yyVAL.statement = &Grant{Action: GRANT, Privileges: yyDollar[2].strs, On: yyDollar[4].node, Users: yyDollar[6].userSpecs, Raw: rawTail(yylex)}
+		}
+	case 256:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:2225
+		{
+			yyVAL.statement = &Grant{Action: REVOKE, Privileges: yyDollar[2].strs, On: yyDollar[4].node, Users: yyDollar[6].userSpecs}
+		}
+	case 257:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2231
+		{
+			yyVAL.strs = []string{yyDollar[1].text}
+		}
+	case 258:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2235
+		{
+			yyVAL.strs = append(yyDollar[1].strs, yyDollar[3].text)
+		}
+	case 259:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2241
+		{
+			yyVAL.text = string(bytes.ToLower(yyDollar[1].node.Value))
+		}
+	case 260:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2245
+		{
+			yyVAL.text = yyDollar[1].text + " " + string(bytes.ToLower(yyDollar[2].node.Value))
+		}
+	case 277:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2269
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 279:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2276
+		{
+			yyVAL.node = NewSimpleParseNode(WILDCARD, "*")
+		}
+	case 280:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2282
+		{
+			yyVAL.userSpecs = []*UserSpec{yyDollar[1].userSpec}
+		}
+	case 281:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2286
+		{
+			yyVAL.userSpecs = append(yyDollar[1].userSpecs, yyDollar[3].userSpec)
+		}
+	case 282:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2292
+		{
+			yyVAL.userSpec = &UserSpec{User: yyDollar[1].node}
+		}
+	case 283:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2296
+		{
+			yyVAL.userSpec = &UserSpec{User: yyDollar[1].node}
+		}
+	case 284:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2300
+		{
+			// The tokenizer reads 'user'@host as a string
+			// followed by the identifier @host.
+			if len(yyDollar[2].node.Value) < 2 || yyDollar[2].node.Value[0] != '@' {
+				yylex.Error("expecting @host")
+				return 1
+			}
+			yyDollar[2].node.Value = yyDollar[2].node.Value[1:]
+			yyVAL.userSpec = &UserSpec{User: yyDollar[1].node, Host: yyDollar[2].node}
+		}
+	case 285:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2311
+		{
+			if string(yyDollar[2].node.Value) != "@" {
+				yylex.Error("expecting @")
+				return 1
+			}
+			yyVAL.userSpec = &UserSpec{User: yyDollar[1].node, Host: yyDollar[3].node}
+		}
+	case 286:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2321
+		{
+			yyVAL.statement = &LockTables{Tables: yyDollar[3].tableLocks}
+		}
+	case 287:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2327
+		{
+			yyVAL.statement = &UnlockTables{}
+		}
+	case 288:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2333
+		{
+			show := &Show{}
+			switch string(yyDollar[2].node.Value) {
+			case "vitess_keyspaces":
+				show.VitessObject = &ShowVitessKeyspaces{}
+			case "vitess_shards":
+				show.VitessObject = &ShowVitessShards{}
+			case "tables":
+				show.Type = SHOW_TABLES
+			case "databases", "schemas":
+				show.Type = SHOW_DATABASES
+			case "variables":
+				show.Type = SHOW_VARIABLES
+			case "status":
+				show.Type = SHOW_STATUS
+			case "warnings":
+				show.Type = SHOW_WARNINGS
+			case "errors":
+				show.Type = SHOW_ERRORS
+			default:
+				yylex.Error("unexpected show " + string(yyDollar[2].node.Value))
+				return 1
+			}
+			if (show.Type == SHOW_WARNINGS || show.Type == SHOW_ERRORS) && yyDollar[3].node != nil {
+				yylex.Error("unexpected " + string(yyDollar[3].node.Value))
+				return 1
+			}
+			if !setShowFilter(show, yyDollar[3].node) {
+				yylex.Error("unexpected where")
+				return 1
+			}
+			yyVAL.statement = show
+		}
+	case 289:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2367
+		{
+			show := &Show{Limit: yyDollar[3].node}
+			switch string(yyDollar[2].node.Value) {
+			case "warnings":
+				show.Type = SHOW_WARNINGS
+			case "errors":
+				show.Type = SHOW_ERRORS
+			default:
+				yylex.Error("unexpected show " + string(yyDollar[2].node.Value))
+				return 1
+			}
+			yyVAL.statement = show
+		}
+	case 290:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:2381
+		{
+			show := &Show{Count: true}
+			switch {
+			case !bytes.Equal(yyDollar[2].node.Value, COUNT):
+				yylex.Error("unexpected show " + string(yyDollar[2].node.Value))
+				return 1
+			case bytes.Equal(yyDollar[6].node.Value, WARNINGS):
+				show.Type = SHOW_WARNINGS
+			case bytes.Equal(yyDollar[6].node.Value, ERRORS):
+				show.Type = SHOW_ERRORS
+			default:
+				yylex.Error("expecting warnings or errors")
+				return 1
+			}
+			yyVAL.statement = show
+		}
+	case 291:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2398
+		{
+			show := &Show{Scope: setScope(yyDollar[2].node.Value)}
+			if show.Scope == nil {
+				yylex.Error("unexpected show " + string(yyDollar[2].node.Value))
+				return 1
+			}
+			switch string(yyDollar[3].node.Value) {
+			case "variables":
+				show.Type = SHOW_VARIABLES
+			case "status":
+				show.Type = SHOW_STATUS
+			default:
+				yylex.Error("unexpected show " + string(yyDollar[3].node.Value))
+				return 1
+			}
+			setShowFilter(show, yyDollar[4].node)
+			yyVAL.statement = show
+		}
+	case 292:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2417
+		{
+			show := &Show{}
+			switch string(yyDollar[2].node.Value) {
+			case "tables":
+				if yyDollar[4].node.Type != ID {
+					yylex.Error("expecting database name")
+					return 1
+				}
+				show.Type = SHOW_TABLES
+				show.DBName = yyDollar[4].node
+			case "columns", "fields":
+				show.Type = SHOW_COLUMNS
+				show.OnTable = yyDollar[4].node
+			default:
+				yylex.Error("unexpected show " + string(yyDollar[2].node.Value))
+				return 1
+			}
+			setShowFilter(show, yyDollar[5].node)
+			yyVAL.statement = show
+		}
+	case 293:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:2438
+		{
+			switch string(yyDollar[2].node.Value) {
+			case "columns", "fields":
+			default:
+				yylex.Error("unexpected show " + string(yyDollar[2].node.Value))
+				return 1
+			}
+			show := &Show{Type: SHOW_COLUMNS, OnTable: NewSimpleParseNode('.', ".").PushTwo(yyDollar[6].node, yyDollar[4].node)}
+			setShowFilter(show, yyDollar[7].node)
+			yyVAL.statement = show
+		}
+	case 294:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2451
+		{
+			yyVAL.statement = &Show{Type: SHOW_CREATE_TABLE, OnTable: yyDollar[4].node}
+		}
+	case 295:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2455
+		{
+			yyVAL.statement = &Show{Type: SHOW_CREATE_VIEW, OnTable: yyDollar[4].node}
+		}
+	case 298:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2464
+		{
+			yyVAL.node = nil
+		}
+	case 299:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2468
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[2].node)
+		}
+	case 300:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2472
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[2].node)
+		}
+	case 301:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2478
+		{
+		}
+	case 302:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2481
+		{
+			if !bytes.Equal(yyDollar[1].node.Value, TABLES) {
+				yylex.Error("expecting tables")
+				return 1
+			}
+		}
+	case 303:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2490
+		{
+			yyVAL.tableLocks = []*TableLock{yyDollar[1].tableLock}
+		}
+	case 304:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2494
+		{
+			yyVAL.tableLocks = append(yyDollar[1].tableLocks, yyDollar[3].tableLock)
+		}
+	case 305:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2500
+		{
+			lock, name, ok := lockType(yyDollar[2].node)
+			if !ok {
+				yylex.Error("unexpected lock type " + name)
+				return 1
+			}
+			yyVAL.tableLock = &TableLock{Table: &AliasedTableExpr{Expr: yyDollar[1].node}, Lock: lock}
+		}
+	case 306:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2509
+		{
+			// Either a lock type of two words, or an alias and a lock type.
+			if lock, _, ok := lockType(yyDollar[2].node, yyDollar[3].node); ok {
+				yyVAL.tableLock = &TableLock{Table: &AliasedTableExpr{Expr: yyDollar[1].node}, Lock: lock}
+			} else if lock, name, ok := lockType(yyDollar[3].node); ok {
+				yyVAL.tableLock = &TableLock{Table: &AliasedTableExpr{Expr: yyDollar[1].node, As: yyDollar[2].node.Value}, Lock: lock}
+			} else {
+				yylex.Error("unexpected lock type " + string(bytes.ToLower(yyDollar[2].node.Value)) + " " + name)
+				return 1
+			}
+		}
+	case 307:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2521
+		{
+			lock, name, ok := lockType(yyDollar[3].node, yyDollar[4].node)
+			if !ok {
+				yylex.Error("unexpected lock type " + name)
+				return 1
+			}
+			yyVAL.tableLock = &TableLock{Table: &AliasedTableExpr{Expr: yyDollar[1].node, As: yyDollar[2].node.Value}, Lock: lock}
+		}
+	case 308:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2530
+		{
+			yyVAL.tableLock = &TableLock{Table: &AliasedTableExpr{Expr: yyDollar[1].node, As: yyDollar[3].node.Value}, Lock: yyDollar[4].lockType}
+		}
+	case 309:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2536
+		{
+			lock, name, ok := lockType(yyDollar[1].node)
+			if !ok {
+				yylex.Error("unexpected lock type " + name)
+				return 1
+			}
+			yyVAL.lockType = lock
+		}
+	case 310:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2545
+		{
+			lock, name, ok := lockType(yyDollar[1].node, yyDollar[2].node)
+			if !ok {
+				yylex.Error("unexpected lock type " + name)
+				return 1
+			}
+			yyVAL.lockType = lock
+		}
+	case 311:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2555
+		{
+			yyVAL.boolean = false
+		}
+	case 312:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2559
+		{
+			yyVAL.boolean = true
+		}
+	case 313:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2565
+		{
+			yyVAL.tableSpec = &TableSpec{Columns: []*ColumnDefinition{yyDollar[1].columnDefinition}}
+		}
+	case 314:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2569
+		{
+			yyVAL.tableSpec.Columns = append(yyVAL.tableSpec.Columns, yyDollar[3].columnDefinition)
+		}
+	case 315:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2573
+		{
+			yyVAL.tableSpec.Indexes = append(yyVAL.tableSpec.Indexes, yyDollar[3].indexDefinition)
+		}
+	case 316:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2577
+		{
+			yyVAL.tableSpec.Constraints = append(yyVAL.tableSpec.Constraints, yyDollar[3].constraintDefinition)
+		}
+	case 317:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2582
+		{
+			yyVAL.tableOptions = nil
+		}
+	case 319:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2589
+		{
+			yyVAL.tableOptions = []*TableOption{yyDollar[1].tableOption}
+		}
+	case 320:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2593
+		{
+			yyVAL.tableOptions = append(yyDollar[1].tableOptions, yyDollar[2].tableOption)
+		}
+	case 321:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2597
+		{
+			yyVAL.tableOptions = append(yyDollar[1].tableOptions, yyDollar[3].tableOption)
+		}
+	case 322:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2603
+		{
+			if bytes.Equal(yyDollar[1].node.Value, CHARACTER) {
+				yylex.Error("expecting character set")
+				return 1
+			}
+			yyVAL.tableOption = &TableOption{Name: yyDollar[1].node.Value, Value: yyDollar[3].node}
+		}
+	case 323:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2611
+		{
+			if !bytes.Equal(yyDollar[1].node.Value, CHARACTER) {
+				yylex.Error("expecting character set")
+				return 1
+			}
+			yyVAL.tableOption = &TableOption{Name: CHARSET, Value: yyDollar[4].node}
+		}
+	case 324:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2619
+		{
+			yyVAL.tableOption = &TableOption{Name: COLLATE_OPTION, Value: yyDollar[3].node}
+		}
+	case 325:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2623
+		{
+			if !bytes.Equal(yyDollar[2].tableOption.Name, CHARSET) && !bytes.Equal(yyDollar[2].tableOption.Name, COLLATE_OPTION) {
+				yylex.Error("unexpected default " + string(yyDollar[2].tableOption.Name))
+				return 1
+			}
+			yyVAL.tableOption = yyDollar[2].tableOption
+		}
+	case 329:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2637
+		{
+		}
+	case 330:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2639
+		{
+		}
+	case 331:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2643
+		{
+			yyVAL.columnDefinition = &ColumnDefinition{Name: yyDollar[1].node, Type: yyDollar[2].columnType}
+		}
+	case 332:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2649
+		{
+			yyVAL.columnType = ColumnType{Type: yyDollar[1].node.Value}
+		}
+	case 333:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2653
+		{
+			yyVAL.columnType = ColumnType{Type: yyDollar[1].node.Value, Length: yyDollar[3].node.Value}
+		}
+	case 334:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:2657
+		{
+			yyVAL.columnType = ColumnType{Type: yyDollar[1].node.Value, Length: yyDollar[3].node.Value, Scale: yyDollar[5].node.Value}
+		}
+	case 335:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2661
+		{
+			if !bytes.Equal(yyDollar[1].node.Value, ENUM) && !skipDDLClause(yylex, "expecting enum") {
+				return 1
+			}
+			yyVAL.columnType = ColumnType{Type: yyDollar[1].node.Value, EnumValues: yyDollar[3].nodes}
+		}
+	case 336:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2668
+		{
+			yyVAL.columnType = ColumnType{Type: []byte("set"), EnumValues: yyDollar[3].nodes}
+		}
+	case 337:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2674
+		{
+			yyVAL.nodes = []*Node{yyDollar[1].node}
+		}
+	case 338:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2678
+		{
+			yyVAL.nodes = append(yyDollar[1].nodes, yyDollar[3].node)
+		}
+	case 340:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2685
+		{
+			yyVAL.columnType.NotNull = false
+		}
+	case 341:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2689
+		{
+			yyVAL.columnType.NotNull = true
+		}
+	case 342:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2693
+		{
+			yyVAL.columnType.Default = yyDollar[3].node
+		}
+	case 343:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2697
+		{
+			yyVAL.columnType.OnUpdate = yyDollar[4].node
+		}
+	case 344:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2701
+		{
+			yyVAL.columnType.KeyOpt = UNIQUE
+		}
+	case 345:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2705
+		{
+			yyVAL.columnType.KeyOpt = UNIQUE
+		}
+	case 346:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2709
+		{
+			yyVAL.columnType.Collate = yyDollar[3].node.Value
+		}
+	case 347:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2713
+		{
+			if !bytes.Equal(yyDollar[2].node.Value, CHARACTER) && !skipDDLClause(yylex, "unexpected column attribute "+string(yyDollar[2].node.Value)) {
+				return 1
+			}
+			yyVAL.columnType.Charset = yyDollar[4].node.Value
+		}
+	case 348:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:2720
+		{
+			if string(yyDollar[3].node.Value) != "always" {
+				yylex.Error("expecting generated always")
+				return 1
+			}
+			yyVAL.columnType.Generated = yyDollar[6].node
+		}
+	case 349:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2728
+		{
+			yyVAL.columnType.Generated = yyDollar[4].node
+		}
+	case 350:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2732
+		{
+			yyVAL.columnType.Check = yyDollar[4].node
+		}
+	case 351:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2736
+		{
+			if !bytes.Equal(yyDollar[2].node.Value, PRIMARY) && !skipDDLClause(yylex, "unexpected column attribute "+string(yyDollar[2].node.Value)) {
+				return 1
+			}
+			yyVAL.columnType.KeyOpt = KEY
+		}
+	case 352:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2743
+		{
+			if !setColumnAttribute(&yyVAL.columnType, yyDollar[2].node.Value, nil) && !skipDDLClause(yylex, "unexpected column attribute "+string(yyDollar[2].node.Value)) {
+				return 1
+			}
+		}
+	case 353:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2749
+		{
+			if !setColumnAttribute(&yyVAL.columnType, yyDollar[2].node.Value, yyDollar[3].node) && !skipDDLClause(yylex, "unexpected column attribute "+string(yyDollar[2].node.Value)) {
+				return 1
+			}
+		}
+	case 354:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2755
+		{
+			if !setColumnAttribute(&yyVAL.columnType, yyDollar[2].node.Value, yyDollar[3].node) && !skipDDLClause(yylex, "unexpected column attribute "+string(yyDollar[2].node.Value)) {
+				return 1
+			}
+		}
+	case 358:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2769
+		{
+			yyDollar[2].node.Value = append([]byte("-"), yyDollar[2].node.Value...)
+			yyVAL.node = yyDollar[2].node
+		}
+	case 359:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2774
+		{
+			yyVAL.node = yyDollar[2].node
+		}
+	case 360:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2778
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[2].node)
+		}
+	case 361:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2783
+		{
+			SetAllowComments(yylex, true)
+		}
+	case 362:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2787
+		{
+			yyVAL.comments = yyDollar[2].comments
+			SetAllowComments(yylex, false)
+		}
+	case 363:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2793
+		{
+			yyVAL.comments = nil
+		}
+	case 364:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2797
+		{
+			yyVAL.comments = append(yyVAL.comments, Comment(yyDollar[2].node.Value))
+		}
+	case 365:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2803
+		{
+			yyVAL.setOp = SETOP_UNION
+		}
+	case 366:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2807
+		{
+			yyVAL.setOp = SETOP_UNION_ALL
+		}
+	case 367:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2811
+		{
+			yyVAL.setOp = SETOP_UNION_DISTINCT
+		}
+	case 368:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2815
+		{
+			yyVAL.setOp = SETOP_MINUS
+		}
+	case 369:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2819
+		{
+			yyVAL.setOp = SETOP_EXCEPT
+		}
+	case 370:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2823
+		{
+			yyVAL.setOp = SETOP_EXCEPT_ALL
+		}
+	case 371:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2827
+		{
+			yyVAL.setOp = SETOP_EXCEPT_DISTINCT
+		}
+	case 372:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2831
+		{
+			yyVAL.setOp = SETOP_INTERSECT
+		}
+	case 373:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2835
+		{
+			yyVAL.setOp = SETOP_INTERSECT_ALL
+		}
+	case 374:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2839
+		{
+			yyVAL.setOp = SETOP_INTERSECT_DISTINCT
+		}
+	case 375:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2844
+		{
+			yyVAL.nodes = nil
+		}
+	case 376:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2848
+		{
+			yyVAL.nodes = append(yyDollar[1].nodes, yyDollar[2].node)
+		}
+	case 386:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2865
+		{
+			yyVAL.selectExprs = SelectExprs{yyDollar[1].selectExpr}
+		}
+	case 387:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2869
+		{
+			yyVAL.selectExprs = append(yyVAL.selectExprs, yyDollar[3].selectExpr)
+		}
+	case 388:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2875
+		{
+			yyVAL.selectExpr = &StarExpr{}
+		}
+	case 389:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2879
+		{
+			yyVAL.selectExpr = &NonStarExpr{Expr: yyDollar[1].node, As: yyDollar[2].str}
+		}
+	case 390:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2883
+		{
+			yyVAL.selectExpr = &StarExpr{TableName: yyDollar[1].node.Value}
+		}
+	case 393:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2893
+		{
+			yyVAL.nodes = []*Node{yyDollar[1].node}
+		}
+	case 394:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2897
+		{
+			yyVAL.nodes = append(yyVAL.nodes, yyDollar[3].node)
+		}
+	case 395:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2902
+		{
+			yyVAL.str = nil
+		}
+	case 396:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2906
+		{
+			yyVAL.str = yyDollar[1].node.Value
+		}
+	case 397:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2910
+		{
+			yyVAL.str = yyDollar[2].node.Value
+		}
+	case 398:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2916
+		{
+			yyVAL.tableExprs = TableExprs{yyDollar[1].tableExpr}
+		}
+	case 399:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2920
+		{
+			yyVAL.tableExprs = append(yyVAL.tableExprs, yyDollar[3].tableExpr)
+		}
+	case 400:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2926
+		{
+			if yyDollar[1].node.Type == '(' && yyDollar[2].str == nil {
+				yylex.Error("every derived table must have its own alias")
+				return 1
+			}
+			yyVAL.tableExpr = &AliasedTableExpr{Expr: yyDollar[1].node, As: yyDollar[2].str, Hints: yyDollar[3].indexHints}
+		}
+	case 401:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2934
+		{
+			if yyDollar[1].node.Type == '(' {
+				yylex.Error("partitions can only be selected from a table")
+				return 1
+			}
+			yyVAL.tableExpr = &AliasedTableExpr{Expr: yyDollar[1].node, Partitions: yyDollar[2].partitions, As: yyDollar[3].str, Hints: yyDollar[4].indexHints}
+		}
+	case 402:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2942
+		{
+			if yyDollar[1].node.Type == '(' {
+				yylex.Error("partitions can only be selected from a table")
+				return 1
+			}
+			yyVAL.tableExpr = &AliasedTableExpr{Expr: yyDollar[1].node, Partitions: yyDollar[3].partitions, As: yyDollar[2].node.Value, Hints: yyDollar[4].indexHints}
+		}
+	case 403:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2950
+		{
+			if yyDollar[1].node.Type == '(' {
+				yylex.Error("partitions can only be selected from a table")
+				return 1
+			}
+			yyVAL.tableExpr = &AliasedTableExpr{Expr: yyDollar[1].node, Partitions: yyDollar[4].partitions, As: yyDollar[3].node.Value, Hints: yyDollar[5].indexHints}
+		}
+	case 404:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2958
+		{
+			yyVAL.tableExpr = &ParenTableExpr{Inner: yyDollar[2].tableExpr}
+		}
+	case 405:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2962
+		{
+			yyVAL.tableExpr = &JoinTableExpr{
+				LeftExpr:  yyDollar[1].tableExpr,
+				Join:      yyDollar[2].str,
+				RightExpr: yyDollar[3].tableExpr,
+			}
+		}
+	case 406:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2970
+		{
+			yyVAL.tableExpr = &JoinTableExpr{
+				LeftExpr:  yyDollar[1].tableExpr,
+				Join:      yyDollar[2].str,
+				RightExpr: yyDollar[3].tableExpr,
+				On:        yyDollar[5].node,
+			}
+		}
+	case 407:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2980
+		{
+			yyVAL.str = nil
+		}
+	case 408:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2984
+		{
+			yyVAL.str = yyDollar[1].node.Value
+		}
+	case 409:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2988
+		{
+			yyVAL.str = yyDollar[2].node.Value
+		}
+	case 410:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2994
+		{
+			yyVAL.str = yyDollar[1].node.Value
+		}
+	case 411:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2998
+		{
+			yyVAL.str = SJOIN
+		}
+	case 412:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3002
+		{
+			yyVAL.str = LJOIN
+		}
+	case 413:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3006
+		{
+			yyVAL.str = LJOIN
+		}
+	case 414:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3010
+		{
+			yyVAL.str = RJOIN
+		}
+	case 415:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3014
+		{
+			yyVAL.str = RJOIN
+		}
+	case 416:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3018
+		{
+			yyVAL.str = yyDollar[2].node.Value
+		}
+	case 417:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3022
+		{
+			yyVAL.str = CJOIN
+		}
+	case 418:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3026
+		{
+			yyVAL.str = NJOIN
+		}
+	case 420:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3033
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 421:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3037
+		{
+			yyDollar[1].node.Type = ID
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 422:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3042
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[2].statement)
+		}
+	case 423:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3047
+		{
+			yyVAL.partitions = nil
+		}
+	case 425:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3054
+		{
+			yyVAL.partitions = Partitions(yyDollar[3].nodes)
+		}
+	case 427:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3061
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 428:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3065
+		{
+			yyDollar[1].node.Type = ID
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 429:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3071
+		{
+			yyVAL.indexHints = nil
+		}
+	case 430:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3075
+		{
+			yyVAL.indexHints = append(yyDollar[1].indexHints, yyDollar[2].indexHint)
+		}
+	case 431:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:3081
+		{
+			if yyDollar[5].nodes == nil && yyDollar[1].hintType != USE_INDEX {
+				yylex.Error("expecting index names")
+				return 1
+			}
+			yyVAL.indexHint = &IndexHint{Type: yyDollar[1].hintType, For: yyDollar[3].hintFor, Indexes: yyDollar[5].nodes}
+		}
+	case 432:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3091
+		{
+			yyVAL.hintType = USE_INDEX
+		}
+	case 433:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3095
+		{
+			yyVAL.hintType = IGNORE_INDEX
+		}
+	case 434:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3099
+		{
+			yyVAL.hintType = FORCE_INDEX
+		}
+	case 435:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3104
+		{
+			yyVAL.hintFor = HINT_FOR_ALL
+		}
+	case 436:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3108
+		{
+			yyVAL.hintFor = HINT_FOR_JOIN
+		}
+	case 437:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3112
+		{
+			yyVAL.hintFor = HINT_FOR_ORDER_BY
+		}
+	case 438:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3116
+		{
+			yyVAL.hintFor = HINT_FOR_GROUP_BY
+		}
+	case 439:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3121
+		{
+			yyVAL.nodes = nil
+		}
+	case 441:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3127
+		{
+			yyVAL.node = NewSimpleParseNode(WHERE, "where")
+		}
+	case 442:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3131
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[2].node)
+		}
+	case 444:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3138
+		{
+			// TRUE and FALSE are read as values, so that expression
+			// has a single way of parsing them. They're the only
+			// values that can be used as conditions.
+			if !isBooleanValue(yyDollar[1].node) {
+				if isSubquery(yyDollar[1].node) && yyDollar[0].node.Type == NOT {
+					yylex.Error("expecting EXISTS before the subquery negated by NOT")
+				} else {
+					yylex.Error("syntax error")
+				}
+				return 1
+			}
+		}
+	case 446:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3156
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 447:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3160
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 448:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3164
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[2].node)
+		}
+	case 449:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3168
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[2].node)
+		}
+	case 450:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3174
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 451:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:3178
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node.Push(NewSimpleParseNode('(', "(").Push(yyDollar[5].statement)))
+		}
+	case 452:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3182
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 453:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3186
+		{
+			yyVAL.node = NewSimpleParseNode(NOT_IN, "not in").PushTwo(yyDollar[1].node, yyDollar[4].node)
+		}
+	case 454:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3190
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 455:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3194
+		{
+			yyVAL.node = NewSimpleParseNode(NOT_LIKE, "not like").PushTwo(yyDollar[1].node, yyDollar[4].node)
+		}
+	case 456:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:3198
+		{
+			yyVAL.node = NewSimpleParseNode(MEMBER_OF, "member of").PushTwo(yyDollar[1].node, yyDollar[5].node)
+		}
+	case 457:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3202
+		{
+			yyVAL.node = yyDollar[2].node
+			yyVAL.node.Push(yyDollar[1].node)
+			yyVAL.node.Push(yyDollar[3].node)
+			yyVAL.node.Push(yyDollar[5].node)
+		}
+	case 458:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:3209
+		{
+			yyVAL.node = NewSimpleParseNode(NOT_BETWEEN, "not between")
+			yyVAL.node.Push(yyDollar[1].node)
+			yyVAL.node.Push(yyDollar[4].node)
+			yyVAL.node.Push(yyDollar[6].node)
+		}
+	case 459:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3216
+		{
+			yyVAL.node = NewSimpleParseNode(IS_NULL, "is null").Push(yyDollar[1].node)
+		}
+	case 460:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3220
+		{
+			yyVAL.node = NewSimpleParseNode(IS_NOT_NULL, "is not null").Push(yyDollar[1].node)
+		}
+	case 461:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3224
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[3].statement)
+		}
+	case 474:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3248
+		{
+			yyVAL.sqlNode = yyDollar[1].node.Push(yyDollar[2].node)
+		}
+	case 475:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3252
+		{
+			yyVAL.sqlNode = yyDollar[1].statement
+		}
+	case 476:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3258
+		{
+			yyVAL.node = NewSimpleParseNode(NODE_LIST, "node_list")
+			yyVAL.node.Push(yyDollar[1].node)
+		}
+	case 477:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3263
+		{
+			yyVAL.node.Push(yyDollar[3].node)
+		}
+	case 478:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3269
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[2].node)
+		}
+	case 479:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3273
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[2].statement)
+		}
+	case 480:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3279
+		{
+			yyVAL.node = NewSimpleParseNode(NODE_LIST, "node_list")
+			yyVAL.node.Push(yyDollar[1].node)
+		}
+	case 481:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3284
+		{
+			yyVAL.node.Push(yyDollar[3].node)
+		}
+	case 484:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3292
+		{
+			yyVAL.node = yyDollar[1].node.NodeAt(0)
+		}
+	case 485:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3296
+		{
+			yylex.Error("the (+) outer join marker is not supported, use LEFT JOIN")
+			return 1
+		}
+	case 486:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3301
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[2].statement)
+		}
+	case 487:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3305
+		{
+			if yyDollar[2].node.Len() > 1 {
+				// A row constructor, like (a, b) in (a, b) = (1, 2).
+				yyVAL.node = NewSimpleParseNode(TUPLE, "tuple")
+				yyVAL.node.Sub = yyDollar[2].node.Sub
+			} else {
+				switch yyDollar[2].node.NodeAt(0).Type {
+				case NUMBER, STRING, ID, VALUE_ARG, '(', '.', TUPLE:
+					yyVAL.node = yyDollar[2].node.NodeAt(0)
+				default:
+					yyVAL.node = yyDollar[1].node.Push(yyDollar[2].node.NodeAt(0))
+				}
+			}
+		}
+	case 488:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3320
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 489:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3324
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 490:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3328
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 491:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3332
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 492:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3336
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 493:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3340
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 494:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3344
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 495:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3348
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 496:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3352
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 497:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3356
+		{
+			if yyDollar[2].node.Type == NUMBER { // Simplify trivial unary expressions
+				switch yyDollar[1].node.Type {
+				case UMINUS:
+					yyDollar[2].node.Value = append(yyDollar[1].node.Value, yyDollar[2].node.Value...)
+					yyVAL.node = yyDollar[2].node
+				case UPLUS:
+					yyVAL.node = yyDollar[2].node
+				default:
+					yyVAL.node = yyDollar[1].node.Push(yyDollar[2].node)
+				}
+			} else {
+				yyVAL.node = yyDollar[1].node.Push(yyDollar[2].node)
+			}
+		}
+	case 499:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3373
+		{
+			yyVAL.node = NewSimpleParseNode(OVER, "over").PushTwo(yyDollar[1].node, yyDollar[2].overClause)
+		}
+	case 500:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3377
+		{
+			yyDollar[1].node.Type = FUNCTION
+			yyVAL.node = yyDollar[1].node.Push(NewSimpleParseNode(NODE_LIST, "node_list"))
+		}
+	case 501:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3382
+		{
+			if column, ok := valuesColumn(yyDollar[1].node, yyDollar[3].selectExprs); ok {
+				yyDollar[1].node.Type = VALUES_FUNC
+				yyVAL.node = yyDollar[1].node.Push(column)
+			} else {
+				yyDollar[1].node.Type = FUNCTION
+				yyVAL.node = yyDollar[1].node.Push(yyDollar[3].selectExprs)
+			}
+		}
+	case 503:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3393
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 504:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3397
+		{
+			if !bytes.Equal(yyDollar[3].node.Value, TIME) || !bytes.Equal(yyDollar[4].node.Value, ZONE) {
+				yylex.Error("expecting time zone")
+				return 1
+			}
+			yyVAL.node = NewSimpleParseNode(AT_TIME_ZONE, "at time zone").PushTwo(yyDollar[1].node, yyDollar[5].node)
+		}
+	case 505:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3405
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 506:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3409
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 507:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3415
+		{
+			yyDollar[1].node.Type = FUNCTION
+			yyVAL.node = yyDollar[1].node.Push(NewSimpleParseNode(NODE_LIST, "node_list"))
+		}
+	case 508:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3420
+		{
+			yyDollar[1].node.Type = FUNCTION
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[3].selectExprs)
+		}
+	case 509:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3425
+		{
+			yyDollar[1].node.Type = FUNCTION
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[3].node)
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[4].selectExprs)
+		}
+	case 510:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3433
+		{
+			yyVAL.overClause = yyDollar[3].overClause
+		}
+	case 511:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3437
+		{
+			yyVAL.overClause = &OverClause{Name: yyDollar[2].node}
+		}
+	case 512:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3443
+		{
+			yyVAL.overClause = &OverClause{PartitionBy: yyDollar[1].node, OrderBy: yyDollar[2].node, Frame: yyDollar[3].frameClause}
+		}
+	case 513:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3447
+		{
+			yyVAL.overClause = &OverClause{Name: yyDollar[1].node, PartitionBy: yyDollar[2].node, OrderBy: yyDollar[3].node, Frame: yyDollar[4].frameClause}
+		}
+	case 514:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3452
+		{
+			yyVAL.namedWindows = nil
+		}
+	case 515:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3456
+		{
+			yyVAL.namedWindows = yyDollar[2].namedWindows
+		}
+	case 516:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3462
+		{
+			yyVAL.namedWindows = NamedWindows{yyDollar[1].namedWindow}
+		}
+	case 517:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3466
+		{
+			yyVAL.namedWindows = append(yyDollar[1].namedWindows, yyDollar[3].namedWindow)
+		}
+	case 518:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3472
+		{
+			yyVAL.namedWindow = &NamedWindow{Name: yyDollar[1].node, Spec: yyDollar[4].overClause}
+		}
+	case 519:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3477
+		{
+			yyVAL.node = nil
+		}
+	case 520:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3481
+		{
+			yyVAL.node = yyDollar[3].node
+		}
+	case 521:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3486
+		{
+			yyVAL.frameClause = nil
+		}
+	case 522:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3490
+		{
+			yyVAL.frameClause = &FrameClause{Unit: yyDollar[1].node.Value, Start: yyDollar[2].framePoint}
+		}
+	case 523:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3494
+		{
+			yyVAL.frameClause = &FrameClause{Unit: yyDollar[1].node.Value, Start: yyDollar[3].framePoint, End: yyDollar[5].framePoint}
+		}
+	case 526:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3504
+		{
+			point, ok := newFramePoint(yyDollar[1].node, yyDollar[2].node.Value)
+			if !ok {
+				yylex.Error("unexpected frame bound")
+				return 1
+			}
+			yyVAL.framePoint = point
+		}
+	case 527:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3514
+		{
+			yyVAL.node = nil
+		}
+	case 528:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3518
+		{
+			yyVAL.node = yyDollar[3].node
+		}
+	case 536:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3533
+		{
+			yyVAL.node = NewSimpleParseNode(UPLUS, "+")
+		}
+	case 537:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3537
+		{
+			yyVAL.node = NewSimpleParseNode(UMINUS, "-")
+		}
+	case 539:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3544
+		{
+			yyVAL.node = NewSimpleParseNode(CASE_WHEN, "case")
+			yyVAL.node.Push(yyDollar[2].node)
+		}
+	case 540:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3549
+		{
+			yyVAL.node.PushTwo(yyDollar[2].node, yyDollar[3].node)
+		}
+	case 541:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3555
+		{
+			yyVAL.node = NewSimpleParseNode(WHEN_LIST, "when_list")
+			yyVAL.node.Push(yyDollar[1].node)
+		}
+	case 542:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3560
+		{
+			yyVAL.node.Push(yyDollar[2].node)
+		}
+	case 543:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3566
+		{
+			yyVAL.node.PushTwo(yyDollar[2].node, yyDollar[4].node)
+		}
+	case 544:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3570
+		{
+			yyVAL.node.Push(yyDollar[2].node)
+		}
+	case 546:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3577
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 547:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3581
+		{
+			yyDollar[1].node.Type = ID
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 548:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3586
+		{
+			yyVAL.node = yyDollar[4].node.PushTwo(yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node), yyDollar[5].node)
+		}
+	case 554:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3598
+		{
+			yyVAL.node = NewSimpleParseNode(GROUP, "group")
+		}
+	case 555:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3602
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[3].node)
+		}
+	case 556:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3607
+		{
+			yyVAL.node = NewSimpleParseNode(HAVING, "having")
+		}
+	case 557:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3611
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[2].node)
+		}
+	case 558:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3616
+		{
+			yyVAL.node = NewSimpleParseNode(ORDER, "order")
+		}
+	case 559:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3620
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[3].node)
+		}
+	case 560:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3626
+		{
+			yyVAL.node = NewSimpleParseNode(NODE_LIST, "node_list")
+			yyVAL.node.Push(yyDollar[1].node)
+		}
+	case 561:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3631
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[3].node)
+		}
+	case 562:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3637
+		{
+			yyVAL.node = yyDollar[2].node.Push(yyDollar[1].node)
+			if yyDollar[3].node != nil {
+				yyVAL.node = yyDollar[3].node.Push(yyVAL.node)
+			}
+		}
+	case 563:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3645
+		{
+			yyVAL.node = NewSimpleParseNode(ASC, "asc")
+		}
+	case 566:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3652
+		{
+			yyVAL.node = nil
+		}
+	case 567:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3656
+		{
+			if !bytes.Equal(yyDollar[1].node.Value, NULLS) {
+				yylex.Error("expecting nulls")
+				return 1
+			}
+			switch {
+			case bytes.Equal(yyDollar[2].node.Value, FIRST):
+				yyVAL.node = NewSimpleParseNode(NULLS_FIRST, "nulls first")
+			case bytes.Equal(yyDollar[2].node.Value, LAST):
+				yyVAL.node = NewSimpleParseNode(NULLS_LAST, "nulls last")
+			default:
+				yylex.Error("expecting first or last")
+				return 1
+			}
+		}
+	case 568:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3673
+		{
+			yyVAL.node = NewSimpleParseNode(LIMIT, "limit")
+		}
+	case 570:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3680
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[2].node)
+		}
+	case 571:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3684
+		{
+			yyVAL.node = yyDollar[1].node.PushTwo(yyDollar[2].node, yyDollar[4].node)
+		}
+	case 572:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3689
+		{
+			yyVAL.node = nil
+		}
+	case 573:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3693
+		{
+			yyDollar[2].node.Type = FUNCTION
+			yyVAL.node = yyDollar[2].node.Push(NewSimpleParseNode(NODE_LIST, "node_list"))
+		}
+	case 574:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3698
+		{
+			yyDollar[2].node.Type = FUNCTION
+			yyVAL.node = yyDollar[2].node.Push(yyDollar[4].selectExprs)
+		}
+	case 575:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3704
+		{
+			yyVAL.selectInto = nil
+		}
+	case 577:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3711
+		{
+			fields, lines, msg := newLoadOptions(yyDollar[3].nodeLists)
+			if msg != "" {
+				yylex.Error(msg)
+				return 1
+			}
+			if (fields != nil || lines != nil) && !bytes.Equal(yyDollar[2].selectInto.Type, OUTFILE) {
+				yylex.Error("unexpected options for dumpfile")
+				return 1
+			}
+			yyDollar[2].selectInto.Fields, yyDollar[2].selectInto.Lines = fields, lines
+			yyVAL.selectInto = yyDollar[2].selectInto
+		}
+	case 578:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3725
+		{
+			yyVAL.selectInto = &SelectInto{Variables: yyDollar[2].nodes}
+		}
+	case 579:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3731
+		{
+			if !bytes.Equal(yyDollar[1].node.Value, OUTFILE) && !bytes.Equal(yyDollar[1].node.Value, DUMPFILE) {
+				yylex.Error("expecting outfile or dumpfile")
+				return 1
+			}
+			yyVAL.selectInto = &SelectInto{Type: yyDollar[1].node.Value, FileName: yyDollar[2].node}
+		}
+	case 580:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3741
+		{
+			yyVAL.nodes = []*Node{yyDollar[1].node}
+		}
+	case 581:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3745
+		{
+			yyVAL.nodes = append(yyDollar[1].nodes, yyDollar[3].node)
+		}
+	case 582:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3751
+		{
+			// Only user variables can be assigned outside
+			// of stored programs.
+			if yyDollar[1].node.Value[0] != '@' {
+				yylex.Error("syntax error")
+				return 1
+			}
+			yyVAL.node = yyDollar[1].node
+		}
+	case 583:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3762
+		{
+			yyVAL.lock = &Lock{Type: NO_LOCK}
+		}
+	case 584:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3766
+		{
+			yyVAL.lock = &Lock{Type: FOR_UPDATE, Wait: yyDollar[3].lockWait}
+		}
+	case 585:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3770
+		{
+			yyVAL.lock = &Lock{Type: FOR_SHARE, Wait: yyDollar[3].lockWait}
+		}
+	case 586:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3774
+		{
+			yyVAL.lock = &Lock{Type: LOCK_IN_SHARE_MODE}
+		}
+	case 587:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3779
+		{
+			yyVAL.lockWait = LOCK_WAIT
+		}
+	case 588:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3783
+		{
+			yyVAL.lockWait = LOCK_NOWAIT
+		}
+	case 589:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3787
+		{
+			yyVAL.lockWait = LOCK_SKIP_LOCKED
+		}
+	case 590:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3792
+		{
+			yyVAL.columns = nil
+		}
+	case 591:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3796
+		{
+			yyVAL.columns = yyDollar[2].columns
+		}
+	case 592:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3802
+		{
+			yyVAL.columns = Columns{&NonStarExpr{Expr: yyDollar[1].node}}
+		}
+	case 593:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3806
+		{
+			yyVAL.columns = append(yyVAL.columns, &NonStarExpr{Expr: yyDollar[3].node})
+		}
+	case 594:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3812
+		{
+			yyVAL.nodes = []*Node{yyDollar[1].node}
+		}
+	case 595:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3816
+		{
+			yyVAL.nodes = append(yyDollar[1].nodes, yyDollar[3].node)
+		}
+	case 596:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3821
+		{
+			yyVAL.rowAlias = nil
+		}
+	case 598:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3828
+		{
+			yyVAL.rowAlias = &RowAlias{Name: yyDollar[2].node, Columns: yyDollar[3].columns}
+		}
+	case 599:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3833
+		{
+			yyVAL.node = NewSimpleParseNode(DUPLICATE, "duplicate")
+		}
+	case 600:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3837
+		{
+			yyVAL.node = yyDollar[2].node.Push(yyDollar[5].node)
+		}
+	case 601:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3843
+		{
+			yyVAL.node = NewSimpleParseNode(NODE_LIST, "node_list")
+			yyVAL.node.Push(yyDollar[1].node)
+		}
+	case 602:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3848
+		{
+			yyVAL.node = yyDollar[1].node.Push(yyDollar[3].node)
+		}
+	case 603:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3854
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 604:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3860
+		{
+			yyVAL.setExprs = SetExprs{yyDollar[1].setExpr}
+		}
+	case 605:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3864
+		{
+			yyVAL.setExprs = append(yyDollar[1].setExprs, yyDollar[3].setExpr)
+		}
+	case 606:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3870
+		{
+			yyDollar[1].setExpr.Expr = yyDollar[3].node
+			yyVAL.setExpr = yyDollar[1].setExpr
+		}
+	case 607:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3875
+		{
+			yyDollar[1].setExpr.Expr = yyDollar[3].node
+			yyVAL.setExpr = yyDollar[1].setExpr
+		}
+	case 609:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3883
+		{
+			yyVAL.nodes = []*Node{yyDollar[1].node, yyDollar[2].node, yyDollar[3].node}
+		}
+	case 610:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3887
+		{
+			yyVAL.nodes = append(yyDollar[1].nodes, yyDollar[2].node)
+		}
+	case 611:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3891
+		{
+			yyVAL.nodes = append(yyDollar[1].nodes, yyDollar[2].node, yyDollar[3].node)
+		}
+	case 612:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3897
+		{
+			yyVAL.setExpr = &SetExpr{Name: yyDollar[1].node}
+		}
+	case 613:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3901
+		{
+			scope := setScope(yyDollar[1].node.Value)
+			if scope == nil {
+				yylex.Error("expecting scope")
+				return 1
+			}
+			if yyDollar[2].node.Type == ID && bytes.HasPrefix(yyDollar[2].node.Value, []byte("@")) || yyDollar[2].node.Type == '.' && bytes.HasPrefix(yyDollar[2].node.NodeAt(0).Value, []byte("@")) {
+				yylex.Error("unexpected scope for variable")
+				return 1
+			}
+			yyVAL.setExpr = &SetExpr{Scope: scope, Name: yyDollar[2].node}
+		}
+	case 614:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3916
+		{
+			switch {
+			case bytes.EqualFold(yyDollar[1].node.Value, NAMES):
+				yyVAL.setExpr = &SetExpr{Name: NewSimpleParseNode(SET_NAMES, "names"), Expr: yyDollar[2].node}
+			case bytes.EqualFold(yyDollar[1].node.Value, CHARSET):
+				yyVAL.setExpr = &SetExpr{Name: NewSimpleParseNode(SET_CHARSET, "character set"), Expr: yyDollar[2].node}
+			default:
+				yylex.Error("expecting names or charset")
+				return 1
+			}
+		}
+	case 615:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3928
+		{
+			if !bytes.EqualFold(yyDollar[1].node.Value, NAMES) {
+				yylex.Error("expecting names")
+				return 1
+			}
+			yyVAL.setExpr = &SetExpr{Name: NewSimpleParseNode(SET_NAMES, "names"), Expr: yyDollar[3].node.PushTwo(yyDollar[2].node, yyDollar[4].node)}
+		}
+	case 616:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3936
+		{
+			if !bytes.EqualFold(yyDollar[1].node.Value, CHARACTER) {
+				yylex.Error("expecting character set")
+				return 1
+			}
+			yyVAL.setExpr = &SetExpr{Name: NewSimpleParseNode(SET_CHARSET, "character set"), Expr: yyDollar[3].node}
+		}
+	case 624:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3957
+		{
+			yyVAL.node = yyDollar[2].node.PushTwo(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 625:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3962
+		{
+			yyVAL.node = nil
+		}
+	case 627:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3966
+		{
+			yyVAL.node = nil
+		}
+	case 631:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3974
+		{
+			yyVAL.boolean = false
+		}
+	case 632:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3976
+		{
+			yyVAL.boolean = true
+		}
+	case 633:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3979
+		{
+			yyVAL.boolean = false
+		}
+	case 634:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3981
+		{
+			yyVAL.boolean = true
+		}
+	case 635:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3984
+		{
+			yyVAL.node = nil
+		}
+	case 641:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3994
+		{
+			yyVAL.node = nil
+		}
+	case 643:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3998
+		{
+			yyVAL.bytes = nil
+		}
+	case 644:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4002
+		{
+			yyVAL.bytes = yyDollar[2].node.Value
+		}
+	case 645:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4008
+		{
+			yyVAL.node.LowerCase()
+		}
+	case 647:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4015
+		{
+			yyVAL.node.Type = ID
+		}
+	case 649:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4022
+		{
+			yyVAL.node.Type = ID
 		}
-	case 188:
-		//line sql.y:876
+	case 674:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4057
 		{
 			ForceEOF(yylex)
 		}
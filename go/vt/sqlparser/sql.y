@@ -5,7 +5,10 @@
 %{
 package sqlparser
 
-import "bytes"
+import (
+  "bytes"
+  "strings"
+)
 
 func SetParseTree(yylex interface{}, stmt Statement) {
   tn := yylex.(*Tokenizer)
@@ -22,13 +25,617 @@ func ForceEOF(yylex interface{}) {
   tn.ForceEOF = true
 }
 
+// setColumnAttribute applies the column attribute name, with its
+// optional value, to columnType. It returns false if the attribute
+// is not recognized.
+func setColumnAttribute(columnType *ColumnType, name []byte, value *Node) bool {
+  switch string(name) {
+  case "unsigned":
+    columnType.Unsigned = value == nil
+  case "zerofill":
+    columnType.Zerofill = value == nil
+  case "auto_increment":
+    columnType.Autoincrement = value == nil
+  case "visible", "invisible":
+    columnType.Visibility = name
+    return value == nil
+  case "comment":
+    columnType.Comment = value
+    return value != nil && value.Type == STRING
+  case "srid":
+    columnType.SRID = value
+    return value != nil && value.Type == NUMBER
+  case "virtual", "stored":
+    columnType.Storage = name
+    return value == nil && columnType.Generated != nil
+  default:
+    return false
+  }
+  return value == nil
+}
+
+// updateListToValues converts the assignments of an INSERT ... SET
+// into the equivalent column list and single row VALUES clause.
+func updateListToValues(updateList *Node) (Columns, *Node) {
+  columns := make(Columns, updateList.Len())
+  row := NewSimpleParseNode(NODE_LIST, "node_list")
+  for i := 0; i < updateList.Len(); i++ {
+    columns[i] = &NonStarExpr{Expr: updateList.NodeAt(i).NodeAt(0)}
+    row.Push(updateList.NodeAt(i).NodeAt(1))
+  }
+  rows := NewSimpleParseNode(NODE_LIST, "node_list")
+  rows.Push(NewSimpleParseNode('(', "(").Push(row))
+  return columns, NewSimpleParseNode(VALUES, "values").Push(rows)
+}
+
+// valuesColumn returns the column of args if name and args
+// are a VALUES(col) reference to the value that an INSERT
+// would have inserted, like in ON DUPLICATE KEY UPDATE.
+func valuesColumn(name *Node, args SelectExprs) (*Node, bool) {
+  if name.Type != VALUES || len(args) != 1 {
+    return nil, false
+  }
+  expr, ok := args[0].(*NonStarExpr)
+  if !ok || expr.As != nil {
+    return nil, false
+  }
+  switch expr.Expr.Type {
+  case ID, '.':
+    return expr.Expr, true
+  }
+  return nil, false
+}
+
+// isBooleanValue returns true if node is TRUE
+// or FALSE, possibly parenthesized.
+func isBooleanValue(node *Node) bool {
+  for node.Type == '(' {
+    inner, ok := node.At(0).(*Node)
+    if !ok {
+      return false
+    }
+    node = inner
+  }
+  return node.Type == TRUE || node.Type == FALSE
+}
+
+// isSubquery returns true if node is a parenthesized select.
+func isSubquery(node *Node) bool {
+  if node.Type != '(' {
+    return false
+  }
+  _, ok := node.At(0).(SelectStatement)
+  return ok
+}
+
+// setShowFilter sets the LIKE or WHERE filter of show. It
+// returns false if the filter isn't allowed, which is the case
+// of WHERE with the vitess objects.
+func setShowFilter(show *Show, filter *Node) bool {
+  switch {
+  case filter == nil:
+  case filter.Type == WHERE && show.VitessObject != nil:
+    return false
+  case filter.Type == WHERE:
+    show.Where = filter.NodeAt(0)
+  default:
+    switch object := show.VitessObject.(type) {
+    case *ShowVitessKeyspaces:
+      object.Like = filter.NodeAt(0)
+    case *ShowVitessShards:
+      object.Like = filter.NodeAt(0)
+    default:
+      show.Like = filter.NodeAt(0)
+    }
+  }
+  return true
+}
+
+// setDBOptions sets the character set and collation of ddl
+// from options. It returns false if one of the options isn't
+// allowed for a database.
+func setDBOptions(ddl *DBDDL, options []*TableOption) bool {
+  for _, option := range options {
+    switch {
+    case bytes.Equal(option.Name, CHARSET):
+      ddl.Charset = string(option.Value.Value)
+    case bytes.Equal(option.Name, COLLATE_OPTION):
+      ddl.Collate = string(option.Value.Value)
+    default:
+      return false
+    }
+  }
+  return true
+}
+
+// newFramePoint returns the window frame bound made of expr
+// followed by word. expr is the unbounded or current keyword,
+// or the offset of the bound. It returns false if the bound
+// isn't valid.
+func newFramePoint(expr *Node, word []byte) (*FramePoint, bool) {
+  switch {
+  case expr.Type == ID && bytes.Equal(expr.Value, UNBOUNDED):
+    if bytes.Equal(word, PRECEDING) || bytes.Equal(word, FOLLOWING) {
+      return &FramePoint{Type: []byte("unbounded " + string(word))}, true
+    }
+  case expr.Type == ID && bytes.Equal(expr.Value, CURRENT):
+    if bytes.Equal(word, ROW) {
+      return &FramePoint{Type: []byte("current row")}, true
+    }
+  case bytes.Equal(word, PRECEDING), bytes.Equal(word, FOLLOWING):
+    return &FramePoint{Type: word, Expr: expr}, true
+  }
+  return nil, false
+}
+
+// newUnion returns the union of left and right. If left is
+// itself a union that isn't closed by trailing clauses, right
+// is appended to its arms, so that chains of unions stay flat.
+// Since INTERSECT binds tighter than the other set operations,
+// an intersect arm that follows one of them is instead grouped
+// with the select of the last arm.
+func newUnion(left SelectStatement, typ int, right SelectStatement) *Union {
+  union, ok := left.(*Union)
+  if !ok || union.OrderBy != nil {
+    return &Union{First: left, Arms: []*UnionArm{{Type: typ, Select: right}}}
+  }
+  if last := union.Arms[len(union.Arms)-1]; IsIntersect(typ) && !IsIntersect(last.Type) {
+    last.Select = newUnion(last.Select, typ, right)
+    return union
+  }
+  union.Arms = append(union.Arms, &UnionArm{Type: typ, Select: right})
+  return union
+}
+
+// setUnionTail sets the clauses that follow the parenthesized
+// last select of union, unless they're all empty.
+func setUnionTail(union *Union, orderBy, limit *Node, lock *Lock) {
+  if orderBy.Len() == 0 && limit.Len() == 0 && lock.Type == NO_LOCK {
+    return
+  }
+  union.OrderBy, union.Limit, union.Lock = orderBy, limit, lock
+}
+
+// selectOptions splits the keywords between SELECT and the
+// select expressions into the DISTINCT flag and the other
+// select options, which keep their order.
+func selectOptions(nodes []*Node) (distinct Distinct, options []string) {
+  for _, node := range nodes {
+    if node.Type == DISTINCT {
+      distinct = true
+      continue
+    }
+    options = append(options, string(node.Value))
+  }
+  return distinct, options
+}
+
+// setScope returns the scope named by the SET scope keyword
+// or @@scope prefix name, or nil if name is not a scope.
+func setScope(name []byte) []byte {
+  switch {
+  case bytes.EqualFold(name, GLOBAL):
+    return GLOBAL
+  case bytes.EqualFold(name, SESSION), bytes.EqualFold(name, LOCAL):
+    return SESSION
+  }
+  return nil
+}
+
+// resolveSetScopes sets the scope of the assignments of a SET
+// statement. A scope keyword applies to the system variables that
+// follow it up to the next scope keyword, and the @@ and @@scope.
+// prefixes are moved from the variable names into their scope.
+// Once a scope was set, the system variables without a scope
+// keyword get their session scope explicitly, so that the
+// formatted statement doesn't change their meaning.
+func resolveSetScopes(exprs SetExprs) {
+  var scope []byte
+  for _, expr := range exprs {
+    if expr.Scope != nil {
+      scope = expr.Scope
+      continue
+    }
+    name := expr.Name
+    switch {
+    case name.Type == '.' && name.NodeAt(0).Type == ID && bytes.HasPrefix(name.NodeAt(0).Value, []byte("@@")):
+      if s := setScope(name.NodeAt(0).Value[2:]); s != nil {
+        expr.Scope, expr.Name = s, name.NodeAt(1)
+      }
+    case name.Type == ID && bytes.HasPrefix(name.Value, []byte("@@")):
+      expr.Scope = SESSION
+      name.Value = name.Value[2:]
+    case name.Type == ID && bytes.HasPrefix(name.Value, []byte("@")):
+    default:
+      expr.Scope = scope
+    }
+    if expr.Scope != nil && scope == nil {
+      scope = SESSION
+    }
+  }
+}
+
+// newTransactionChars returns the TransactionChars of the words
+// of a SET TRANSACTION statement, which start with the optional
+// scope and TRANSACTION, and contain ',' nodes between the
+// characteristics. It returns an error message if they're invalid.
+func newTransactionChars(words []*Node) (*TransactionChars, string) {
+  chars := &TransactionChars{Scope: setScope(words[0].Value)}
+  if chars.Scope != nil {
+    words = words[1:]
+  }
+  if words[0].Type != ID || !bytes.EqualFold(words[0].Value, TRANSACTION) {
+    return nil, "expecting transaction"
+  }
+  if len(words) == 1 {
+    return nil, "expecting transaction characteristic"
+  }
+  var characteristic []byte
+  for i, word := range words[1:] {
+    if word.Type == ID {
+      if characteristic != nil {
+        characteristic = append(characteristic, ' ')
+      }
+      characteristic = append(characteristic, bytes.ToLower(word.Value)...)
+      if i != len(words)-2 {
+        continue
+      }
+    }
+    switch level := bytes.TrimPrefix(characteristic, []byte("isolation level ")); {
+    case len(level) < len(characteristic) && chars.IsolationLevel == nil:
+      for _, isolationLevel := range [][]byte{REPEATABLE_READ, READ_COMMITTED, READ_UNCOMMITTED, SERIALIZABLE} {
+        if bytes.Equal(level, isolationLevel) {
+          chars.IsolationLevel = isolationLevel
+        }
+      }
+      if chars.IsolationLevel == nil {
+        return nil, "unexpected isolation level"
+      }
+    case bytes.Equal(characteristic, READ_ONLY) && chars.AccessMode == nil:
+      chars.AccessMode = READ_ONLY
+    case bytes.Equal(characteristic, READ_WRITE) && chars.AccessMode == nil:
+      chars.AccessMode = READ_WRITE
+    default:
+      return nil, "unexpected transaction characteristic"
+    }
+    characteristic = nil
+  }
+  return chars, ""
+}
+
+// lockType returns the LOCK TABLES lock type named by words,
+// and the lowercased name. ok is false if it's not a lock type.
+func lockType(words ...*Node) (lock int, name string, ok bool) {
+  for i, word := range words {
+    if i != 0 {
+      name += " "
+    }
+    name += string(bytes.ToLower(word.Value))
+  }
+  for lock, lockName := range lockTypeName {
+    if name == lockName {
+      return lock, name, true
+    }
+  }
+  return 0, name, false
+}
+
+// adminModifier returns the lowercased modifier of an ANALYZE,
+// OPTIMIZE or REPAIR statement. ok is false if it's not one.
+func adminModifier(word *Node) (modifier []byte, ok bool) {
+  modifier = bytes.ToLower(word.Value)
+  if !bytes.Equal(modifier, LOCAL) && !bytes.Equal(modifier, NO_WRITE_TO_BINLOG) {
+    return nil, false
+  }
+  return modifier, true
+}
+
+// newFlush builds a FLUSH statement from its comma separated
+// options. A leading modifier is recognized, and the names
+// that follow TABLES are the flushed tables. The other options
+// are kept as the lowercased type. On error, it returns nil
+// and the error message.
+func newFlush(options [][]*Node) (*Flush, string) {
+  flush := &Flush{}
+  first := options[0]
+  if len(first) > 1 {
+    if modifier, ok := adminModifier(first[0]); ok {
+      flush.Modifier = modifier
+      first = first[1:]
+    }
+  }
+  if first[0].Type == TABLE || bytes.EqualFold(first[0].Value, TABLES) {
+    flush.Type = TABLES
+    if len(first) > 2 {
+      return nil, "unexpected " + string(first[2].Value)
+    }
+    if len(first) == 2 {
+      flush.Tables = append(flush.Tables, first[1])
+    }
+    for _, option := range options[1:] {
+      if flush.Tables == nil || len(option) != 1 {
+        return nil, "expecting table name"
+      }
+      flush.Tables = append(flush.Tables, option[0])
+    }
+    return flush, ""
+  }
+  var types [][]byte
+  for i, option := range options {
+    if i == 0 {
+      option = first
+    }
+    words := make([][]byte, len(option))
+    for j, word := range option {
+      if word.Type != ID {
+        return nil, "unexpected flush option " + String(word)
+      }
+      words[j] = bytes.ToLower(word.Value)
+    }
+    types = append(types, bytes.Join(words, []byte(" ")))
+  }
+  flush.Type = bytes.Join(types, []byte(", "))
+  return flush, ""
+}
+
+// newLoadOptions builds the FIELDS and LINES clauses of LOAD DATA
+// and SELECT ... INTO OUTFILE from their options. Every option is
+// a list of words followed by the value. FIELDS (or COLUMNS) and
+// LINES are the first word of the first option of their clause. On
+// error, it returns the error message.
+func newLoadOptions(options [][]*Node) (fields *FieldsOptions, lines *LinesOptions, msg string) {
+  var clause string
+  for _, option := range options {
+    words, value := option[:len(option)-1], option[len(option)-1]
+    switch strings.ToLower(string(words[0].Value)) {
+    case "fields", "columns":
+      if fields != nil || lines != nil {
+        return nil, nil, "unexpected " + string(words[0].Value)
+      }
+      clause, words, fields = "fields", words[1:], &FieldsOptions{}
+    case "lines":
+      if lines != nil {
+        return nil, nil, "unexpected lines"
+      }
+      clause, words, lines = "lines", words[1:], &LinesOptions{}
+    }
+    var name string
+    for i, word := range words {
+      if i != 0 {
+        name += " "
+      }
+      name += strings.ToLower(string(word.Value))
+    }
+    switch {
+    case clause == "fields" && name == "terminated" && fields.TerminatedBy == nil:
+      fields.TerminatedBy = value
+    case clause == "fields" && (name == "enclosed" || name == "optionally enclosed") && fields.EnclosedBy == nil:
+      fields.EnclosedBy, fields.OptionallyEnclosed = value, name == "optionally enclosed"
+    case clause == "fields" && name == "escaped" && fields.EscapedBy == nil:
+      fields.EscapedBy = value
+    case clause == "lines" && name == "starting" && lines.StartingBy == nil:
+      lines.StartingBy = value
+    case clause == "lines" && name == "terminated" && lines.TerminatedBy == nil:
+      lines.TerminatedBy = value
+    default:
+      return nil, nil, "unexpected option " + name + " by"
+    }
+  }
+  return fields, lines, ""
+}
+
+// rawTail returns the text of the query that starts at
+// the last token scanned. It's used after a syntax error
+// to keep the clauses that are not parsed.
+func rawTail(yylex interface{}) []byte {
+  tkn := yylex.(*Tokenizer)
+  if tkn.tokenStart >= len(tkn.sql) {
+    return nil
+  }
+  return bytes.TrimSpace([]byte(tkn.sql[tkn.tokenStart:]))
+}
+
+// markAlterOption records where the next ALTER TABLE operation
+// starts in the query. The reduction doesn't need a lookahead, so
+// the tokenizer is just after the previous token.
+func markAlterOption(yylex interface{}) {
+  tkn := yylex.(*Tokenizer)
+  tkn.alterMarks = append(tkn.alterMarks, tkn.position-1)
+  tkn.skippedDDL = false
+}
+
+// alterRawText sets the text of the AlterRaw operations, which
+// extends up to the comma that precedes the next operation, and
+// drops the empty ones.
+func alterRawText(yylex interface{}, options []AlterOption) []AlterOption {
+  tkn := yylex.(*Tokenizer)
+  var result []AlterOption
+  for i, option := range options {
+    if raw, ok := option.(*AlterRaw); ok {
+      end := len(tkn.sql)
+      if i+1 < len(tkn.alterMarks) {
+        end = tkn.alterMarks[i+1] - 1
+      }
+      if start := tkn.alterMarks[i]; start < end {
+        raw.Raw = bytes.TrimSpace([]byte(tkn.sql[start:end]))
+      }
+      if len(raw.Raw) == 0 {
+        continue
+      }
+    }
+    result = append(result, option)
+  }
+  return result
+}
+
+// markRawText records where the clauses that are kept as raw
+// text if they can't be parsed start in the query: the table
+// options of CREATE TABLE, or the columns of CREATE INDEX. The
+// reduction doesn't need a lookahead, so the tokenizer is just
+// after the previous token.
+func markRawText(yylex interface{}) {
+  tkn := yylex.(*Tokenizer)
+  tkn.rawStart = tkn.position - 1
+}
+
+// rawText returns the text of the query that starts at
+// the position recorded by markRawText, or nil if it's empty.
+func rawText(yylex interface{}) []byte {
+  tkn := yylex.(*Tokenizer)
+  if tkn.rawStart >= len(tkn.sql) {
+    return nil
+  }
+  raw := bytes.TrimSpace([]byte(tkn.sql[tkn.rawStart:]))
+  if len(raw) == 0 {
+    return nil
+  }
+  return raw
+}
+
+// createIndex returns CREATE INDEX as an alter of table that adds
+// index. Its options are USING, COMMENT, ALGORITHM and LOCK. The
+// columns and the options are kept as written if one of them is
+// something else.
+func createIndex(yylex interface{}, table *Node, index *IndexDefinition, options []AlterOption) *DDLSimple {
+  ddl := &DDLSimple{Action: ALTER, Table: table, AlterOptions: []AlterOption{&AddIndex{Index: index}}}
+  using := index.Using
+  for _, option := range options {
+    option := option.(*TableOption)
+    switch {
+    case bytes.Equal(option.Name, USING_OPTION):
+      index.Using = option.Value.Value
+    case bytes.Equal(option.Name, COMMENT_OPTION) && option.Value.Type == STRING:
+      index.Comment = option.Value
+    case isLockOption(option):
+      ddl.AlterOptions = append(ddl.AlterOptions, option)
+    default:
+      index.Columns, index.Using, index.Comment, index.Raw = nil, using, nil, rawText(yylex)
+      ddl.AlterOptions = ddl.AlterOptions[:1]
+      return ddl
+    }
+  }
+  return ddl
+}
+
+// isLockOption returns true if option is the ALGORITHM
+// or LOCK that can follow CREATE INDEX and DROP INDEX.
+func isLockOption(option AlterOption) bool {
+  name := option.(*TableOption).Name
+  return bytes.Equal(name, ALGORITHM) || bytes.Equal(name, LOCK_OPTION)
+}
+
+// viewOption is an option of CREATE VIEW. User is the value
+// of ALGORITHM and DEFINER, and Value the one of SQL SECURITY.
+type viewOption struct {
+  Name  []byte
+  User  *UserSpec
+  Value []byte
+}
+
+// setViewOption sets option in spec. It returns false
+// if the option or its value is not recognized.
+func setViewOption(spec *ViewSpec, option *viewOption) bool {
+  switch {
+  case bytes.Equal(option.Name, ALGORITHM):
+    if option.User.Host != nil || option.User.User.Type != ID {
+      return false
+    }
+    algorithm := bytes.ToLower(option.User.User.Value)
+    switch string(algorithm) {
+    case "undefined", "merge", "temptable":
+      spec.Algorithm = algorithm
+      return true
+    }
+  case bytes.Equal(option.Name, DEFINER):
+    spec.Definer = option.User
+    return true
+  case bytes.Equal(option.Name, SECURITY):
+    switch string(option.Value) {
+    case "definer", "invoker":
+      spec.Security = option.Value
+      return true
+    }
+  }
+  return false
+}
+
+// skipDDLClause records that a clause of an ALTER TABLE operation
+// isn't understood, so that the operation is kept as raw text: MySQL
+// accepts more clauses than the grammar parses. Outside of ALTER
+// TABLE, it reports message as an error and returns false.
+func skipDDLClause(yylex interface{}, message string) bool {
+  tkn := yylex.(*Tokenizer)
+  if len(tkn.alterMarks) == 0 {
+    tkn.Error(message)
+    return false
+  }
+  tkn.skippedDDL = true
+  return true
+}
+
+// takeSkippedDDL returns true if skipDDLClause was called
+// since the last call, and resets it.
+func takeSkippedDDL(yylex interface{}) bool {
+  tkn := yylex.(*Tokenizer)
+  skipped := tkn.skippedDDL
+  tkn.skippedDDL = false
+  return skipped
+}
+
 var (
   LJOIN = []byte("left join")
   RJOIN = []byte("right join")
   CJOIN = []byte("cross join")
   NJOIN = []byte("natural join")
-  SHARE = []byte("share")
-  MODE =  []byte("mode")
+  SJOIN = []byte("straight_join")
+  OUTFILE = []byte("outfile")
+  DUMPFILE = []byte("dumpfile")
+  CHARACTER = []byte("character")
+  CHARSET = []byte("charset")
+  NULLS = []byte("nulls")
+  FIRST = []byte("first")
+  LAST =  []byte("last")
+  PRIMARY = []byte("primary")
+  QUERY = []byte("query")
+  CACHE = []byte("cache")
+  ENUM = []byte("enum")
+  TIME = []byte("time")
+  ZONE = []byte("zone")
+  TABLES = []byte("tables")
+  CONNECTION = []byte("connection")
+  VALUE = []byte("value")
+  FORMAT = []byte("format")
+  START = []byte("start")
+  TRANSACTION = []byte("transaction")
+  WORK = []byte("work")
+  LOCAL = []byte("local")
+  NAMES = []byte("names")
+  SPATIAL = []byte("spatial")
+  ALGORITHM = []byte("algorithm")
+  DEFINER = []byte("definer")
+  SQL = []byte("sql")
+  SECURITY = []byte("security")
+  RESTRICT = []byte("restrict")
+  CASCADE = []byte("cascade")
+  COMMENT_OPTION = []byte("comment")
+  LOCK_OPTION = []byte("lock")
+  USING_OPTION = []byte("using")
+  COLLATE_OPTION = []byte("collate")
+  NO_WRITE_TO_BINLOG = []byte("no_write_to_binlog")
+  READ = []byte("read")
+  EXPORT = []byte("export")
+  DATA = []byte("data")
+  INFILE = []byte("infile")
+  LINES = []byte("lines")
+  COUNT = []byte("count")
+  WARNINGS = []byte("warnings")
+  ERRORS = []byte("errors")
+  EXTENDED = []byte("extended")
+  UNBOUNDED = []byte("unbounded")
+  CURRENT = []byte("current")
+  ROW = []byte("row")
+  PRECEDING = []byte("preceding")
+  FOLLOWING = []byte("following")
 )
 
 %}
@@ -45,166 +652,2131 @@ var (
   tableExprs  TableExprs
   tableExpr   TableExpr
   sqlNode     SQLNode
+  boolean     bool
+  nodes       []*Node
+  tableSpec   *TableSpec
+  columnDefinition *ColumnDefinition
+  columnType  ColumnType
+  rowAlias    *RowAlias
+  selectInto  *SelectInto
+  alterOption AlterOption
+  alterOptions []AlterOption
+  indexDefinition *IndexDefinition
+  tableOption *TableOption
+  tableOptions []*TableOption
+  indexColumn *IndexColumn
+  indexColumns []*IndexColumn
+  overClause  *OverClause
+  tableLock   *TableLock
+  tableLocks  []*TableLock
+  lockType    int
+  bytes       []byte
+  setExpr     *SetExpr
+  setExprs    SetExprs
+  viewSpec    *ViewSpec
+  viewOption  *viewOption
+  constraintDefinition *ConstraintDefinition
+  foreignKey  *ForeignKeyDefinition
+  ddl         *DDLSimple
+  verb        int
+  nodeLists   [][]*Node
+  load        *Load
+  strs        []string
+  text        string
+  userSpec    *UserSpec
+  userSpecs   []*UserSpec
+  cte         *CommonTableExpr
+  ctes        []*CommonTableExpr
+  frameClause *FrameClause
+  framePoint  *FramePoint
+  namedWindow *NamedWindow
+  namedWindows NamedWindows
+  parenSelect *ParenSelect
+  setOp       int
+  lock        *Lock
+  lockWait    int
+  partitions  Partitions
+  indexHint   *IndexHint
+  indexHints  IndexHints
+  hintType    int
+  hintFor     int
 }
 
-%token <node> SELECT INSERT UPDATE DELETE FROM WHERE GROUP HAVING ORDER BY LIMIT COMMENT FOR
-%token <node> ALL DISTINCT AS EXISTS IN IS LIKE BETWEEN NULL ASC DESC VALUES INTO DUPLICATE KEY DEFAULT SET LOCK
-%token <node> ID STRING NUMBER VALUE_ARG
+%token <node> SELECT INSERT REPLACE UPDATE DELETE FROM WHERE GROUP HAVING ORDER BY LIMIT COMMENT FOR EXPLAIN PARTITIONS DO RESET PROCEDURE MEMBER OF OVER UNLOCK SHOW
+%token <node> BEGIN COMMIT ROLLBACK
+%token <node> ANALYZE OPTIMIZE REPAIR FLUSH LOAD GRANT REVOKE
+%token <node> ALL DISTINCT AS EXISTS IN IS LIKE BETWEEN NULL TRUE FALSE ASC DESC VALUES INTO DUPLICATE KEY DEFAULT SET LOCK
+%token <node> STRING NUMBER VALUE_ARG EXTENSION_EXPR OUTER_JOIN_MARKER
 %token <node> LE GE NE NULL_SAFE_EQUAL
 %token <node> LEX_ERROR
 %token <node> '(' '=' '<' '>' '~'
 
+// A quoted user name can be followed by its @host, which is an
+// ID, or by an ID that starts the next clause, like the SQL
+// SECURITY that can follow the DEFINER of a view. The host wins.
+%nonassoc <node> USER_NAME
+// NEXT is also a column name, but "select next value" is the
+// start of SELECT NEXT VALUE FOR rather than an alias.
+%nonassoc <node> NEXT
+%nonassoc <node> ID
 %left <node> UNION MINUS EXCEPT INTERSECT
 %left <node> ','
 %left <node> JOIN STRAIGHT_JOIN LEFT RIGHT INNER OUTER CROSS NATURAL USE FORCE
 %left <node> ON
 %left <node> AND OR
 %right <node> NOT
-%left <node> '&' '|' '^'
+%left <node> '&' '|' '^' CONCAT_PIPE
 %left <node> '+' '-'
 %left <node> '*' '/' '%'
 %nonassoc <node> '.'
 %left <node> UNARY
+%left <node> COLLATE AT
 %right <node> CASE, WHEN, THEN, ELSE
 %left <node> END
 
 // DDL Tokens
-%token <node> CREATE ALTER DROP RENAME
-%token <node> TABLE INDEX VIEW TO IGNORE IF UNIQUE USING
+%token <node> CREATE ALTER DROP RENAME TRUNCATE DESCRIBE CONVERT ADD CHANGE MODIFY COLUMN FULLTEXT
+%token <node> TABLE INDEX VIEW TO IGNORE IF UNIQUE USING WITH TEMPORARY DATABASE SCHEMA RECURSIVE ROWS RANGE WINDOW ANY SOME
+%token <node> CONSTRAINT FOREIGN REFERENCES CHECK GENERATED
+%token <node> SQL_CALC_FOUND_ROWS SQL_CACHE SQL_NO_CACHE SQL_SMALL_RESULT SQL_BIG_RESULT SQL_BUFFER_RESULT HIGH_PRIORITY
+%token <node> SHARE MODE NOWAIT SKIP LOCKED PARTITION
+
+%start any_command
+
+// Fake Tokens
+%token <node> ASSIGN JSON_EXTRACT_OP JSON_UNQUOTE_EXTRACT_OP
+%token <node> NODE_LIST UPLUS UMINUS CASE_WHEN WHEN_LIST FUNCTION NO_LOCK FOR_UPDATE FOR_SHARE LOCK_IN_SHARE_MODE
+%token <node> NOT_IN NOT_LIKE NOT_BETWEEN IS_NULL IS_NOT_NULL UNION_ALL TUPLE TABLE_EXPR VALUES_FUNC NULLS_FIRST NULLS_LAST MEMBER_OF AT_TIME_ZONE
+%token <node> SET_NAMES SET_CHARSET WILDCARD
+
+%type <statement> command
+%type <statement> select_statement select_body paren_union insert_statement replace_statement update_statement delete_statement set_statement
+%type <ddl> create_table_prefix create_view_prefix
+%type <statement> admin_statement flush_statement load_statement grant_statement
+%type <strs> privilege_list
+%type <text> privilege
+%type <node> privilege_word grant_object grant_name
+%type <userSpec> grant_user
+%type <userSpecs> grant_user_list
+%type <statement> create_statement alter_statement rename_statement drop_statement truncate_statement describe_statement explain_statement do_statement reset_statement
+%type <statement> lock_statement unlock_statement show_statement next_value_statement explainable_statement
+%type <statement> begin_statement commit_statement rollback_statement use_statement
+%type <boolean> partitions_opt temporary_opt recursive_opt
+%type <comments> comment_opt comment_list
+%type <setOp> union_op
+%type <nodes> select_option_list_opt
+%type <node> select_option
+%type <selectExprs> select_expression_list
+%type <selectExpr> select_expression
+%type <str> as_lower_opt as_opt
+%type <node> expression
+%type <nodes> expression_list enum_value_list
+%type <tableExprs> table_expression_list
+%type <tableExpr> table_expression
+%type <str> join_type
+%type <node> simple_table_expression dml_table_expression
+%type <node> where_expression_opt boolean_expression logical_expression condition boolean_value compare quantifier
+%type <sqlNode> values
+%type <node> parenthesised_lists parenthesised_list value_expression_list value_expression keyword_as_func
+%type <node> unary_operator case_expression when_expression_list when_expression column_name value
+%type <node> group_by_opt having_opt order_by_opt order_list order asc_desc_opt nulls_opt limit_opt limit on_dup_opt
+%type <columns> column_list_opt column_list
+%type <node> update_list update_expression set_value
+%type <setExpr> set_expression set_variable set_charset
+%type <setExprs> set_list
+%type <node> charset_value collation_value
+%type <nodes> into_variable_list transaction_words table_id_list view_name_list table_name_list flush_words load_option load_option_words
+%type <nodeLists> flush_option_list load_option_list_opt load_option_list
+%type <load> load_infile
+%type <bytes> load_duplicate_opt
+%type <node> load_ignore_opt
+%type <node> flush_word into_variable
+%type <bytes> flush_lock_opt admin_option_list_opt
+%type <lock> lock_opt
+%type <lockWait> lock_wait_opt
+%type <partitions> partition_opt partition_list
+%type <indexHints> index_hint_list
+%type <indexHint> index_hint
+%type <hintType> index_hint_type
+%type <hintFor> index_hint_for_opt
+%type <nodes> index_list index_list_opt
+%type <verb> admin_verb
+%type <node> database_keyword exists_opt not_exists_opt ignore_opt non_spec_operation to_opt
+%type <node> sql_id explain_extended column_id table_id non_reserved_keyword
+%type <tableSpec> table_spec table_definition
+%type <viewSpec> view_spec view_option_list
+%type <viewOption> view_option
+%type <cte> cte
+%type <parenSelect> paren_select
+%type <frameClause> frame_opt
+%type <namedWindow> named_window
+%type <namedWindows> window_opt named_window_list
+%type <framePoint> frame_point
+%type <node> frame_unit
+%type <ctes> cte_list
+%type <indexDefinition> index_option_list_opt
+%type <node> index_or_key
+%type <bytes> index_direction_opt
+%type <tableOption> table_option
+%type <tableOptions> table_option_list_opt table_option_list
+%type <node> table_option_value
+%type <columnDefinition> column_definition
+%type <columnType> column_type column_type_spec
+%type <node> force_eof procedure_opt
+%type <rowAlias> row_alias_opt row_alias
+%type <selectInto> into_opt into_clause into_file
+%type <alterOption> index_lock_option alter_convert alter_option
+%type <alterOptions> drop_index_option_list_opt alter_option_list create_index_option_list_opt
+%type <node> alter_option_mark
+%type <indexDefinition> index_definition
+%type <constraintDefinition> constraint_definition
+%type <foreignKey> foreign_key_definition reference_option_list_opt
+%type <node> constraint_name index_or_key_opt column_default
+%type <bytes> reference_option
+%type <indexColumn> index_column
+%type <indexColumns> index_column_list
+%type <bytes> index_type_opt index_using_opt insert_priority_opt explain_format
+%type <bytes> transaction_modifier_list_opt transaction_modifier_list transaction_modifier
+%type <node> sql_id_opt
+%type <bytes> collate_opt view_check_opt
+%type <node> function_call partition_by_opt window_order_opt
+%type <overClause> over_clause window_spec
+%type <tableLock> table_lock
+%type <tableLocks> table_lock_list
+%type <lockType> lock_type
+%type <node> show_from show_filter_opt describe_keyword describe_column_opt start_keyword
+
+%%
+
+any_command:
+  command
+  {
+    SetParseTree(yylex, $1)
+  }
+
+command:
+  select_statement
+| paren_union
+| insert_statement
+| replace_statement
+| update_statement
+| delete_statement
+| set_statement
+| create_statement
+| alter_statement
+| rename_statement
+| drop_statement
+| truncate_statement
+| describe_statement
+| explain_statement
+| do_statement
+| reset_statement
+| lock_statement
+| unlock_statement
+| show_statement
+| next_value_statement
+| begin_statement
+| commit_statement
+| rollback_statement
+| use_statement
+| admin_statement
+| flush_statement
+| load_statement
+| grant_statement
+
+select_statement:
+  select_body
+| WITH recursive_opt cte_list select_body
+  {
+    with := &With{Recursive: $2, CTEs: $3}
+    switch sel := $4.(type) {
+    case *Select:
+      sel.With = with
+    case *Union:
+      sel.With = with
+    }
+    $$ = $4
+  }
+
+select_body:
+  SELECT comment_opt select_option_list_opt select_expression_list FROM table_expression_list where_expression_opt group_by_opt having_opt window_opt order_by_opt limit_opt procedure_opt into_opt lock_opt
+  {
+    distinct, options := selectOptions($3)
+    $$ = &Select{Comments: $2, Distinct: distinct, Options: options, SelectExprs: $4, From: $6, Where: $7, GroupBy: $8, Having: $9, Windows: $10, OrderBy: $11, Limit: $12, Procedure: $13, Into: $14, Lock: $15}
+  }
+| SELECT comment_opt select_option_list_opt select_expression_list order_by_opt limit_opt into_opt
+  {
+    // Like MySQL, a select without FROM doesn't accept
+    // WHERE, GROUP BY, HAVING or a lock clause.
+    distinct, options := selectOptions($3)
+    $$ = &Select{
+      Comments:    $2,
+      Distinct:    distinct,
+      Options:     options,
+      SelectExprs: $4,
+      Where:       NewSimpleParseNode(WHERE, "where"),
+      GroupBy:     NewSimpleParseNode(GROUP, "group"),
+      Having:      NewSimpleParseNode(HAVING, "having"),
+      OrderBy:     $5,
+      Limit:       $6,
+      Into:        $7,
+      Lock:        &Lock{Type: NO_LOCK},
+    }
+  }
+| SELECT comment_opt select_option_list_opt select_expression_list order_by_opt limit_opt into_clause FROM table_expression_list where_expression_opt group_by_opt having_opt window_opt order_by_opt limit_opt procedure_opt lock_opt
+  {
+    // The INTO clause can also precede FROM. The empty ORDER
+    // BY and LIMIT let it share its start with a select
+    // without FROM.
+    if $5.Len() != 0 || $6.Len() != 0 {
+      yylex.Error("order by and limit must follow from")
+      return 1
+    }
+    distinct, options := selectOptions($3)
+    $$ = &Select{Comments: $2, Distinct: distinct, Options: options, SelectExprs: $4, From: $9, Where: $10, GroupBy: $11, Having: $12, Windows: $13, OrderBy: $14, Limit: $15, Procedure: $16, Into: $7, Lock: $17}
+  }
+| select_body union_op select_body %prec UNION
+  {
+    $$ = newUnion($1.(SelectStatement), $2, $3.(SelectStatement))
+  }
+| select_body union_op paren_select order_by_opt limit_opt lock_opt
+  {
+    union := newUnion($1.(SelectStatement), $2, $3)
+    setUnionTail(union, $4, $5, $6)
+    $$ = union
+  }
+
+// paren_union is a union whose first select is parenthesized.
+// It's only accepted as a statement, since a parenthesized
+// select is a subquery in the other places.
+paren_union:
+  paren_select union_op select_body %prec UNION
+  {
+    $$ = newUnion($1, $2, $3.(SelectStatement))
+  }
+| paren_select union_op paren_select order_by_opt limit_opt lock_opt
+  {
+    union := newUnion($1, $2, $3)
+    setUnionTail(union, $4, $5, $6)
+    $$ = union
+  }
+| paren_union union_op select_body %prec UNION
+  {
+    $$ = newUnion($1.(SelectStatement), $2, $3.(SelectStatement))
+  }
+| paren_union union_op paren_select order_by_opt limit_opt lock_opt
+  {
+    union := newUnion($1.(SelectStatement), $2, $3)
+    setUnionTail(union, $4, $5, $6)
+    $$ = union
+  }
+
+paren_select:
+  '(' select_body ')'
+  {
+    $$ = &ParenSelect{Select: $2.(SelectStatement)}
+  }
+| '(' paren_union ')'
+  {
+    $$ = &ParenSelect{Select: $2.(SelectStatement)}
+  }
+
+cte_list:
+  cte
+  {
+    $$ = []*CommonTableExpr{$1}
+  }
+| cte_list ',' cte
+  {
+    $$ = append($1, $3)
+  }
+
+cte:
+  ID column_list_opt AS '(' select_statement ')'
+  {
+    $$ = &CommonTableExpr{Name: $1, Columns: $2, Subquery: $5.(SelectStatement)}
+  }
+
+next_value_statement:
+  SELECT comment_opt select_option_list_opt NEXT sql_id FOR ID
+  {
+    if $3 != nil || !bytes.Equal($5.Value, VALUE) {
+      yylex.Error("expecting value")
+      return 1
+    }
+    $$ = &NextValueFor{Comments: $2, SequenceName: $7.Value}
+  }
+
+insert_statement:
+  INSERT comment_opt insert_priority_opt ignore_opt INTO dml_table_expression partition_opt column_list_opt values on_dup_opt
+  {
+    $$ = &Insert{Comments: $2, Priority: $3, Ignore: $4 != nil, Table: $6, Partitions: $7, Columns: $8, Values: $9, OnDup: $10}
+  }
+| INSERT comment_opt insert_priority_opt ignore_opt INTO dml_table_expression partition_opt column_list_opt VALUES parenthesised_lists row_alias on_dup_opt
+  {
+    $$ = &Insert{Comments: $2, Priority: $3, Ignore: $4 != nil, Table: $6, Partitions: $7, Columns: $8, Values: $9.Push($10), RowAlias: $11, OnDup: $12}
+  }
+| INSERT comment_opt insert_priority_opt ignore_opt INTO dml_table_expression partition_opt SET update_list row_alias_opt on_dup_opt
+  {
+    columns, values := updateListToValues($9)
+    $$ = &Insert{Comments: $2, Priority: $3, Ignore: $4 != nil, Table: $6, Partitions: $7, Columns: columns, Values: values, RowAlias: $10, OnDup: $11}
+  }
+
+insert_priority_opt:
+  {
+    $$ = nil
+  }
+| sql_id
+  {
+    switch {
+    case bytes.Equal($1.Value, LOW_PRIORITY):
+      $$ = LOW_PRIORITY
+    case bytes.Equal($1.Value, DELAYED):
+      $$ = DELAYED
+    default:
+      yylex.Error("expecting insert modifier")
+      return 1
+    }
+  }
+| HIGH_PRIORITY
+  {
+    $$ = $1.Value
+  }
+
+replace_statement:
+  REPLACE comment_opt INTO dml_table_expression column_list_opt values
+  {
+    $$ = &Replace{Comments: $2, Table: $4, Columns: $5, Values: $6}
+  }
+| REPLACE comment_opt INTO dml_table_expression SET update_list
+  {
+    columns, values := updateListToValues($6)
+    $$ = &Replace{Comments: $2, Table: $4, Columns: columns, Values: values}
+  }
+| REPLACE comment_opt INTO dml_table_expression column_list_opt values ON DUPLICATE
+  {
+    yylex.Error("on duplicate key update is not allowed with replace")
+    return 1
+  }
+| REPLACE comment_opt INTO dml_table_expression SET update_list ON DUPLICATE
+  {
+    yylex.Error("on duplicate key update is not allowed with replace")
+    return 1
+  }
+
+update_statement:
+  UPDATE comment_opt dml_table_expression partition_opt SET update_list where_expression_opt order_by_opt limit_opt
+  {
+    $$ = &Update{Comments: $2, Table: $3, Partitions: $4, List: $6, Where: $7, OrderBy: $8, Limit: $9}
+  }
+
+delete_statement:
+  DELETE comment_opt FROM dml_table_expression partition_opt where_expression_opt order_by_opt limit_opt
+  {
+    $$ = &Delete{Comments: $2, Table: $4, Partitions: $5, Where: $6, OrderBy: $7, Limit: $8}
+  }
+
+set_statement:
+  SET comment_opt set_list
+  {
+    resolveSetScopes($3)
+    $$ = &Set{Comments: $2, Exprs: $3}
+  }
+| SET comment_opt transaction_words
+  {
+    chars, err := newTransactionChars($3)
+    if chars == nil {
+      yylex.Error(err)
+      return 1
+    }
+    $$ = &Set{Comments: $2, Transaction: chars}
+  }
+
+create_statement:
+  create_table_prefix force_eof
+  {
+    $$ = $1
+  }
+| create_table_prefix non_spec_operation force_eof
+  {
+    $$ = $1
+  }
+| create_table_prefix LIKE dml_table_expression
+  {
+    $1.OptLike = &OptLike{LikeTable: $3}
+    $$ = $1
+  }
+| create_table_prefix '(' LIKE dml_table_expression ')'
+  {
+    $1.OptLike = &OptLike{LikeTable: $4, Parens: true}
+    $$ = $1
+  }
+| create_table_prefix table_definition table_option_list_opt
+  {
+    $2.Options = $3
+    $1.TableSpec = $2
+    $$ = $1
+  }
+| create_table_prefix table_definition error
+  {
+    // The table options are kept as written if one
+    // of them is not parsed yet, like PARTITION BY.
+    $2.RawOptions = rawText(yylex)
+    $1.TableSpec = $2
+    $$ = $1
+  }
+| create_table_prefix table_definition table_option_list error
+  {
+    $2.RawOptions = rawText(yylex)
+    $1.TableSpec = $2
+    $$ = $1
+  }
+| CREATE index_type_opt INDEX sql_id index_using_opt ON ID raw_text_mark '(' index_column_list ')' create_index_option_list_opt
+  {
+    // Change this to an alter statement
+    $$ = createIndex(yylex, $7, &IndexDefinition{Type: $2, Name: $4, Columns: $10, Using: $5}, $12)
+  }
+| CREATE sql_id INDEX sql_id index_using_opt ON ID raw_text_mark '(' index_column_list ')' create_index_option_list_opt
+  {
+    if !bytes.Equal($2.Value, SPATIAL) {
+      yylex.Error("unexpected index type " + string($2.Value))
+      return 1
+    }
+    $$ = createIndex(yylex, $7, &IndexDefinition{Type: $2.Value, Name: $4, Columns: $10, Using: $5}, $12)
+  }
+| CREATE index_type_opt INDEX sql_id index_using_opt ON ID raw_text_mark error
+  {
+    // The columns and options are kept as written if they can't be parsed.
+    index := &IndexDefinition{Type: $2, Name: $4, Using: $5, Raw: rawText(yylex)}
+    $$ = &DDLSimple{Action: ALTER, Table: $7, AlterOptions: []AlterOption{&AddIndex{Index: index}}}
+  }
+| create_view_prefix view_spec
+  {
+    if $1.ViewSpec != nil {
+      $2.Algorithm, $2.Definer, $2.Security = $1.ViewSpec.Algorithm, $1.ViewSpec.Definer, $1.ViewSpec.Security
+    }
+    $1.ViewSpec = $2
+    $$ = $1
+  }
+| create_view_prefix error
+  {
+    // Fall back to the view name for the view
+    // definitions that are not parsed yet.
+    $1.ViewSpec = nil
+    $$ = $1
+  }
+
+| CREATE database_keyword not_exists_opt ID table_option_list_opt
+  {
+    ddl := &DBDDL{Action: CREATE, DBName: $4, IfNotExists: $3 != nil}
+    if !setDBOptions(ddl, $5) {
+      yylex.Error("unexpected database option")
+      return 1
+    }
+    $$ = ddl
+  }
+
+create_table_prefix:
+  CREATE temporary_opt TABLE not_exists_opt ID
+  {
+    $$ = &DDLSimple{Action: CREATE, Table: $5, Temporary: $2, IfNotExists: $4 != nil}
+    yylex.(*Tokenizer).createTable = $$
+  }
+
+table_definition:
+  '(' table_spec ')'
+  {
+    $$ = $2
+    markRawText(yylex)
+  }
+
+// create_view_prefix keeps the view options in a ViewSpec
+// without a select, which is replaced by the definition.
+create_view_prefix:
+  CREATE VIEW sql_id
+  {
+    $$ = &DDLSimple{Action: CREATE, Table: $3, View: true}
+  }
+| CREATE view_option_list VIEW sql_id
+  {
+    $$ = &DDLSimple{Action: CREATE, Table: $4, View: true, ViewSpec: $2}
+  }
+| CREATE OR REPLACE VIEW sql_id
+  {
+    // Change this to an alter statement
+    $$ = &DDLSimple{Action: ALTER, Table: $5, View: true, Replace: true}
+  }
+| CREATE OR REPLACE view_option_list VIEW sql_id
+  {
+    $$ = &DDLSimple{Action: ALTER, Table: $6, View: true, Replace: true, ViewSpec: $4}
+  }
+
+view_option_list:
+  view_option
+  {
+    $$ = &ViewSpec{}
+    if !setViewOption($$, $1) {
+      yylex.Error("unexpected view option " + string($1.Name))
+      return 1
+    }
+  }
+| view_option_list view_option
+  {
+    if !setViewOption($$, $2) {
+      yylex.Error("unexpected view option " + string($2.Name))
+      return 1
+    }
+  }
+
+view_option:
+  sql_id '=' grant_user
+  {
+    $$ = &viewOption{Name: $1.Value, User: $3}
+  }
+| sql_id sql_id sql_id
+  {
+    if !bytes.Equal($1.Value, SQL) || !bytes.Equal($2.Value, SECURITY) {
+      yylex.Error("expecting sql security")
+      return 1
+    }
+    $$ = &viewOption{Name: SECURITY, Value: $3.Value}
+  }
+
+view_spec:
+  column_list_opt AS select_statement view_check_opt
+  {
+    $$ = &ViewSpec{Columns: $1, Select: $3.(SelectStatement), CheckOption: $4}
+  }
+
+view_check_opt:
+  {
+    $$ = nil
+  }
+| WITH CHECK sql_id
+  {
+    if string($3.Value) != "option" {
+      yylex.Error("expecting check option")
+      return 1
+    }
+    $$ = []byte("check option")
+  }
+| WITH sql_id CHECK sql_id
+  {
+    if string($2.Value) != "cascaded" && string($2.Value) != "local" || string($4.Value) != "option" {
+      yylex.Error("expecting check option")
+      return 1
+    }
+    $$ = []byte(string($2.Value) + " check option")
+  }
+
+alter_statement:
+  ALTER ignore_opt TABLE ID alter_option_list
+  {
+    options := alterRawText(yylex, $5)
+    $$ = &DDLSimple{Action: ALTER, Table: $4, AlterOptions: options}
+    if len(options) == 1 {
+      if rename, ok := options[0].(*RenameTable); ok {
+        // Change this to a rename statement
+        $$ = &Rename{OldName: $4, NewName: rename.NewName}
+      }
+    }
+  }
+| ALTER ignore_opt TABLE ID alter_option_list error
+  {
+    // The end of the last operation is not parsed yet.
+    $5[len($5)-1] = &AlterRaw{}
+    $$ = &DDLSimple{Action: ALTER, Table: $4, AlterOptions: alterRawText(yylex, $5)}
+  }
+| ALTER VIEW sql_id view_spec
+  {
+    $$ = &DDLSimple{Action: ALTER, Table: $3, View: true, ViewSpec: $4}
+  }
+| ALTER VIEW sql_id error
+  {
+    $$ = &DDLSimple{Action: ALTER, Table: $3, View: true}
+  }
+| ALTER database_keyword ID table_option_list
+  {
+    ddl := &DBDDL{Action: ALTER, DBName: $3}
+    if !setDBOptions(ddl, $4) {
+      yylex.Error("unexpected database option")
+      return 1
+    }
+    $$ = ddl
+  }
+
+alter_option_list:
+  alter_option_mark alter_option
+  {
+    $$ = []AlterOption{$2}
+  }
+| alter_option_list ',' alter_option_mark alter_option
+  {
+    $$ = append($1, $4)
+  }
+
+alter_option_mark:
+  {
+    markAlterOption(yylex)
+  }
+
+alter_option:
+  alter_convert
+| ADD column_definition
+  {
+    $$ = &AddColumn{Column: $2}
+    if takeSkippedDDL(yylex) {
+      $$ = &AlterRaw{}
+    }
+  }
+| ADD COLUMN column_definition
+  {
+    $$ = &AddColumn{Column: $3}
+    if takeSkippedDDL(yylex) {
+      $$ = &AlterRaw{}
+    }
+  }
+| ADD index_definition
+  {
+    $$ = &AddIndex{Index: $2}
+    if takeSkippedDDL(yylex) {
+      $$ = &AlterRaw{}
+    }
+  }
+| CHANGE column_opt sql_id column_definition
+  {
+    $$ = &ChangeColumn{OldName: $3, Column: $4}
+    if takeSkippedDDL(yylex) {
+      $$ = &AlterRaw{}
+    }
+  }
+| MODIFY column_opt column_definition
+  {
+    $$ = &ModifyColumn{Column: $3}
+    if takeSkippedDDL(yylex) {
+      $$ = &AlterRaw{}
+    }
+  }
+| DROP sql_id
+  {
+    $$ = &DropColumn{Name: $2}
+  }
+| DROP COLUMN sql_id
+  {
+    $$ = &DropColumn{Name: $3}
+  }
+| DROP index_or_key sql_id
+  {
+    $$ = &DropIndex{Name: $3}
+  }
+| DROP sql_id KEY
+  {
+    if !bytes.Equal($2.Value, PRIMARY) {
+      yylex.Error("expecting primary key")
+      return 1
+    }
+    $$ = &DropIndex{}
+  }
+| RENAME to_opt ID
+  {
+    $$ = &RenameTable{NewName: $3}
+  }
+| sql_id '=' table_option_value
+  {
+    $$ = &TableOption{Name: $1.Value, Value: $3}
+  }
+| error
+  {
+    // The text is set by alterRawText.
+    $$ = &AlterRaw{}
+  }
+
+column_opt:
+  {}
+| COLUMN
+  {}
+
+index_definition:
+  index_type_opt index_or_key sql_id_opt '(' index_column_list ')' index_option_list_opt
+  {
+    $$ = $7
+    $$.Type, $$.Name, $$.Columns = $1, $3, $5
+  }
+| sql_id index_or_key sql_id_opt '(' index_column_list ')' index_option_list_opt
+  {
+    switch {
+    case bytes.Equal($1.Value, PRIMARY) && $2.Type == KEY && $3 == nil:
+    case bytes.Equal($1.Value, SPATIAL):
+    default:
+      if !skipDDLClause(yylex, "unexpected index type " + string($1.Value)) {
+        return 1
+      }
+    }
+    $$ = $7
+    $$.Type, $$.Name, $$.Columns = $1.Value, $3, $5
+  }
+| CONSTRAINT sql_id KEY '(' index_column_list ')' index_option_list_opt
+  {
+    if !bytes.Equal($2.Value, PRIMARY) && !skipDDLClause(yylex, "expecting primary key") {
+      return 1
+    }
+    $$ = $7
+    $$.Type, $$.Columns = PRIMARY, $5
+  }
+| CONSTRAINT sql_id sql_id KEY '(' index_column_list ')' index_option_list_opt
+  {
+    if !bytes.Equal($3.Value, PRIMARY) && !skipDDLClause(yylex, "expecting primary key") {
+      return 1
+    }
+    $$ = $8
+    $$.Constraint, $$.Type, $$.Columns = $2, PRIMARY, $6
+  }
+| constraint_name UNIQUE index_or_key_opt sql_id_opt '(' index_column_list ')' index_option_list_opt
+  {
+    $$ = $8
+    $$.Constraint, $$.Type, $$.Name, $$.Columns = $1, []byte("unique"), $4, $6
+  }
+
+// constraint_name is the CONSTRAINT that can precede
+// the definition of a key or a constraint, with its
+// optional symbol.
+constraint_name:
+  CONSTRAINT
+  {
+    $$ = nil
+  }
+| CONSTRAINT sql_id
+  {
+    $$ = $2
+  }
+
+constraint_definition:
+  foreign_key_definition
+  {
+    $$ = &ConstraintDefinition{ForeignKey: $1}
+  }
+| constraint_name foreign_key_definition
+  {
+    $$ = &ConstraintDefinition{Name: $1, ForeignKey: $2}
+  }
+| CHECK '(' expression ')'
+  {
+    $$ = &ConstraintDefinition{Check: $3}
+  }
+| constraint_name CHECK '(' expression ')'
+  {
+    $$ = &ConstraintDefinition{Name: $1, Check: $4}
+  }
+
+foreign_key_definition:
+  FOREIGN KEY sql_id_opt '(' column_list ')' REFERENCES dml_table_expression '(' column_list ')' reference_option_list_opt
+  {
+    $$ = $12
+    $$.Name, $$.Columns, $$.ReferencedTable, $$.ReferencedColumns = $3, $5, $8, $10
+  }
+
+reference_option_list_opt:
+  {
+    $$ = &ForeignKeyDefinition{}
+  }
+| reference_option_list_opt ON DELETE reference_option
+  {
+    $$.OnDelete = $4
+  }
+| reference_option_list_opt ON UPDATE reference_option
+  {
+    $$.OnUpdate = $4
+  }
+
+reference_option:
+  sql_id
+  {
+    if !bytes.Equal($1.Value, RESTRICT) && !bytes.Equal($1.Value, CASCADE) {
+      yylex.Error("unexpected reference option " + string($1.Value))
+      return 1
+    }
+    $$ = $1.Value
+  }
+| sql_id sql_id
+  {
+    if string($1.Value) != "no" || string($2.Value) != "action" {
+      yylex.Error("expecting no action")
+      return 1
+    }
+    $$ = []byte("no action")
+  }
+| SET NULL
+  {
+    $$ = []byte("set null")
+  }
+| SET DEFAULT
+  {
+    $$ = []byte("set default")
+  }
+
+index_option_list_opt:
+  {
+    $$ = &IndexDefinition{}
+  }
+| index_option_list_opt USING sql_id
+  {
+    $$.Using = $3.Value
+  }
+| index_option_list_opt sql_id STRING
+  {
+    if !bytes.Equal($2.Value, COMMENT_OPTION) && !skipDDLClause(yylex, "unexpected index option " + string($2.Value)) {
+      return 1
+    }
+    $$.Comment = $3
+  }
+
+// create_index_option_list_opt keeps the options of CREATE INDEX
+// as TableOptions, which createIndex sets in the index.
+create_index_option_list_opt:
+  {
+    $$ = nil
+  }
+| create_index_option_list_opt USING sql_id
+  {
+    $$ = append($1, &TableOption{Name: USING_OPTION, Value: $3})
+  }
+| create_index_option_list_opt sql_id STRING
+  {
+    $$ = append($1, &TableOption{Name: $2.Value, Value: $3})
+  }
+| create_index_option_list_opt index_lock_option
+  {
+    $$ = append($1, $2)
+  }
+
+raw_text_mark:
+  {
+    markRawText(yylex)
+  }
+
+index_type_opt:
+  {
+    $$ = nil
+  }
+| UNIQUE
+  {
+    $$ = []byte("unique")
+  }
+| FULLTEXT
+  {
+    $$ = []byte("fulltext")
+  }
+
+index_or_key:
+  INDEX
+| KEY
+
+index_or_key_opt:
+  {
+    $$ = nil
+  }
+| index_or_key
+
+sql_id_opt:
+  {
+    $$ = nil
+  }
+| sql_id
+
+index_column_list:
+  index_column
+  {
+    $$ = []*IndexColumn{$1}
+  }
+| index_column_list ',' index_column
+  {
+    $$ = append($1, $3)
+  }
+
+index_column:
+  sql_id index_direction_opt
+  {
+    $$ = &IndexColumn{Column: $1, Direction: $2}
+  }
+| sql_id '(' NUMBER ')' index_direction_opt
+  {
+    $$ = &IndexColumn{Column: $1, Length: $3.Value, Direction: $5}
+  }
+
+index_direction_opt:
+  {
+    $$ = nil
+  }
+| ASC
+  {
+    $$ = []byte("asc")
+  }
+| DESC
+  {
+    $$ = []byte("desc")
+  }
+
+alter_convert:
+  CONVERT TO sql_id SET sql_id collate_opt
+  {
+    if !bytes.Equal($3.Value, CHARACTER) {
+      yylex.Error("expecting character set")
+      return 1
+    }
+    $$ = &AlterCharset{CharacterSet: $5.Value, Collate: $6}
+  }
+| CONVERT TO sql_id sql_id collate_opt
+  {
+    if !bytes.Equal($3.Value, CHARSET) {
+      yylex.Error("expecting character set")
+      return 1
+    }
+    $$ = &AlterCharset{CharacterSet: $4.Value, Collate: $5}
+  }
+
+collate_opt:
+  {
+    $$ = nil
+  }
+| COLLATE sql_id
+  {
+    $$ = $2.Value
+  }
+
+rename_statement:
+  RENAME TABLE ID TO ID
+  {
+    $$ = &Rename{OldName: $3, NewName: $5}
+  }
+
+drop_statement:
+  DROP temporary_opt TABLE exists_opt table_id_list drop_behavior_opt
+  {
+    $$ = &DDLSimple{Action: DROP, Table: $5[0], Tables: $5, Temporary: $2, IfExists: $4 != nil}
+  }
+| DROP INDEX sql_id ON ID alter_option_mark drop_index_option_list_opt
+  {
+    // Change this to an alter statement
+    options := $7
+    for _, option := range options {
+      if !isLockOption(option) {
+        // Only ALGORITHM and LOCK are parsed, the
+        // options are kept as written otherwise.
+        options = alterRawText(yylex, []AlterOption{&AlterRaw{}})
+        break
+      }
+    }
+    options = append([]AlterOption{&DropIndex{Name: $3}}, options...)
+    $$ = &DDLSimple{Action: ALTER, Table: $5, AlterOptions: options}
+  }
+| DROP INDEX sql_id ON ID alter_option_mark drop_index_option_list_opt error
+  {
+    // The options are kept as written if one of them can't be parsed.
+    options := append([]AlterOption{&DropIndex{Name: $3}}, alterRawText(yylex, []AlterOption{&AlterRaw{}})...)
+    $$ = &DDLSimple{Action: ALTER, Table: $5, AlterOptions: options}
+  }
+| DROP VIEW exists_opt view_name_list
+  {
+    $$ = &DDLSimple{Action: DROP, Table: $4[0], Tables: $4, View: true, IfExists: $3 != nil}
+  }
+| DROP database_keyword exists_opt ID
+  {
+    $$ = &DBDDL{Action: DROP, DBName: $4, IfExists: $3 != nil}
+  }
+
+drop_index_option_list_opt:
+  {
+    $$ = nil
+  }
+| drop_index_option_list_opt index_lock_option
+  {
+    $$ = append($1, $2)
+  }
+| drop_index_option_list_opt ',' index_lock_option
+  {
+    $$ = append($1, $3)
+  }
+
+index_lock_option:
+  sql_id equal_opt sql_id
+  {
+    $$ = &TableOption{Name: $1.Value, Value: $3}
+  }
+| sql_id equal_opt DEFAULT
+  {
+    $$ = &TableOption{Name: $1.Value, Value: $3}
+  }
+| LOCK equal_opt sql_id
+  {
+    $$ = &TableOption{Name: LOCK_OPTION, Value: $3}
+  }
+| LOCK equal_opt DEFAULT
+  {
+    $$ = &TableOption{Name: LOCK_OPTION, Value: $3}
+  }
+
+// drop_behavior_opt accepts RESTRICT and CASCADE,
+// which MySQL ignores.
+drop_behavior_opt:
+  {}
+| sql_id
+  {
+    if !bytes.Equal($1.Value, RESTRICT) && !bytes.Equal($1.Value, CASCADE) {
+      yylex.Error("expecting restrict or cascade")
+      return 1
+    }
+  }
+
+table_id_list:
+  ID
+  {
+    $$ = []*Node{$1}
+  }
+| table_id_list ',' ID
+  {
+    $$ = append($1, $3)
+  }
+
+view_name_list:
+  sql_id
+  {
+    $$ = []*Node{$1}
+  }
+| view_name_list ',' sql_id
+  {
+    $$ = append($1, $3)
+  }
+
+truncate_statement:
+  TRUNCATE comment_opt TABLE dml_table_expression
+  {
+    $$ = &Truncate{Comments: $2, Table: $4}
+  }
+| TRUNCATE comment_opt dml_table_expression
+  {
+    $$ = &Truncate{Comments: $2, Table: $3}
+  }
+
+describe_statement:
+  describe_keyword comment_opt dml_table_expression describe_column_opt
+  {
+    $$ = &Describe{Comments: $2, Table: $3, Column: $4}
+  }
+| EXPLAIN dml_table_expression describe_column_opt
+  {
+    $$ = &Describe{Table: $2, Column: $3}
+  }
+
+explain_format:
+  sql_id '=' sql_id
+  {
+    if !bytes.Equal($1.Value, FORMAT) {
+      yylex.Error("expecting format")
+      return 1
+    }
+    switch string($3.Value) {
+    case "traditional", "json", "tree":
+    default:
+      yylex.Error("unexpected format " + string($3.Value))
+      return 1
+    }
+    $$ = $3.Value
+  }
+
+explain_extended:
+  sql_id
+  {
+    if !bytes.Equal($1.Value, EXTENDED) {
+      yylex.Error("unexpected explain " + string($1.Value))
+      return 1
+    }
+  }
+
+explainable_statement:
+  select_statement
+| paren_union
+| insert_statement
+| replace_statement
+| update_statement
+| delete_statement
+
+describe_keyword:
+  DESCRIBE
+| DESC
+
+describe_column_opt:
+  {
+    $$ = nil
+  }
+| sql_id
+| STRING
+
+explain_statement:
+  EXPLAIN partitions_opt explainable_statement
+  {
+    $$ = &Explain{Partitions: $2, Statement: $3}
+  }
+| EXPLAIN explain_format explainable_statement
+  {
+    $$ = &Explain{OutputFormat: $2, Statement: $3}
+  }
+| EXPLAIN explain_extended explainable_statement
+  {
+    $$ = &Explain{Extended: true, Statement: $3}
+  }
+| EXPLAIN ANALYZE explainable_statement
+  {
+    $$ = &Explain{Analyze: true, Statement: $3}
+  }
+| EXPLAIN partitions_opt EXPLAIN
+  {
+    yylex.Error("cannot explain an explain statement")
+    return 1
+  }
+| EXPLAIN FOR sql_id NUMBER
+  {
+    if !bytes.Equal($3.Value, CONNECTION) {
+      yylex.Error("expecting connection")
+      return 1
+    }
+    $$ = &ExplainForConnection{ConnectionID: $4}
+  }
+
+begin_statement:
+  BEGIN comment_opt work_opt
+  {
+    $$ = &Begin{Comments: $2}
+  }
+| start_keyword comment_opt sql_id transaction_modifier_list_opt
+  {
+    if !bytes.Equal($3.Value, TRANSACTION) {
+      yylex.Error("expecting transaction")
+      return 1
+    }
+    $$ = &Begin{Comments: $2, Start: true, Modifiers: $4}
+  }
+
+// start_keyword checks that a statement starting with an
+// identifier is START TRANSACTION, before going any further.
+start_keyword:
+  ID
+  {
+    if !bytes.EqualFold($1.Value, START) {
+      yylex.Error("syntax error")
+      return 1
+    }
+  }
+
+commit_statement:
+  COMMIT comment_opt work_opt
+  {
+    $$ = &Commit{Comments: $2}
+  }
+
+rollback_statement:
+  ROLLBACK comment_opt work_opt
+  {
+    $$ = &Rollback{Comments: $2}
+  }
+
+work_opt:
+  {
+  }
+| sql_id
+  {
+    if !bytes.Equal($1.Value, WORK) {
+      yylex.Error("expecting work")
+      return 1
+    }
+  }
+
+transaction_modifier_list_opt:
+  {
+    $$ = nil
+  }
+| transaction_modifier_list
+
+transaction_modifier_list:
+  transaction_modifier
+| transaction_modifier_list ',' transaction_modifier
+  {
+    $$ = append(append($1, ", "...), $3...)
+  }
+
+transaction_modifier:
+  WITH sql_id sql_id
+  {
+    if string($2.Value) != "consistent" || string($3.Value) != "snapshot" {
+      yylex.Error("unexpected transaction modifier")
+      return 1
+    }
+    $$ = []byte("with consistent snapshot")
+  }
+| sql_id sql_id
+  {
+    if string($1.Value) != "read" || string($2.Value) != "only" && string($2.Value) != "write" {
+      yylex.Error("unexpected transaction modifier")
+      return 1
+    }
+    $$ = []byte("read " + string($2.Value))
+  }
+
+use_statement:
+  USE ID
+  {
+    $$ = &Use{DBName: $2}
+  }
+
+do_statement:
+  DO expression_list
+  {
+    $$ = &Do{Exprs: $2}
+  }
+
+reset_statement:
+  RESET sql_id
+  {
+    switch string($2.Value) {
+    case "master":
+      $$ = &Reset{Target: RESET_MASTER}
+    case "slave":
+      $$ = &Reset{Target: RESET_SLAVE}
+    case "replica":
+      $$ = &Reset{Target: RESET_REPLICA}
+    default:
+      yylex.Error("unexpected reset target " + string($2.Value))
+      return 1
+    }
+  }
+| RESET sql_id sql_id
+  {
+    if !bytes.Equal($2.Value, QUERY) || !bytes.Equal($3.Value, CACHE) {
+      yylex.Error("expecting query cache")
+      return 1
+    }
+    $$ = &Reset{Target: RESET_QUERY_CACHE}
+  }
+
+admin_statement:
+  admin_verb TABLE table_name_list admin_option_list_opt
+  {
+    if $4 != nil && $1 != ADMIN_REPAIR {
+      yylex.Error("unexpected option " + string($4))
+      return 1
+    }
+    $$ = &OtherAdmin{Verb: $1, Tables: $3, Options: $4}
+  }
+| admin_verb ID table_name_list
+  {
+    if !bytes.EqualFold($2.Value, TABLES) {
+      yylex.Error("expecting tables")
+      return 1
+    }
+    $$ = &OtherAdmin{Verb: $1, Tables: $3}
+  }
+| admin_verb ID TABLE table_name_list admin_option_list_opt
+  {
+    modifier, ok := adminModifier($2)
+    if !ok {
+      yylex.Error("expecting local or no_write_to_binlog")
+      return 1
+    }
+    if $5 != nil && $1 != ADMIN_REPAIR {
+      yylex.Error("unexpected option " + string($5))
+      return 1
+    }
+    $$ = &OtherAdmin{Verb: $1, Modifier: modifier, Tables: $4, Options: $5}
+  }
+| admin_verb ID ID table_name_list
+  {
+    modifier, ok := adminModifier($2)
+    if !ok {
+      yylex.Error("expecting local or no_write_to_binlog")
+      return 1
+    }
+    if !bytes.EqualFold($3.Value, TABLES) {
+      yylex.Error("expecting tables")
+      return 1
+    }
+    $$ = &OtherAdmin{Verb: $1, Modifier: modifier, Tables: $4}
+  }
+
+// admin_option_list_opt accepts the options of REPAIR TABLE,
+// which are kept lowercased and separated by spaces. They
+// can't follow TABLES, which is an identifier that could be
+// a modifier.
+admin_option_list_opt:
+  {
+    $$ = nil
+  }
+| admin_option_list_opt ID
+  {
+    option := bytes.ToLower($2.Value)
+    switch string(option) {
+    case "quick", "extended", "use_frm":
+    default:
+      yylex.Error("unexpected option " + string(option))
+      return 1
+    }
+    if $1 != nil {
+      option = append(append($1, ' '), option...)
+    }
+    $$ = option
+  }
+
+admin_verb:
+  ANALYZE
+  {
+    $$ = ADMIN_ANALYZE
+  }
+| OPTIMIZE
+  {
+    $$ = ADMIN_OPTIMIZE
+  }
+| REPAIR
+  {
+    $$ = ADMIN_REPAIR
+  }
+
+table_name_list:
+  dml_table_expression
+  {
+    $$ = []*Node{$1}
+  }
+| table_name_list ',' dml_table_expression
+  {
+    $$ = append($1, $3)
+  }
+
+flush_statement:
+  FLUSH flush_option_list flush_lock_opt
+  {
+    flush, msg := newFlush($2)
+    if flush == nil {
+      yylex.Error(msg)
+      return 1
+    }
+    switch string($3) {
+    case "with read lock":
+      flush.WithLock = true
+    case "for export":
+      flush.ForExport = true
+    }
+    if (flush.WithLock || flush.ForExport) && !bytes.Equal(flush.Type, TABLES) {
+      yylex.Error("unexpected " + string($3))
+      return 1
+    }
+    $$ = flush
+  }
+
+flush_option_list:
+  flush_words
+  {
+    $$ = [][]*Node{$1}
+  }
+| flush_option_list ',' flush_words
+  {
+    $$ = append($1, $3)
+  }
+
+flush_words:
+  flush_word
+  {
+    $$ = []*Node{$1}
+  }
+| flush_words flush_word
+  {
+    $$ = append($1, $2)
+  }
+
+flush_word:
+  dml_table_expression
+| TABLE
+
+flush_lock_opt:
+  {
+    $$ = nil
+  }
+| WITH ID LOCK
+  {
+    if !bytes.EqualFold($2.Value, READ) {
+      yylex.Error("expecting read")
+      return 1
+    }
+    $$ = []byte("with read lock")
+  }
+| FOR ID
+  {
+    if !bytes.EqualFold($2.Value, EXPORT) {
+      yylex.Error("expecting export")
+      return 1
+    }
+    $$ = []byte("for export")
+  }
+
+load_statement:
+  LOAD ID load_infile load_duplicate_opt INTO TABLE dml_table_expression load_option_list_opt load_ignore_opt column_list_opt
+  {
+    if !bytes.EqualFold($2.Value, DATA) {
+      yylex.Error("expecting data")
+      return 1
+    }
+    load := $3
+    fields, lines, msg := newLoadOptions($8)
+    if msg != "" {
+      yylex.Error(msg)
+      return 1
+    }
+    load.Conflict, load.Table, load.Fields, load.Lines, load.IgnoreLines, load.Columns = $4, $7, fields, lines, $9, $10
+    $$ = load
+  }
+
+load_infile:
+  ID STRING
+  {
+    if !bytes.EqualFold($1.Value, INFILE) {
+      yylex.Error("expecting infile")
+      return 1
+    }
+    $$ = &Load{FileName: $2}
+  }
+| ID ID STRING
+  {
+    if !bytes.EqualFold($1.Value, LOCAL) || !bytes.EqualFold($2.Value, INFILE) {
+      yylex.Error("expecting local infile")
+      return 1
+    }
+    $$ = &Load{Local: true, FileName: $3}
+  }
+
+load_duplicate_opt:
+  {
+    $$ = nil
+  }
+| REPLACE
+  {
+    $$ = []byte("replace")
+  }
+| IGNORE
+  {
+    $$ = []byte("ignore")
+  }
+
+load_option_list_opt:
+  {
+    $$ = nil
+  }
+| load_option_list
+
+load_option_list:
+  load_option
+  {
+    $$ = [][]*Node{$1}
+  }
+| load_option_list load_option
+  {
+    $$ = append($1, $2)
+  }
+
+load_option:
+  load_option_words BY STRING
+  {
+    $$ = append($1, $3)
+  }
+
+load_option_words:
+  ID
+  {
+    $$ = []*Node{$1}
+  }
+| load_option_words ID
+  {
+    $$ = append($1, $2)
+  }
+
+load_ignore_opt:
+  {
+    $$ = nil
+  }
+| IGNORE NUMBER ID
+  {
+    if !bytes.EqualFold($3.Value, LINES) {
+      yylex.Error("expecting lines or rows")
+      return 1
+    }
+    $$ = $2
+  }
+| IGNORE NUMBER ROWS
+  {
+    $$ = $2
+  }
+
+grant_statement:
+  GRANT privilege_list ON grant_object TO grant_user_list
+  {
+    $$ = &Grant{Action: GRANT, Privileges: $2, On: $4, Users: $6}
+  }
+| GRANT privilege_list ON grant_object TO grant_user_list error
+  {
+    // The trailing clauses are kept as text.
+    $$ = &Grant{Action: GRANT, Privileges: $2, On: $4, Users: $6, Raw: rawTail(yylex)}
+  }
+| REVOKE privilege_list ON grant_object FROM grant_user_list
+  {
+    $$ = &Grant{Action: REVOKE, Privileges: $2, On: $4, Users: $6}
+  }
+
+privilege_list:
+  privilege
+  {
+    $$ = []string{$1}
+  }
+| privilege_list ',' privilege
+  {
+    $$ = append($1, $3)
+  }
+
+privilege:
+  privilege_word
+  {
+    $$ = string(bytes.ToLower($1.Value))
+  }
+| privilege privilege_word
+  {
+    $$ = $1 + " " + string(bytes.ToLower($2.Value))
+  }
+
+privilege_word:
+  ID
+| SELECT
+| INSERT
+| UPDATE
+| DELETE
+| CREATE
+| DROP
+| ALTER
+| INDEX
+| ALL
+| LOCK
+| SHOW
+| VIEW
+| TEMPORARY
+| GRANT
+
+grant_object:
+  grant_name
+| grant_name '.' grant_name
+  {
+    $$ = $2.PushTwo($1, $3)
+  }
+
+grant_name:
+  ID
+| '*'
+  {
+    $$ = NewSimpleParseNode(WILDCARD, "*")
+  }
+
+grant_user_list:
+  grant_user
+  {
+    $$ = []*UserSpec{$1}
+  }
+| grant_user_list ',' grant_user
+  {
+    $$ = append($1, $3)
+  }
+
+grant_user:
+  ID
+  {
+    $$ = &UserSpec{User: $1}
+  }
+| STRING %prec USER_NAME
+  {
+    $$ = &UserSpec{User: $1}
+  }
+| STRING ID
+  {
+    // The tokenizer reads 'user'@host as a string
+    // followed by the identifier @host.
+    if len($2.Value) < 2 || $2.Value[0] != '@' {
+      yylex.Error("expecting @host")
+      return 1
+    }
+    $2.Value = $2.Value[1:]
+    $$ = &UserSpec{User: $1, Host: $2}
+  }
+| STRING ID STRING
+  {
+    if string($2.Value) != "@" {
+      yylex.Error("expecting @")
+      return 1
+    }
+    $$ = &UserSpec{User: $1, Host: $3}
+  }
+
+lock_statement:
+  LOCK tables_keyword table_lock_list
+  {
+    $$ = &LockTables{Tables: $3}
+  }
+
+unlock_statement:
+  UNLOCK tables_keyword
+  {
+    $$ = &UnlockTables{}
+  }
+
+show_statement:
+  SHOW sql_id show_filter_opt
+  {
+    show := &Show{}
+    switch string($2.Value) {
+    case "vitess_keyspaces":
+      show.VitessObject = &ShowVitessKeyspaces{}
+    case "vitess_shards":
+      show.VitessObject = &ShowVitessShards{}
+    case "tables":
+      show.Type = SHOW_TABLES
+    case "databases", "schemas":
+      show.Type = SHOW_DATABASES
+    case "variables":
+      show.Type = SHOW_VARIABLES
+    case "status":
+      show.Type = SHOW_STATUS
+    case "warnings":
+      show.Type = SHOW_WARNINGS
+    case "errors":
+      show.Type = SHOW_ERRORS
+    default:
+      yylex.Error("unexpected show " + string($2.Value))
+      return 1
+    }
+    if (show.Type == SHOW_WARNINGS || show.Type == SHOW_ERRORS) && $3 != nil {
+      yylex.Error("unexpected " + string($3.Value))
+      return 1
+    }
+    if !setShowFilter(show, $3) {
+      yylex.Error("unexpected where")
+      return 1
+    }
+    $$ = show
+  }
+| SHOW sql_id limit
+  {
+    show := &Show{Limit: $3}
+    switch string($2.Value) {
+    case "warnings":
+      show.Type = SHOW_WARNINGS
+    case "errors":
+      show.Type = SHOW_ERRORS
+    default:
+      yylex.Error("unexpected show " + string($2.Value))
+      return 1
+    }
+    $$ = show
+  }
+| SHOW sql_id '(' '*' ')' sql_id
+  {
+    show := &Show{Count: true}
+    switch {
+    case !bytes.Equal($2.Value, COUNT):
+      yylex.Error("unexpected show " + string($2.Value))
+      return 1
+    case bytes.Equal($6.Value, WARNINGS):
+      show.Type = SHOW_WARNINGS
+    case bytes.Equal($6.Value, ERRORS):
+      show.Type = SHOW_ERRORS
+    default:
+      yylex.Error("expecting warnings or errors")
+      return 1
+    }
+    $$ = show
+  }
+| SHOW sql_id sql_id show_filter_opt
+  {
+    show := &Show{Scope: setScope($2.Value)}
+    if show.Scope == nil {
+      yylex.Error("unexpected show " + string($2.Value))
+      return 1
+    }
+    switch string($3.Value) {
+    case "variables":
+      show.Type = SHOW_VARIABLES
+    case "status":
+      show.Type = SHOW_STATUS
+    default:
+      yylex.Error("unexpected show " + string($3.Value))
+      return 1
+    }
+    setShowFilter(show, $4)
+    $$ = show
+  }
+| SHOW sql_id show_from dml_table_expression show_filter_opt
+  {
+    show := &Show{}
+    switch string($2.Value) {
+    case "tables":
+      if $4.Type != ID {
+        yylex.Error("expecting database name")
+        return 1
+      }
+      show.Type = SHOW_TABLES
+      show.DBName = $4
+    case "columns", "fields":
+      show.Type = SHOW_COLUMNS
+      show.OnTable = $4
+    default:
+      yylex.Error("unexpected show " + string($2.Value))
+      return 1
+    }
+    setShowFilter(show, $5)
+    $$ = show
+  }
+| SHOW sql_id show_from ID show_from ID show_filter_opt
+  {
+    switch string($2.Value) {
+    case "columns", "fields":
+    default:
+      yylex.Error("unexpected show " + string($2.Value))
+      return 1
+    }
+    show := &Show{Type: SHOW_COLUMNS, OnTable: NewSimpleParseNode('.', ".").PushTwo($6, $4)}
+    setShowFilter(show, $7)
+    $$ = show
+  }
 
-%start any_command
+| SHOW CREATE TABLE dml_table_expression
+  {
+    $$ = &Show{Type: SHOW_CREATE_TABLE, OnTable: $4}
+  }
+| SHOW CREATE VIEW dml_table_expression
+  {
+    $$ = &Show{Type: SHOW_CREATE_VIEW, OnTable: $4}
+  }
 
-// Fake Tokens
-%token <node> NODE_LIST UPLUS UMINUS CASE_WHEN WHEN_LIST FUNCTION NO_LOCK FOR_UPDATE LOCK_IN_SHARE_MODE
-%token <node> NOT_IN NOT_LIKE NOT_BETWEEN IS_NULL IS_NOT_NULL UNION_ALL INDEX_LIST TABLE_EXPR
+show_from:
+  FROM
+| IN
 
-%type <statement> command
-%type <statement> select_statement insert_statement update_statement delete_statement set_statement
-%type <statement> create_statement alter_statement rename_statement drop_statement
-%type <comments> comment_opt comment_list
-%type <str> union_op
-%type <distinct> distinct_opt
-%type <selectExprs> select_expression_list
-%type <selectExpr> select_expression
-%type <str> as_lower_opt as_opt
-%type <node> expression
-%type <tableExprs> table_expression_list
-%type <tableExpr> table_expression
-%type <str> join_type
-%type <node> simple_table_expression dml_table_expression index_hint_list
-%type <node> where_expression_opt boolean_expression condition compare
-%type <sqlNode> values
-%type <node> parenthesised_lists parenthesised_list value_expression_list value_expression keyword_as_func
-%type <node> unary_operator case_expression when_expression_list when_expression column_name value
-%type <node> group_by_opt having_opt order_by_opt order_list order asc_desc_opt limit_opt lock_opt on_dup_opt
-%type <columns> column_list_opt column_list
-%type <node> index_list update_list update_expression
-%type <node> exists_opt not_exists_opt ignore_opt non_rename_operation to_opt constraint_opt using_opt
-%type <node> sql_id
-%type <node> force_eof
+show_filter_opt:
+  {
+    $$ = nil
+  }
+| LIKE STRING
+  {
+    $$ = $1.Push($2)
+  }
+| WHERE boolean_expression
+  {
+    $$ = $1.Push($2)
+  }
 
-%%
+tables_keyword:
+  TABLE
+  {
+  }
+| sql_id
+  {
+    if !bytes.Equal($1.Value, TABLES) {
+      yylex.Error("expecting tables")
+      return 1
+    }
+  }
 
-any_command:
-  command
+table_lock_list:
+  table_lock
   {
-    SetParseTree(yylex, $1)
+    $$ = []*TableLock{$1}
+  }
+| table_lock_list ',' table_lock
+  {
+    $$ = append($1, $3)
   }
 
-command:
-  select_statement
-| insert_statement
-| update_statement
-| delete_statement
-| set_statement
-| create_statement
-| alter_statement
-| rename_statement
-| drop_statement
+table_lock:
+  dml_table_expression ID
+  {
+    lock, name, ok := lockType($2)
+    if !ok {
+      yylex.Error("unexpected lock type " + name)
+      return 1
+    }
+    $$ = &TableLock{Table: &AliasedTableExpr{Expr: $1}, Lock: lock}
+  }
+| dml_table_expression ID ID
+  {
+    // Either a lock type of two words, or an alias and a lock type.
+    if lock, _, ok := lockType($2, $3); ok {
+      $$ = &TableLock{Table: &AliasedTableExpr{Expr: $1}, Lock: lock}
+    } else if lock, name, ok := lockType($3); ok {
+      $$ = &TableLock{Table: &AliasedTableExpr{Expr: $1, As: $2.Value}, Lock: lock}
+    } else {
+      yylex.Error("unexpected lock type " + string(bytes.ToLower($2.Value)) + " " + name)
+      return 1
+    }
+  }
+| dml_table_expression ID ID ID
+  {
+    lock, name, ok := lockType($3, $4)
+    if !ok {
+      yylex.Error("unexpected lock type " + name)
+      return 1
+    }
+    $$ = &TableLock{Table: &AliasedTableExpr{Expr: $1, As: $2.Value}, Lock: lock}
+  }
+| dml_table_expression AS ID lock_type
+  {
+    $$ = &TableLock{Table: &AliasedTableExpr{Expr: $1, As: $3.Value}, Lock: $4}
+  }
 
-select_statement:
-  SELECT comment_opt distinct_opt select_expression_list FROM table_expression_list where_expression_opt group_by_opt having_opt order_by_opt limit_opt lock_opt
+lock_type:
+  ID
   {
-    $$ = &Select{Comments: $2, Distinct: $3, SelectExprs: $4, From: $6, Where: $7, GroupBy: $8, Having: $9, OrderBy: $10, Limit: $11, Lock: $12}
+    lock, name, ok := lockType($1)
+    if !ok {
+      yylex.Error("unexpected lock type " + name)
+      return 1
+    }
+    $$ = lock
   }
-| select_statement union_op select_statement %prec UNION
+| ID ID
   {
-    $$ = &Union{Type: $2, Select1: $1.(SelectStatement), Select2: $3.(SelectStatement)}
+    lock, name, ok := lockType($1, $2)
+    if !ok {
+      yylex.Error("unexpected lock type " + name)
+      return 1
+    }
+    $$ = lock
   }
 
-insert_statement:
-  INSERT comment_opt INTO dml_table_expression column_list_opt values on_dup_opt
+partitions_opt:
+  {
+    $$ = false
+  }
+| PARTITIONS
   {
-    $$ = &Insert{Comments: $2, Table: $4, Columns: $5, Values: $6, OnDup: $7}
+    $$ = true
   }
 
-update_statement:
-  UPDATE comment_opt dml_table_expression SET update_list where_expression_opt order_by_opt limit_opt
+table_spec:
+  column_definition
+  {
+    $$ = &TableSpec{Columns: []*ColumnDefinition{$1}}
+  }
+| table_spec ',' column_definition
+  {
+    $$.Columns = append($$.Columns, $3)
+  }
+| table_spec ',' index_definition
+  {
+    $$.Indexes = append($$.Indexes, $3)
+  }
+| table_spec ',' constraint_definition
   {
-    $$ = &Update{Comments: $2, Table: $3, List: $5, Where: $6, OrderBy: $7, Limit: $8}
+    $$.Constraints = append($$.Constraints, $3)
   }
 
-delete_statement:
-  DELETE comment_opt FROM dml_table_expression where_expression_opt order_by_opt limit_opt
+table_option_list_opt:
   {
-    $$ = &Delete{Comments: $2, Table: $4, Where: $5, OrderBy: $6, Limit: $7}
+    $$ = nil
   }
+| table_option_list
 
-set_statement:
-  SET comment_opt update_list
+table_option_list:
+  table_option
+  {
+    $$ = []*TableOption{$1}
+  }
+| table_option_list table_option
   {
-    $$ = &Set{Comments: $2, Updates: $3}
+    $$ = append($1, $2)
+  }
+| table_option_list ',' table_option
+  {
+    $$ = append($1, $3)
   }
 
-create_statement:
-  CREATE TABLE not_exists_opt ID force_eof
+table_option:
+  sql_id equal_opt table_option_value
   {
-    $$ = &DDLSimple{Action: CREATE, Table: $4}
+    if bytes.Equal($1.Value, CHARACTER) {
+      yylex.Error("expecting character set")
+      return 1
+    }
+    $$ = &TableOption{Name: $1.Value, Value: $3}
   }
-| CREATE constraint_opt INDEX sql_id using_opt ON ID force_eof
+| sql_id SET equal_opt table_option_value
   {
-    // Change this to an alter statement
-    $$ = &DDLSimple{Action: ALTER, Table: $7}
+    if !bytes.Equal($1.Value, CHARACTER) {
+      yylex.Error("expecting character set")
+      return 1
+    }
+    $$ = &TableOption{Name: CHARSET, Value: $4}
   }
-| CREATE VIEW sql_id force_eof
+| COLLATE equal_opt table_option_value
   {
-    $$ = &DDLSimple{Action: CREATE, Table: $3}
+    $$ = &TableOption{Name: COLLATE_OPTION, Value: $3}
+  }
+| DEFAULT table_option
+  {
+    if !bytes.Equal($2.Name, CHARSET) && !bytes.Equal($2.Name, COLLATE_OPTION) {
+      yylex.Error("unexpected default " + string($2.Name))
+      return 1
+    }
+    $$ = $2
   }
 
-alter_statement:
-  ALTER ignore_opt TABLE ID non_rename_operation force_eof
+table_option_value:
+  sql_id
+| STRING
+| NUMBER
+
+equal_opt:
+  {}
+| '='
+  {}
+
+column_definition:
+  sql_id column_type_spec
+  {
+    $$ = &ColumnDefinition{Name: $1, Type: $2}
+  }
+
+column_type:
+  sql_id
+  {
+    $$ = ColumnType{Type: $1.Value}
+  }
+| sql_id '(' NUMBER ')'
   {
-    $$ = &DDLSimple{Action: ALTER, Table: $4}
+    $$ = ColumnType{Type: $1.Value, Length: $3.Value}
   }
-| ALTER ignore_opt TABLE ID RENAME to_opt ID
+| sql_id '(' NUMBER ',' NUMBER ')'
   {
-    // Change this to a rename statement
-    $$ = &Rename{OldName: $4, NewName: $7}
+    $$ = ColumnType{Type: $1.Value, Length: $3.Value, Scale: $5.Value}
   }
-| ALTER VIEW sql_id force_eof
+| sql_id '(' enum_value_list ')'
   {
-    $$ = &DDLSimple{Action: ALTER, Table: $3}
+    if !bytes.Equal($1.Value, ENUM) && !skipDDLClause(yylex, "expecting enum") {
+      return 1
+    }
+    $$ = ColumnType{Type: $1.Value, EnumValues: $3}
+  }
+| SET '(' enum_value_list ')'
+  {
+    $$ = ColumnType{Type: []byte("set"), EnumValues: $3}
   }
 
-rename_statement:
-  RENAME TABLE ID TO ID
+enum_value_list:
+  STRING
   {
-    $$ = &Rename{OldName: $3, NewName: $5}
+    $$ = []*Node{$1}
+  }
+| enum_value_list ',' STRING
+  {
+    $$ = append($1, $3)
   }
 
-drop_statement:
-  DROP TABLE exists_opt ID
+column_type_spec:
+  column_type
+| column_type_spec NULL
   {
-    $$ = &DDLSimple{Action: DROP, Table: $4}
+    $$.NotNull = false
   }
-| DROP INDEX sql_id ON ID
+| column_type_spec NOT NULL
   {
-    // Change this to an alter statement
-    $$ = &DDLSimple{Action: ALTER, Table: $5}
+    $$.NotNull = true
+  }
+| column_type_spec DEFAULT column_default
+  {
+    $$.Default = $3
+  }
+| column_type_spec ON UPDATE column_default
+  {
+    $$.OnUpdate = $4
+  }
+| column_type_spec UNIQUE
+  {
+    $$.KeyOpt = UNIQUE
+  }
+| column_type_spec UNIQUE KEY
+  {
+    $$.KeyOpt = UNIQUE
+  }
+| column_type_spec COLLATE sql_id
+  {
+    $$.Collate = $3.Value
+  }
+| column_type_spec sql_id SET sql_id
+  {
+    if !bytes.Equal($2.Value, CHARACTER) && !skipDDLClause(yylex, "unexpected column attribute " + string($2.Value)) {
+      return 1
+    }
+    $$.Charset = $4.Value
+  }
+| column_type_spec GENERATED sql_id AS '(' expression ')'
+  {
+    if string($3.Value) != "always" {
+      yylex.Error("expecting generated always")
+      return 1
+    }
+    $$.Generated = $6
+  }
+| column_type_spec AS '(' expression ')'
+  {
+    $$.Generated = $4
+  }
+| column_type_spec CHECK '(' expression ')'
+  {
+    $$.Check = $4
+  }
+| column_type_spec sql_id KEY
+  {
+    if !bytes.Equal($2.Value, PRIMARY) && !skipDDLClause(yylex, "unexpected column attribute " + string($2.Value)) {
+      return 1
+    }
+    $$.KeyOpt = KEY
+  }
+| column_type_spec sql_id
+  {
+    if !setColumnAttribute(&$$, $2.Value, nil) && !skipDDLClause(yylex, "unexpected column attribute " + string($2.Value)) {
+      return 1
+    }
+  }
+| column_type_spec sql_id STRING
+  {
+    if !setColumnAttribute(&$$, $2.Value, $3) && !skipDDLClause(yylex, "unexpected column attribute " + string($2.Value)) {
+      return 1
+    }
+  }
+| column_type_spec sql_id NUMBER
+  {
+    if !setColumnAttribute(&$$, $2.Value, $3) && !skipDDLClause(yylex, "unexpected column attribute " + string($2.Value)) {
+      return 1
+    }
+  }
+
+// column_default is the value of DEFAULT or ON UPDATE,
+// like a signed number, current_timestamp(6) or an
+// expression in parentheses.
+column_default:
+  value
+| sql_id
+| function_call
+| '-' NUMBER
+  {
+    $2.Value = append([]byte("-"), $2.Value...)
+    $$ = $2
   }
-| DROP VIEW exists_opt sql_id force_eof
+| '+' NUMBER
   {
-    $$ = &DDLSimple{Action: DROP, Table: $4}
+    $$ = $2
+  }
+| '(' expression ')'
+  {
+    $$ = $1.Push($2)
   }
 
 comment_opt:
@@ -229,34 +2801,65 @@ comment_list:
 union_op:
   UNION
   {
-    $$ = $1.Value
+    $$ = SETOP_UNION
   }
 | UNION ALL
   {
-    $$ = []byte("union all")
+    $$ = SETOP_UNION_ALL
+  }
+| UNION DISTINCT
+  {
+    $$ = SETOP_UNION_DISTINCT
   }
 | MINUS
   {
-    $$ = $1.Value
+    $$ = SETOP_MINUS
   }
 | EXCEPT
   {
-    $$ = $1.Value
+    $$ = SETOP_EXCEPT
+  }
+| EXCEPT ALL
+  {
+    $$ = SETOP_EXCEPT_ALL
+  }
+| EXCEPT DISTINCT
+  {
+    $$ = SETOP_EXCEPT_DISTINCT
   }
 | INTERSECT
   {
-    $$ = $1.Value
+    $$ = SETOP_INTERSECT
+  }
+| INTERSECT ALL
+  {
+    $$ = SETOP_INTERSECT_ALL
+  }
+| INTERSECT DISTINCT
+  {
+    $$ = SETOP_INTERSECT_DISTINCT
   }
 
-distinct_opt:
+select_option_list_opt:
   {
-    $$ = Distinct(false)
+    $$ = nil
   }
-| DISTINCT
+| select_option_list_opt select_option
   {
-    $$ = Distinct(true)
+    $$ = append($1, $2)
   }
 
+select_option:
+  DISTINCT
+| STRAIGHT_JOIN
+| SQL_CALC_FOUND_ROWS
+| SQL_CACHE
+| SQL_NO_CACHE
+| SQL_SMALL_RESULT
+| SQL_BIG_RESULT
+| SQL_BUFFER_RESULT
+| HIGH_PRIORITY
+
 select_expression_list:
   select_expression
   {
@@ -282,9 +2885,19 @@ select_expression:
   }
 
 expression:
-  boolean_expression
+  logical_expression
 | value_expression
 
+expression_list:
+  expression
+  {
+    $$ = []*Node{$1}
+  }
+| expression_list ',' expression
+  {
+    $$ = append($$, $3)
+  }
+
 as_lower_opt:
   {
     $$ = nil
@@ -293,7 +2906,7 @@ as_lower_opt:
   {
     $$ = $1.Value
   }
-| AS sql_id
+| AS column_id
   {
     $$ = $2.Value
   }
@@ -311,7 +2924,35 @@ table_expression_list:
 table_expression:
   simple_table_expression as_opt index_hint_list
   {
-    $$ = &AliasedTableExpr{Expr:$1, As: $2, Hint: $3}
+    if $1.Type == '(' && $2 == nil {
+      yylex.Error("every derived table must have its own alias")
+      return 1
+    }
+    $$ = &AliasedTableExpr{Expr:$1, As: $2, Hints: $3}
+  }
+| simple_table_expression partition_list as_opt index_hint_list
+  {
+    if $1.Type == '(' {
+      yylex.Error("partitions can only be selected from a table")
+      return 1
+    }
+    $$ = &AliasedTableExpr{Expr:$1, Partitions: $2, As: $3, Hints: $4}
+  }
+| simple_table_expression ID partition_list index_hint_list
+  {
+    if $1.Type == '(' {
+      yylex.Error("partitions can only be selected from a table")
+      return 1
+    }
+    $$ = &AliasedTableExpr{Expr:$1, Partitions: $3, As: $2.Value, Hints: $4}
+  }
+| simple_table_expression AS ID partition_list index_hint_list
+  {
+    if $1.Type == '(' {
+      yylex.Error("partitions can only be selected from a table")
+      return 1
+    }
+    $$ = &AliasedTableExpr{Expr:$1, Partitions: $4, As: $3.Value, Hints: $5}
   }
 | '(' table_expression ')'
   {
@@ -343,7 +2984,7 @@ as_opt:
   {
     $$ = $1.Value
   }
-| AS ID
+| AS table_id
   {
     $$ = $2.Value
   }
@@ -355,7 +2996,7 @@ join_type:
   }
 | STRAIGHT_JOIN
   {
-    $$ = $1.Value
+    $$ = SJOIN
   }
 | LEFT JOIN
   {
@@ -387,9 +3028,14 @@ join_type:
   }
 
 simple_table_expression:
-ID
-| ID '.' ID
+table_id
+| ID '.' table_id
+  {
+    $$ = $2.PushTwo($1, $3)
+  }
+| non_reserved_keyword '.' table_id
   {
+    $1.Type = ID
     $$ = $2.PushTwo($1, $3)
   }
 | '(' select_statement ')'
@@ -397,25 +3043,85 @@ ID
     $$ = $1.Push($2)
   }
 
+partition_opt:
+  {
+    $$ = nil
+  }
+| partition_list
+
+partition_list:
+  PARTITION '(' table_id_list ')'
+  {
+    $$ = Partitions($3)
+  }
+
 dml_table_expression:
-ID
-| ID '.' ID
+table_id
+| ID '.' table_id
   {
     $$ = $2.PushTwo($1, $3)
   }
+| non_reserved_keyword '.' table_id
+  {
+    $1.Type = ID
+    $$ = $2.PushTwo($1, $3)
+  }
 
 index_hint_list:
   {
     $$ = nil
   }
-| USE INDEX '(' index_list ')'
+| index_hint_list index_hint
   {
-    $$.Push($4)
+    $$ = append($1, $2)
   }
-| FORCE INDEX '(' index_list ')'
+
+index_hint:
+  index_hint_type index_or_key index_hint_for_opt '(' index_list_opt ')'
   {
-    $$.Push($4)
+    if $5 == nil && $1 != USE_INDEX {
+      yylex.Error("expecting index names")
+      return 1
+    }
+    $$ = &IndexHint{Type: $1, For: $3, Indexes: $5}
+  }
+
+index_hint_type:
+  USE
+  {
+    $$ = USE_INDEX
+  }
+| IGNORE
+  {
+    $$ = IGNORE_INDEX
+  }
+| FORCE
+  {
+    $$ = FORCE_INDEX
+  }
+
+index_hint_for_opt:
+  {
+    $$ = HINT_FOR_ALL
+  }
+| FOR JOIN
+  {
+    $$ = HINT_FOR_JOIN
+  }
+| FOR ORDER BY
+  {
+    $$ = HINT_FOR_ORDER_BY
+  }
+| FOR GROUP BY
+  {
+    $$ = HINT_FOR_GROUP_BY
+  }
+
+index_list_opt:
+  {
+    $$ = nil
   }
+| index_list
 
 where_expression_opt:
   {
@@ -427,6 +3133,24 @@ where_expression_opt:
   }
 
 boolean_expression:
+  logical_expression
+| value_expression
+  {
+    // TRUE and FALSE are read as values, so that expression
+    // has a single way of parsing them. They're the only
+    // values that can be used as conditions.
+    if !isBooleanValue($1) {
+      if isSubquery($1) && $<node>0.Type == NOT {
+        yylex.Error("expecting EXISTS before the subquery negated by NOT")
+      } else {
+        yylex.Error("syntax error")
+      }
+      return 1
+    }
+  }
+
+// logical_expression is a boolean_expression that isn't a value.
+logical_expression:
   condition
 | boolean_expression AND boolean_expression
   {
@@ -440,7 +3164,7 @@ boolean_expression:
   {
     $$ = $1.Push($2)
   }
-| '(' boolean_expression ')'
+| '(' logical_expression ')'
   {
     $$ = $1.Push($2)
   }
@@ -450,6 +3174,10 @@ condition:
   {
     $$ = $2.PushTwo($1, $3)
   }
+| value_expression compare quantifier '(' select_statement ')'
+  {
+    $$ = $2.PushTwo($1, $3.Push(NewSimpleParseNode('(', "(").Push($5)))
+  }
 | value_expression IN parenthesised_list
   {
     $$ = $2.PushTwo($1, $3)
@@ -466,6 +3194,10 @@ condition:
   {
     $$ = NewSimpleParseNode(NOT_LIKE, "not like").PushTwo($1, $4)
   }
+| value_expression MEMBER OF '(' value_expression ')'
+  {
+    $$ = NewSimpleParseNode(MEMBER_OF, "member of").PushTwo($1, $5)
+  }
 | value_expression BETWEEN value_expression AND value_expression
   {
     $$ = $2
@@ -493,6 +3225,10 @@ condition:
     $$ = $1.Push($3)
   }
 
+boolean_value:
+  TRUE
+| FALSE
+
 compare:
   '='
 | '<'
@@ -502,6 +3238,11 @@ compare:
 | NE
 | NULL_SAFE_EQUAL
 
+quantifier:
+  ANY
+| SOME
+| ALL
+
 values:
   VALUES parenthesised_lists
   {
@@ -547,20 +3288,32 @@ value_expression_list:
 value_expression:
   value
 | column_name
+| EXTENSION_EXPR
+  {
+    $$ = $1.NodeAt(0)
+  }
+| column_name OUTER_JOIN_MARKER
+  {
+    yylex.Error("the (+) outer join marker is not supported, use LEFT JOIN")
+    return 1
+  }
 | '(' select_statement ')'
   {
     $$ = $1.Push($2)
   }
 | '(' value_expression_list ')'
   {
-    if $2.Len() == 1 {
-      $2 = $2.NodeAt(0)
-    }
-    switch $2.Type {
-    case NUMBER, STRING, ID, VALUE_ARG, '(', '.':
-      $$ = $2
-    default:
-      $$ = $1.Push($2)
+    if $2.Len() > 1 {
+      // A row constructor, like (a, b) in (a, b) = (1, 2).
+      $$ = NewSimpleParseNode(TUPLE, "tuple")
+      $$.Sub = $2.Sub
+    } else {
+      switch $2.NodeAt(0).Type {
+      case NUMBER, STRING, ID, VALUE_ARG, '(', '.', TUPLE:
+        $$ = $2.NodeAt(0)
+      default:
+        $$ = $1.Push($2.NodeAt(0))
+      }
     }
   }
 | value_expression '&' value_expression
@@ -575,6 +3328,10 @@ value_expression:
   {
     $$ = $2.PushTwo($1, $3)
   }
+| value_expression CONCAT_PIPE value_expression
+  {
+    $$ = $2.PushTwo($1, $3)
+  }
 | value_expression '+' value_expression
   {
     $$ = $2.PushTwo($1, $3)
@@ -611,7 +3368,50 @@ value_expression:
       $$ = $1.Push($2)
     }
   }
-| sql_id '(' ')'
+| function_call
+| function_call over_clause
+  {
+    $$ = NewSimpleParseNode(OVER, "over").PushTwo($1, $2)
+  }
+| keyword_as_func '(' ')'
+  {
+    $1.Type = FUNCTION
+    $$ = $1.Push(NewSimpleParseNode(NODE_LIST, "node_list"))
+  }
+| keyword_as_func '(' select_expression_list ')'
+  {
+    if column, ok := valuesColumn($1, $3); ok {
+      $1.Type = VALUES_FUNC
+      $$ = $1.Push(column)
+    } else {
+      $1.Type = FUNCTION
+      $$ = $1.Push($3)
+    }
+  }
+| case_expression
+| value_expression COLLATE sql_id
+  {
+    $$ = $2.PushTwo($1, $3)
+  }
+| value_expression AT sql_id sql_id STRING
+  {
+    if !bytes.Equal($3.Value, TIME) || !bytes.Equal($4.Value, ZONE) {
+      yylex.Error("expecting time zone")
+      return 1
+    }
+    $$ = NewSimpleParseNode(AT_TIME_ZONE, "at time zone").PushTwo($1, $5)
+  }
+| column_name JSON_EXTRACT_OP STRING
+  {
+    $$ = $2.PushTwo($1, $3)
+  }
+| column_name JSON_UNQUOTE_EXTRACT_OP STRING
+  {
+    $$ = $2.PushTwo($1, $3)
+  }
+
+function_call:
+  sql_id '(' ')'
   {
     $1.Type = FUNCTION
     $$ = $1.Push(NewSimpleParseNode(NODE_LIST, "node_list"))
@@ -627,16 +3427,106 @@ value_expression:
     $$ = $1.Push($3)
     $$ = $1.Push($4)
   }
-| keyword_as_func '(' select_expression_list ')'
+
+over_clause:
+  OVER '(' window_spec ')'
   {
-    $1.Type = FUNCTION
-    $$ = $1.Push($3)
+    $$ = $3
+  }
+| OVER sql_id
+  {
+    $$ = &OverClause{Name: $2}
+  }
+
+window_spec:
+  partition_by_opt window_order_opt frame_opt
+  {
+    $$ = &OverClause{PartitionBy: $1, OrderBy: $2, Frame: $3}
+  }
+| sql_id partition_by_opt window_order_opt frame_opt
+  {
+    $$ = &OverClause{Name: $1, PartitionBy: $2, OrderBy: $3, Frame: $4}
+  }
+
+window_opt:
+  {
+    $$ = nil
+  }
+| WINDOW named_window_list
+  {
+    $$ = $2
+  }
+
+named_window_list:
+  named_window
+  {
+    $$ = NamedWindows{$1}
+  }
+| named_window_list ',' named_window
+  {
+    $$ = append($1, $3)
+  }
+
+named_window:
+  sql_id AS '(' window_spec ')'
+  {
+    $$ = &NamedWindow{Name: $1, Spec: $4}
+  }
+
+partition_by_opt:
+  {
+    $$ = nil
+  }
+| PARTITION BY value_expression_list
+  {
+    $$ = $3
+  }
+
+frame_opt:
+  {
+    $$ = nil
+  }
+| frame_unit frame_point
+  {
+    $$ = &FrameClause{Unit: $1.Value, Start: $2}
+  }
+| frame_unit BETWEEN frame_point AND frame_point
+  {
+    $$ = &FrameClause{Unit: $1.Value, Start: $3, End: $5}
+  }
+
+frame_unit:
+  ROWS
+| RANGE
+
+frame_point:
+  value_expression sql_id
+  {
+    point, ok := newFramePoint($1, $2.Value)
+    if !ok {
+      yylex.Error("unexpected frame bound")
+      return 1
+    }
+    $$ = point
+  }
+
+window_order_opt:
+  {
+    $$ = nil
+  }
+| ORDER BY order_list
+  {
+    $$ = $3
   }
-| case_expression
 
 keyword_as_func:
   IF
 | VALUES
+| CONVERT
+| REPLACE
+| DATABASE
+| SCHEMA
+| TRUNCATE
 
 unary_operator:
   '+'
@@ -682,17 +3572,27 @@ when_expression:
   }
 
 column_name:
-  sql_id
-| ID '.' sql_id
+  column_id
+| ID '.' column_id
   {
     $$ = $2.PushTwo($1, $3)
   }
+| non_reserved_keyword '.' column_id
+  {
+    $1.Type = ID
+    $$ = $2.PushTwo($1, $3)
+  }
+| ID '.' table_id '.' column_id
+  {
+    $$ = $4.PushTwo($2.PushTwo($1, $3), $5)
+  }
 
 value:
   STRING
 | NUMBER
 | VALUE_ARG
 | NULL
+| boolean_value
 
 group_by_opt:
   {
@@ -733,9 +3633,12 @@ order_list:
   }
 
 order:
-  value_expression asc_desc_opt
+  value_expression asc_desc_opt nulls_opt
   {
     $$ = $2.Push($1)
+    if $3 != nil {
+      $$ = $3.Push($$)
+    }
   }
 
 asc_desc_opt:
@@ -745,11 +3648,35 @@ asc_desc_opt:
 | ASC
 | DESC
 
+nulls_opt:
+  {
+    $$ = nil
+  }
+| sql_id sql_id
+  {
+    if !bytes.Equal($1.Value, NULLS) {
+      yylex.Error("expecting nulls")
+      return 1
+    }
+    switch {
+    case bytes.Equal($2.Value, FIRST):
+      $$ = NewSimpleParseNode(NULLS_FIRST, "nulls first")
+    case bytes.Equal($2.Value, LAST):
+      $$ = NewSimpleParseNode(NULLS_LAST, "nulls last")
+    default:
+      yylex.Error("expecting first or last")
+      return 1
+    }
+  }
+
 limit_opt:
   {
     $$ = NewSimpleParseNode(LIMIT, "limit")
   }
-| LIMIT value_expression
+| limit
+
+limit:
+  LIMIT value_expression
   {
     $$ = $1.Push($2)
   }
@@ -758,25 +3685,107 @@ limit_opt:
     $$ = $1.PushTwo($2, $4)
   }
 
-lock_opt:
+procedure_opt:
+  {
+    $$ = nil
+  }
+| PROCEDURE sql_id '(' ')'
+  {
+    $2.Type = FUNCTION
+    $$ = $2.Push(NewSimpleParseNode(NODE_LIST, "node_list"))
+  }
+| PROCEDURE sql_id '(' select_expression_list ')'
+  {
+    $2.Type = FUNCTION
+    $$ = $2.Push($4)
+  }
+
+into_opt:
+  {
+    $$ = nil
+  }
+| into_clause
+
+into_clause:
+  INTO into_file load_option_list_opt
   {
-    $$ = NewSimpleParseNode(NO_LOCK, "")
+    fields, lines, msg := newLoadOptions($3)
+    if msg != "" {
+      yylex.Error(msg)
+      return 1
+    }
+    if (fields != nil || lines != nil) && !bytes.Equal($2.Type, OUTFILE) {
+      yylex.Error("unexpected options for dumpfile")
+      return 1
+    }
+    $2.Fields, $2.Lines = fields, lines
+    $$ = $2
   }
-| FOR UPDATE
+| INTO into_variable_list
   {
-    $$ = NewSimpleParseNode(FOR_UPDATE, " for update")
+    $$ = &SelectInto{Variables: $2}
   }
-| LOCK IN sql_id sql_id
+
+into_file:
+  sql_id STRING
   {
-    if !bytes.Equal($3.Value, SHARE) {
-      yylex.Error("expecting share")
+    if !bytes.Equal($1.Value, OUTFILE) && !bytes.Equal($1.Value, DUMPFILE) {
+      yylex.Error("expecting outfile or dumpfile")
       return 1
     }
-    if !bytes.Equal($4.Value, MODE) {
-      yylex.Error("expecting mode")
+    $$ = &SelectInto{Type: $1.Value, FileName: $2}
+  }
+
+into_variable_list:
+  into_variable
+  {
+    $$ = []*Node{$1}
+  }
+| into_variable_list ',' into_variable
+  {
+    $$ = append($1, $3)
+  }
+
+into_variable:
+  ID
+  {
+    // Only user variables can be assigned outside
+    // of stored programs.
+    if $1.Value[0] != '@' {
+      yylex.Error("syntax error")
       return 1
     }
-    $$ = NewSimpleParseNode(LOCK_IN_SHARE_MODE, " lock in share mode")
+    $$ = $1
+  }
+
+lock_opt:
+  {
+    $$ = &Lock{Type: NO_LOCK}
+  }
+| FOR UPDATE lock_wait_opt
+  {
+    $$ = &Lock{Type: FOR_UPDATE, Wait: $3}
+  }
+| FOR SHARE lock_wait_opt
+  {
+    $$ = &Lock{Type: FOR_SHARE, Wait: $3}
+  }
+| LOCK IN SHARE MODE
+  {
+    $$ = &Lock{Type: LOCK_IN_SHARE_MODE}
+  }
+
+lock_wait_opt:
+  {
+    $$ = LOCK_WAIT
+  }
+| NOWAIT
+  {
+    $$ = LOCK_NOWAIT
+  }
+| SKIP LOCKED
+  {
+    $$ = LOCK_SKIP_LOCKED
   }
 
 column_list_opt:
@@ -801,12 +3810,23 @@ column_list:
 index_list:
   sql_id
   {
-    $$ = NewSimpleParseNode(INDEX_LIST, "")
-    $$.Push($1)
+    $$ = []*Node{$1}
   }
 | index_list ',' sql_id
   {
-    $$ = $1.Push($3)
+    $$ = append($1, $3)
+  }
+
+row_alias_opt:
+  {
+    $$ = nil
+  }
+| row_alias
+
+row_alias:
+  AS sql_id column_list_opt
+  {
+    $$ = &RowAlias{Name: $2, Columns: $3}
   }
 
 on_dup_opt:
@@ -835,6 +3855,109 @@ update_expression:
     $$ = $2.PushTwo($1, $3)
   }
 
+set_list:
+  set_expression
+  {
+    $$ = SetExprs{$1}
+  }
+| set_list ',' set_expression
+  {
+    $$ = append($1, $3)
+  }
+
+set_expression:
+  set_variable '=' set_value
+  {
+    $1.Expr = $3
+    $$ = $1
+  }
+| set_variable ASSIGN set_value
+  {
+    $1.Expr = $3
+    $$ = $1
+  }
+| set_charset
+
+transaction_words:
+  ID ID ID
+  {
+    $$ = []*Node{$1, $2, $3}
+  }
+| transaction_words ID
+  {
+    $$ = append($1, $2)
+  }
+| transaction_words ',' ID
+  {
+    $$ = append($1, $2, $3)
+  }
+
+set_variable:
+  column_name
+  {
+    $$ = &SetExpr{Name: $1}
+  }
+| ID column_name
+  {
+    scope := setScope($1.Value)
+    if scope == nil {
+      yylex.Error("expecting scope")
+      return 1
+    }
+    if $2.Type == ID && bytes.HasPrefix($2.Value, []byte("@")) || $2.Type == '.' && bytes.HasPrefix($2.NodeAt(0).Value, []byte("@")) {
+      yylex.Error("unexpected scope for variable")
+      return 1
+    }
+    $$ = &SetExpr{Scope: scope, Name: $2}
+  }
+
+set_charset:
+  ID charset_value
+  {
+    switch {
+    case bytes.EqualFold($1.Value, NAMES):
+      $$ = &SetExpr{Name: NewSimpleParseNode(SET_NAMES, "names"), Expr: $2}
+    case bytes.EqualFold($1.Value, CHARSET):
+      $$ = &SetExpr{Name: NewSimpleParseNode(SET_CHARSET, "character set"), Expr: $2}
+    default:
+      yylex.Error("expecting names or charset")
+      return 1
+    }
+  }
+| ID charset_value COLLATE collation_value
+  {
+    if !bytes.EqualFold($1.Value, NAMES) {
+      yylex.Error("expecting names")
+      return 1
+    }
+    $$ = &SetExpr{Name: NewSimpleParseNode(SET_NAMES, "names"), Expr: $3.PushTwo($2, $4)}
+  }
+| ID SET charset_value
+  {
+    if !bytes.EqualFold($1.Value, CHARACTER) {
+      yylex.Error("expecting character set")
+      return 1
+    }
+    $$ = &SetExpr{Name: NewSimpleParseNode(SET_CHARSET, "character set"), Expr: $3}
+  }
+
+charset_value:
+  sql_id
+| STRING
+| DEFAULT
+
+collation_value:
+  sql_id
+| STRING
+
+set_value:
+  expression
+| DEFAULT
+| column_name ASSIGN set_value
+  {
+    $$ = $2.PushTwo($1, $3)
+  }
+
 exists_opt:
   { $$ = nil }
 | IF EXISTS
@@ -843,28 +3966,42 @@ not_exists_opt:
   { $$ = nil }
 | IF NOT EXISTS
 
+database_keyword:
+  DATABASE
+| SCHEMA
+
+recursive_opt:
+  { $$ = false }
+| RECURSIVE
+  { $$ = true }
+
+temporary_opt:
+  { $$ = false }
+| TEMPORARY
+  { $$ = true }
+
 ignore_opt:
   { $$ = nil }
 | IGNORE
 
-non_rename_operation:
-  ALTER
+non_spec_operation:
+  ID
 | DEFAULT
-| DROP
-| ORDER
-| ID
+| AS
+| SELECT
 
 to_opt:
   { $$ = nil }
 | TO
 
-constraint_opt:
-  { $$ = nil }
-| UNIQUE
-
-using_opt:
-  { $$ = nil }
+index_using_opt:
+  {
+    $$ = nil
+  }
 | USING sql_id
+  {
+    $$ = $2.Value
+  }
 
 sql_id:
   ID
@@ -872,6 +4009,50 @@ sql_id:
     $$.LowerCase()
   }
 
+column_id:
+  sql_id
+| non_reserved_keyword
+  {
+    $$.Type = ID
+  }
+
+table_id:
+  ID
+| non_reserved_keyword
+  {
+    $$.Type = ID
+  }
+
+// non_reserved_keyword lists the keywords that MySQL doesn't
+// reserve, or that were accepted as identifiers before they
+// became keywords here. They're still accepted as column and
+// table names.
+non_reserved_keyword:
+  BEGIN
+| COMMIT
+| ROLLBACK
+| NEXT
+| AT
+| MEMBER
+| OF
+| DO
+| TEMPORARY
+| MODIFY
+| CHANGE
+| COLUMN
+| ANY
+| SOME
+| RESET
+| FLUSH
+| UNLOCK
+| ROWS
+| RANGE
+| SHARE
+| MODE
+| NOWAIT
+| SKIP
+| LOCKED
+
 force_eof:
 {
   ForceEOF(yylex)
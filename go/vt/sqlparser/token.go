@@ -8,6 +8,8 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/youtube/vitess/go/sqltypes"
 )
@@ -16,6 +18,7 @@ const EOFCHAR = 0x100
 
 type Tokenizer struct {
 	InStream      io.ByteReader
+	Options       ParserOptions
 	AllowComments bool
 	ForceEOF      bool
 	lastChar      uint16
@@ -24,11 +27,93 @@ type Tokenizer struct {
 	LastError     string
 	posVarIndex   int
 	ParseTree     Statement
+
+	// inVersionComment is set while scanning the body of a
+	// version-conditional comment that applies to the
+	// ServerVersion of Options.
+	inVersionComment bool
+
+	// inExtension is set while a DialectExtension is scanning.
+	// Unexpected characters are then returned as tokens.
+	inExtension bool
+
+	// pending is a token that was scanned ahead, and is
+	// returned by the next call to Scan.
+	pending *Node
+
+	// sql is the text being scanned, if it's known, and
+	// alterMarks are the offsets where the operations of
+	// an ALTER TABLE start in it. tokenStart is the offset
+	// of the last token scanned, and rawStart the one of
+	// the clauses that are kept as raw text if they can't
+	// be parsed.
+	sql        string
+	alterMarks []int
+	tokenStart int
+	rawStart   int
+
+	// skippedDDL is set when a clause of an ALTER TABLE
+	// operation isn't understood. See skipDDLClause.
+	skippedDDL bool
+
+	// createTable is the start of a CREATE TABLE, which
+	// is kept even if its definition doesn't parse.
+	createTable *DDLSimple
+}
+
+// ParserOptions centralizes the flags that change how SQL
+// is tokenized and parsed. The zero value corresponds to
+// MySQL's default sql_mode.
+type ParserOptions struct {
+	// AnsiQuotes treats double-quoted text as an identifier
+	// instead of a string, like the ANSI_QUOTES sql_mode.
+	AnsiQuotes bool
+
+	// PipesAsConcat treats || as the string concatenation
+	// operator instead of OR, like the PIPES_AS_CONCAT sql_mode.
+	PipesAsConcat bool
+
+	// Unreserved lists keywords that should be treated as
+	// plain identifiers.
+	Unreserved map[string]bool
+
+	// ServerVersion is the MySQL version, like 80015, that the
+	// version-conditional comments are evaluated against. If
+	// it's 0, they're kept as comments.
+	ServerVersion int
+}
+
+// DefaultParserOptions are the options used by Parse.
+var DefaultParserOptions = ParserOptions{}
+
+// NewParserOptions returns the ParserOptions that correspond
+// to the comma separated list of sql modes in sqlMode. Unknown
+// modes are ignored.
+func NewParserOptions(sqlMode string) ParserOptions {
+	options := DefaultParserOptions
+	for _, mode := range strings.Split(sqlMode, ",") {
+		switch strings.ToUpper(strings.TrimSpace(mode)) {
+		case "ANSI_QUOTES":
+			options.AnsiQuotes = true
+		case "PIPES_AS_CONCAT":
+			options.PipesAsConcat = true
+		case "ANSI":
+			options.AnsiQuotes = true
+			options.PipesAsConcat = true
+		}
+	}
+	return options
 }
 
 func NewStringTokenizer(s string) *Tokenizer {
+	return NewTokenizerWithOptions(s, DefaultParserOptions)
+}
+
+// NewTokenizerWithOptions creates a Tokenizer for s that
+// honors options.
+func NewTokenizerWithOptions(s string, options ParserOptions) *Tokenizer {
 	b := bytes.NewBufferString(s)
-	return &Tokenizer{InStream: b}
+	return &Tokenizer{InStream: b, Options: options, sql: s}
 }
 
 var keywords = map[string]int{
@@ -45,6 +130,69 @@ var keywords = map[string]int{
 	"limit":  LIMIT,
 	"for":    FOR,
 
+	"explain":    EXPLAIN,
+	"partitions": PARTITIONS,
+	"do":         DO,
+	"add":        ADD,
+	"change":     CHANGE,
+	"modify":     MODIFY,
+	"column":     COLUMN,
+	"fulltext":   FULLTEXT,
+	"constraint": CONSTRAINT,
+	"foreign":    FOREIGN,
+	"references": REFERENCES,
+	"check":      CHECK,
+	"generated":  GENERATED,
+	"convert":    CONVERT,
+	"member":     MEMBER,
+	"of":         OF,
+	"at":         AT,
+	"over":       OVER,
+	"unlock":     UNLOCK,
+	"show":       SHOW,
+	"next":       NEXT,
+	"procedure":  PROCEDURE,
+	"reset":      RESET,
+	"replace":    REPLACE,
+	"truncate":   TRUNCATE,
+	"describe":   DESCRIBE,
+	"begin":      BEGIN,
+	"commit":     COMMIT,
+	"rollback":   ROLLBACK,
+	"with":       WITH,
+	"temporary":  TEMPORARY,
+	"analyze":    ANALYZE,
+	"optimize":   OPTIMIZE,
+	"repair":     REPAIR,
+	"flush":      FLUSH,
+	"load":       LOAD,
+	"grant":      GRANT,
+	"revoke":     REVOKE,
+	"database":   DATABASE,
+	"schema":     SCHEMA,
+	"recursive":  RECURSIVE,
+	"rows":       ROWS,
+	"range":      RANGE,
+	"window":     WINDOW,
+	"any":        ANY,
+	"some":       SOME,
+
+	"sql_calc_found_rows": SQL_CALC_FOUND_ROWS,
+	"sql_cache":           SQL_CACHE,
+	"sql_no_cache":        SQL_NO_CACHE,
+	"sql_small_result":    SQL_SMALL_RESULT,
+	"sql_big_result":      SQL_BIG_RESULT,
+	"sql_buffer_result":   SQL_BUFFER_RESULT,
+	"high_priority":       HIGH_PRIORITY,
+
+	"share":  SHARE,
+	"mode":   MODE,
+	"nowait": NOWAIT,
+	"skip":   SKIP,
+	"locked": LOCKED,
+
+	"partition": PARTITION,
+
 	"union":     UNION,
 	"all":       ALL,
 	"minus":     MINUS,
@@ -80,6 +228,8 @@ var keywords = map[string]int{
 	"like":      LIKE,
 	"between":   BETWEEN,
 	"null":      NULL,
+	"true":      TRUE,
+	"false":     FALSE,
 	"asc":       ASC,
 	"desc":      DESC,
 	"values":    VALUES,
@@ -88,6 +238,7 @@ var keywords = map[string]int{
 	"default":   DEFAULT,
 	"set":       SET,
 	"lock":      LOCK,
+	"collate":   COLLATE,
 
 	"create": CREATE,
 	"alter":  ALTER,
@@ -103,6 +254,30 @@ var keywords = map[string]int{
 	"using":  USING,
 }
 
+// StripProxyHeader removes the PROXY protocol header line that some
+// load balancers prepend to the data they forward, like
+// "PROXY TCP4 10.0.0.1 10.0.0.2 56324 3306\r\n". It returns false
+// if sql doesn't start with such a header. It's meant for servers
+// that read queries from client connections: vtgate receives them
+// over RPC and doesn't tokenize them, so it doesn't call it.
+func StripProxyHeader(sql string) (string, bool) {
+	if !strings.HasPrefix(sql, "PROXY ") {
+		return sql, false
+	}
+	end := strings.IndexByte(sql, '\n')
+	if end == -1 {
+		return sql, false
+	}
+	fields := strings.Fields(sql[:end])
+	switch {
+	case len(fields) == 6 && (fields[1] == "TCP4" || fields[1] == "TCP6"):
+	case len(fields) >= 2 && fields[1] == "UNKNOWN":
+	default:
+		return sql, false
+	}
+	return sql[end+1:], true
+}
+
 func (tkn *Tokenizer) Lex(lval *yySymType) int {
 	parseNode := tkn.Scan()
 	for parseNode.Type == COMMENT {
@@ -133,11 +308,16 @@ func (tkn *Tokenizer) Scan() (parseNode *Node) {
 	if tkn.ForceEOF {
 		return NewSimpleParseNode(0, "")
 	}
+	if tkn.pending != nil {
+		parseNode, tkn.pending = tkn.pending, nil
+		return parseNode
+	}
 
 	if tkn.lastChar == 0 {
 		tkn.Next()
 	}
 	tkn.skipBlank()
+	tkn.tokenStart = tkn.position - 1
 	switch ch := tkn.lastChar; {
 	case isLetter(ch):
 		return tkn.scanIdentifier(ID)
@@ -150,8 +330,26 @@ func (tkn *Tokenizer) Scan() (parseNode *Node) {
 		switch ch {
 		case EOFCHAR:
 			return NewSimpleParseNode(0, "")
-		case '=', ',', ';', '(', ')', '+', '*', '%', '&', '|', '^', '~':
-			return NewSimpleParseNode(int(ch), string(ch))
+		case '=', ',', ';', ')', '+', '%', '&', '^', '~':
+			return NewSimpleParseNode(int(ch), string(rune(ch)))
+		case '(':
+			return tkn.scanOpenParen()
+		case '*':
+			if tkn.inVersionComment && tkn.lastChar == '/' {
+				tkn.Next()
+				tkn.inVersionComment = false
+				return tkn.Scan()
+			}
+			return NewSimpleParseNode(int(ch), string(rune(ch)))
+		case '|':
+			if tkn.lastChar != '|' {
+				return NewSimpleParseNode(int(ch), string(rune(ch)))
+			}
+			tkn.Next()
+			if tkn.Options.PipesAsConcat {
+				return NewSimpleParseNode(CONCAT_PIPE, "||")
+			}
+			return NewSimpleParseNode(OR, "or")
 		case '?':
 			tkn.posVarIndex++
 			return NewSimpleParseNode(VALUE_ARG, fmt.Sprintf(":v%d", tkn.posVarIndex))
@@ -168,15 +366,26 @@ func (tkn *Tokenizer) Scan() (parseNode *Node) {
 				return tkn.scanCommentType1("//")
 			case '*':
 				tkn.Next()
+				if tkn.lastChar == '!' {
+					return tkn.scanVersionComment()
+				}
 				return tkn.scanCommentType2()
 			default:
 				return NewSimpleParseNode(int(ch), string(ch))
 			}
 		case '-':
-			if tkn.lastChar == '-' {
+			switch tkn.lastChar {
+			case '-':
 				tkn.Next()
 				return tkn.scanCommentType1("--")
-			} else {
+			case '>':
+				tkn.Next()
+				if tkn.lastChar == '>' {
+					tkn.Next()
+					return NewSimpleParseNode(JSON_UNQUOTE_EXTRACT_OP, "->>")
+				}
+				return NewSimpleParseNode(JSON_EXTRACT_OP, "->")
+			default:
 				return NewSimpleParseNode(int(ch), string(ch))
 			}
 		case '<':
@@ -210,18 +419,49 @@ func (tkn *Tokenizer) Scan() (parseNode *Node) {
 			} else {
 				return NewSimpleParseNode(LEX_ERROR, "unexpected character '!'")
 			}
-		case '\'', '"':
+		case '"':
+			tok := tkn.scanString(ch)
+			if tkn.Options.AnsiQuotes && tok.Type == STRING {
+				tok.Type = ID
+			}
+			return tok
+		case '\'':
 			return tkn.scanString(ch)
 		case '`':
 			tok := tkn.scanString(ch)
 			tok.Type = ID
 			return tok
 		default:
+			if tkn.inExtension {
+				return NewSimpleParseNode(int(ch), string(rune(ch)))
+			}
+			if node := tkn.scanExtension(ch); node != nil {
+				return node
+			}
 			return NewSimpleParseNode(LEX_ERROR, fmt.Sprintf("unexpected character '%c'", ch))
 		}
 	}
 }
 
+// scanOpenParen scans a '(', or the Oracle outer join marker
+// "(+)", which the grammar rejects with a specific error. Telling
+// them apart takes more than one character of lookahead, so a '+'
+// that isn't part of the marker is held back as pending.
+func (tkn *Tokenizer) scanOpenParen() *Node {
+	tkn.skipBlank()
+	if tkn.lastChar != '+' {
+		return NewSimpleParseNode('(', "(")
+	}
+	tkn.Next()
+	tkn.skipBlank()
+	if tkn.lastChar != ')' {
+		tkn.pending = NewSimpleParseNode('+', "+")
+		return NewSimpleParseNode('(', "(")
+	}
+	tkn.Next()
+	return NewSimpleParseNode(OUTER_JOIN_MARKER, "(+)")
+}
+
 func (tkn *Tokenizer) skipBlank() {
 	ch := tkn.lastChar
 	for ch == ' ' || ch == '\n' || ch == '\r' || ch == '\t' {
@@ -236,8 +476,11 @@ func (tkn *Tokenizer) scanIdentifier(Type int) *Node {
 	for tkn.Next(); isLetter(tkn.lastChar) || isDigit(tkn.lastChar); tkn.Next() {
 		buffer.WriteByte(byte(tkn.lastChar))
 	}
+	if !utf8.Valid(buffer.Bytes()) {
+		return NewParseNode(LEX_ERROR, buffer.Bytes())
+	}
 	lowered := bytes.ToLower(buffer.Bytes())
-	if keywordId, found := keywords[string(lowered)]; found {
+	if keywordId, found := keywords[string(lowered)]; found && !tkn.Options.Unreserved[string(lowered)] {
 		return NewParseNode(keywordId, lowered)
 	}
 	return NewParseNode(Type, buffer.Bytes())
@@ -246,7 +489,12 @@ func (tkn *Tokenizer) scanIdentifier(Type int) *Node {
 func (tkn *Tokenizer) scanBindVar(Type int) *Node {
 	buffer := bytes.NewBuffer(make([]byte, 0, 8))
 	buffer.WriteByte(byte(tkn.lastChar))
-	for tkn.Next(); isLetter(tkn.lastChar) || isDigit(tkn.lastChar) || tkn.lastChar == '.'; tkn.Next() {
+	tkn.Next()
+	if tkn.lastChar == '=' {
+		tkn.Next()
+		return NewSimpleParseNode(ASSIGN, ":=")
+	}
+	for ; isLetter(tkn.lastChar) || isDigit(tkn.lastChar) || tkn.lastChar == '.'; tkn.Next() {
 		buffer.WriteByte(byte(tkn.lastChar))
 	}
 	if buffer.Len() == 1 {
@@ -377,6 +625,37 @@ func (tkn *Tokenizer) scanCommentType2() *Node {
 	return NewParseNode(COMMENT, buffer.Bytes())
 }
 
+// scanVersionComment scans a MySQL version-conditional comment
+// like /*!50604 ... */. If Options.ServerVersion is set and is at
+// least the embedded version, the body of the comment is scanned as
+// regular tokens. Otherwise, the whole text is returned as a COMMENT.
+func (tkn *Tokenizer) scanVersionComment() *Node {
+	buffer := bytes.NewBuffer(make([]byte, 0, 8))
+	buffer.WriteString("/*")
+	tkn.ConsumeNext(buffer)
+	version := 0
+	for isDigit(tkn.lastChar) {
+		version = version*10 + int(tkn.lastChar-'0')
+		tkn.ConsumeNext(buffer)
+	}
+	if tkn.Options.ServerVersion != 0 && tkn.Options.ServerVersion >= version && !tkn.inVersionComment {
+		tkn.inVersionComment = true
+		return tkn.Scan()
+	}
+	for {
+		if tkn.lastChar == '*' {
+			tkn.ConsumeNext(buffer)
+			if tkn.lastChar == '/' {
+				tkn.ConsumeNext(buffer)
+				break
+			}
+			continue
+		}
+		tkn.ConsumeNext(buffer)
+	}
+	return NewParseNode(COMMENT, buffer.Bytes())
+}
+
 func (tkn *Tokenizer) ConsumeNext(buffer *bytes.Buffer) {
 	// Never consume an EOF
 	if tkn.lastChar == EOFCHAR {
@@ -399,8 +678,16 @@ func (tkn *Tokenizer) Next() {
 	tkn.position++
 }
 
+// isLetter returns true if ch can start an identifier. Bytes
+// of multibyte UTF-8 sequences are letters, so that identifiers
+// are scanned rune by rune; scanIdentifier rejects the invalid
+// sequences.
 func isLetter(ch uint16) bool {
-	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_' || ch == '@'
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_' || ch == '@' || isMultibyte(ch)
+}
+
+func isMultibyte(ch uint16) bool {
+	return ch >= utf8.RuneSelf && ch != EOFCHAR
 }
 
 func digitVal(ch uint16) int {
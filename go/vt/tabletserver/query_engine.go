@@ -404,8 +404,11 @@ func (qe *QueryEngine) InvalidateForDDL(ddlInvalidate *proto.DDLInvalidate) {
 	if ddlPlan.Action == 0 {
 		panic(NewTabletError(FAIL, "DDL is not understood"))
 	}
+	if ddlPlan.Temporary {
+		return
+	}
 	qe.schemaInfo.DropTable(ddlPlan.TableName)
-	if ddlPlan.Action != sqlparser.DROP { // CREATE, ALTER, RENAME
+	if ddlPlan.Action != sqlparser.DROP { // CREATE, ALTER, RENAME, TRUNCATE
 		qe.schemaInfo.CreateTable(ddlPlan.NewName)
 	}
 }
@@ -437,6 +440,9 @@ func (qe *QueryEngine) execDDL(logStats *SQLQueryStats, ddl string) *mproto.Quer
 		panic(NewTabletErrorSql(FAIL, err))
 	}
 
+	if ddlPlan.Temporary {
+		return result
+	}
 	qe.schemaInfo.DropTable(ddlPlan.TableName)
 	if ddlPlan.Action != sqlparser.DROP { // CREATE, ALTER, RENAME
 		qe.schemaInfo.CreateTable(ddlPlan.NewName)
@@ -805,7 +811,7 @@ func (qe *QueryEngine) fullStreamFetch(logStats *SQLQueryStats, conn PoolConnect
 
 func (qe *QueryEngine) generateFinalSql(parsedQuery *sqlparser.ParsedQuery, bindVars map[string]interface{}, listVars []sqltypes.Value, buildStreamComment []byte) string {
 	bindVars[MAX_RESULT_NAME] = qe.maxResultSize.Get() + 1
-	sql, err := parsedQuery.GenerateQuery(bindVars, listVars)
+	sql, err := parsedQuery.GenerateQuery(bindVars, listVars, nil)
 	if err != nil {
 		panic(NewTabletError(FAIL, "%s", err))
 	}